@@ -35,6 +35,7 @@ func Serve(router *mux.Router, store *storage.Storage, pool *worker.Pool) {
 	sr.HandleFunc("/users/{userID:[0-9]+}", handler.updateUser).Methods(http.MethodPut)
 	sr.HandleFunc("/users/{userID:[0-9]+}", handler.removeUser).Methods(http.MethodDelete)
 	sr.HandleFunc("/users/{userID:[0-9]+}/mark-all-as-read", handler.markUserAsRead).Methods(http.MethodPut)
+	sr.HandleFunc("/users/{userID:[0-9]+}/filter-rules", handler.userFilterRules).Methods(http.MethodGet)
 	sr.HandleFunc("/users/{username}", handler.userByUsername).Methods(http.MethodGet)
 	sr.HandleFunc("/me", handler.currentUser).Methods(http.MethodGet)
 	sr.HandleFunc("/categories", handler.createCategory).Methods(http.MethodPost)
@@ -56,14 +57,30 @@ func Serve(router *mux.Router, store *storage.Storage, pool *worker.Pool) {
 	sr.HandleFunc("/feeds/{feedID}", handler.updateFeed).Methods(http.MethodPut)
 	sr.HandleFunc("/feeds/{feedID}", handler.removeFeed).Methods(http.MethodDelete)
 	sr.HandleFunc("/feeds/{feedID}/icon", handler.feedIcon).Methods(http.MethodGet)
+	sr.HandleFunc("/feeds/{feedID}/history", handler.getFeedHistory).Methods(http.MethodGet)
+	sr.HandleFunc("/feeds/{feedID}/preview", handler.previewFeed).Methods(http.MethodPost)
+	sr.HandleFunc("/feeds/{feedID}/url-history", handler.getFeedURLHistory).Methods(http.MethodGet)
+	sr.HandleFunc("/feeds/{feedID}/merge/{targetFeedID}", handler.mergeFeed).Methods(http.MethodPut)
 	sr.HandleFunc("/feeds/{feedID}/mark-all-as-read", handler.markFeedAsRead).Methods(http.MethodPut)
 	sr.HandleFunc("/export", handler.exportFeeds).Methods(http.MethodGet)
 	sr.HandleFunc("/import", handler.importFeeds).Methods(http.MethodPost)
 	sr.HandleFunc("/feeds/{feedID}/entries", handler.getFeedEntries).Methods(http.MethodGet)
 	sr.HandleFunc("/feeds/{feedID}/entries/{entryID}", handler.getFeedEntry).Methods(http.MethodGet)
+	sr.HandleFunc("/automation-rules", handler.getAutomationRules).Methods(http.MethodGet)
+	sr.HandleFunc("/automation-rules", handler.createAutomationRule).Methods(http.MethodPost)
+	sr.HandleFunc("/automation-rules/{ruleID}", handler.updateAutomationRule).Methods(http.MethodPut)
+	sr.HandleFunc("/automation-rules/{ruleID}", handler.removeAutomationRule).Methods(http.MethodDelete)
+	sr.HandleFunc("/saved-searches", handler.getSavedSearches).Methods(http.MethodGet)
+	sr.HandleFunc("/saved-searches", handler.createSavedSearch).Methods(http.MethodPost)
+	sr.HandleFunc("/saved-searches/{savedSearchID}", handler.updateSavedSearch).Methods(http.MethodPut)
+	sr.HandleFunc("/saved-searches/{savedSearchID}", handler.removeSavedSearch).Methods(http.MethodDelete)
+	sr.HandleFunc("/saved-searches/{savedSearchID}/mark-all-as-read", handler.markSavedSearchAsRead).Methods(http.MethodPut)
+	sr.HandleFunc("/saved-searches/{savedSearchID}/entries", handler.getSavedSearchEntries).Methods(http.MethodGet)
 	sr.HandleFunc("/entries", handler.getEntries).Methods(http.MethodGet)
 	sr.HandleFunc("/entries", handler.setEntryStatus).Methods(http.MethodPut)
 	sr.HandleFunc("/entries/{entryID}", handler.getEntry).Methods(http.MethodGet)
 	sr.HandleFunc("/entries/{entryID}/bookmark", handler.toggleBookmark).Methods(http.MethodPut)
+	sr.HandleFunc("/entries/{entryID}/read-later", handler.toggleReadLater).Methods(http.MethodPut)
+	sr.HandleFunc("/entries/{entryID}/revisions", handler.getEntryRevisions).Methods(http.MethodGet)
 	sr.HandleFunc("/entries/{entryID}/fetch-content", handler.fetchContent).Methods(http.MethodGet)
 }
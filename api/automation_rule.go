@@ -0,0 +1,107 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package api // import "miniflux.app/api"
+
+import (
+	json_parser "encoding/json"
+	"net/http"
+
+	"miniflux.app/http/request"
+	"miniflux.app/http/response/json"
+	"miniflux.app/model"
+	"miniflux.app/validator"
+)
+
+func (h *handler) getAutomationRules(w http.ResponseWriter, r *http.Request) {
+	rules, err := h.store.AutomationRules(request.UserID(r))
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	json.OK(w, r, rules)
+}
+
+func (h *handler) createAutomationRule(w http.ResponseWriter, r *http.Request) {
+	userID := request.UserID(r)
+
+	var ruleRequest model.AutomationRuleRequest
+	if err := json_parser.NewDecoder(r.Body).Decode(&ruleRequest); err != nil {
+		json.BadRequest(w, r, err)
+		return
+	}
+
+	if validationErr := validator.ValidateAutomationRule(h.store, userID, 0, &ruleRequest); validationErr != nil {
+		json.BadRequest(w, r, validationErr.Error())
+		return
+	}
+
+	rule := &model.AutomationRule{UserID: userID}
+	ruleRequest.Patch(rule)
+	if err := h.store.CreateAutomationRule(rule); err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	json.Created(w, r, rule)
+}
+
+func (h *handler) updateAutomationRule(w http.ResponseWriter, r *http.Request) {
+	userID := request.UserID(r)
+	ruleID := request.RouteInt64Param(r, "ruleID")
+
+	rule, err := h.store.AutomationRule(userID, ruleID)
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	if rule == nil {
+		json.NotFound(w, r)
+		return
+	}
+
+	var ruleRequest model.AutomationRuleRequest
+	if err := json_parser.NewDecoder(r.Body).Decode(&ruleRequest); err != nil {
+		json.BadRequest(w, r, err)
+		return
+	}
+
+	if validationErr := validator.ValidateAutomationRule(h.store, userID, rule.ID, &ruleRequest); validationErr != nil {
+		json.BadRequest(w, r, validationErr.Error())
+		return
+	}
+
+	ruleRequest.Patch(rule)
+	if err := h.store.UpdateAutomationRule(rule); err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	json.Created(w, r, rule)
+}
+
+func (h *handler) removeAutomationRule(w http.ResponseWriter, r *http.Request) {
+	userID := request.UserID(r)
+	ruleID := request.RouteInt64Param(r, "ruleID")
+
+	rule, err := h.store.AutomationRule(userID, ruleID)
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	if rule == nil {
+		json.NotFound(w, r)
+		return
+	}
+
+	if err := h.store.RemoveAutomationRule(userID, rule.ID); err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	json.NoContent(w, r)
+}
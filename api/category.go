@@ -13,6 +13,7 @@ import (
 	"miniflux.app/http/response/json"
 	"miniflux.app/model"
 	"miniflux.app/validator"
+	"miniflux.app/worker"
 )
 
 func (h *handler) createCategory(w http.ResponseWriter, r *http.Request) {
@@ -134,9 +135,7 @@ func (h *handler) refreshCategory(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	go func() {
-		h.pool.Push(jobs)
-	}()
+	h.pool.Push(jobs, worker.PriorityInteractive)
 
 	json.NoContent(w, r)
 }
@@ -18,6 +18,7 @@ import (
 	"miniflux.app/model"
 	"miniflux.app/proxy"
 	"miniflux.app/reader/processor"
+	"miniflux.app/search"
 	"miniflux.app/storage"
 	"miniflux.app/url"
 	"miniflux.app/validator"
@@ -35,14 +36,14 @@ func (h *handler) getEntryFromBuilder(w http.ResponseWriter, r *http.Request, b
 		return
 	}
 
-	entry.Content = proxy.AbsoluteProxyRewriter(h.router, r.Host, entry.Content)
+	entry.Content = proxy.AbsoluteProxyRewriter(h.router, r.Host, entry.Content, entry.Feed.EffectiveProxyName())
 	proxyOption := config.Opts.ProxyOption()
 
 	for i := range entry.Enclosures {
 		if proxyOption == "all" || proxyOption != "none" && !url.IsHTTPS(entry.Enclosures[i].URL) {
 			for _, mediaType := range config.Opts.ProxyMediaTypes() {
 				if strings.HasPrefix(entry.Enclosures[i].MimeType, mediaType+"/") {
-					entry.Enclosures[i].URL = proxy.AbsoluteProxifyURL(h.router, r.Host, entry.Enclosures[i].URL)
+					entry.Enclosures[i].URL = proxy.AbsoluteProxifyURL(h.router, r.Host, entry.Enclosures[i].URL, entry.Feed.EffectiveProxyName())
 					break
 				}
 			}
@@ -84,19 +85,38 @@ func (h *handler) getEntry(w http.ResponseWriter, r *http.Request) {
 
 func (h *handler) getFeedEntries(w http.ResponseWriter, r *http.Request) {
 	feedID := request.RouteInt64Param(r, "feedID")
-	h.findEntries(w, r, feedID, 0)
+	h.findEntries(w, r, feedID, 0, "")
 }
 
 func (h *handler) getCategoryEntries(w http.ResponseWriter, r *http.Request) {
 	categoryID := request.RouteInt64Param(r, "categoryID")
-	h.findEntries(w, r, 0, categoryID)
+	h.findEntries(w, r, 0, categoryID, "")
 }
 
 func (h *handler) getEntries(w http.ResponseWriter, r *http.Request) {
-	h.findEntries(w, r, 0, 0)
+	h.findEntries(w, r, 0, 0, "")
 }
 
-func (h *handler) findEntries(w http.ResponseWriter, r *http.Request, feedID int64, categoryID int64) {
+func (h *handler) getSavedSearchEntries(w http.ResponseWriter, r *http.Request) {
+	savedSearch, err := h.store.SavedSearch(request.UserID(r), request.RouteInt64Param(r, "savedSearchID"))
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	if savedSearch == nil {
+		json.NotFound(w, r)
+		return
+	}
+
+	h.findEntries(w, r, 0, 0, savedSearch.Query)
+}
+
+// findEntries returns the entries matching the request filters, savedQuery is the query of a saved search.
+//
+// The pages are linked with cursors, except for a full-text search: its entries are sorted by relevance,
+// a score computed for each query that a cursor can't resume from, so the offset must be used instead.
+func (h *handler) findEntries(w http.ResponseWriter, r *http.Request, feedID int64, categoryID int64, savedQuery string) {
 	statuses := request.QueryStringParamList(r, "status")
 	for _, status := range statuses {
 		if err := validator.ValidateEntryStatus(status); err != nil {
@@ -124,6 +144,34 @@ func (h *handler) findEntries(w http.ResponseWriter, r *http.Request, feedID int
 		return
 	}
 
+	fullTextSearch := search.Parse(request.QueryStringParam(r, "search", "")).HasFullText()
+
+	var cursor *model.EntryCursor
+	if token := request.QueryStringParam(r, "cursor", ""); token != "" {
+		var err error
+		if cursor, err = model.DecodeEntryCursor(token); err != nil {
+			json.BadRequest(w, r, err)
+			return
+		}
+
+		if err := validator.ValidateEntryCursor(cursor); err != nil {
+			json.BadRequest(w, r, err)
+			return
+		}
+
+		if offset > 0 {
+			json.BadRequest(w, r, errors.New("The cursor and the offset cannot be used together"))
+			return
+		}
+
+		if fullTextSearch {
+			json.BadRequest(w, r, errors.New("The cursor cannot be used with a full-text search"))
+			return
+		}
+
+		order, direction = cursor.Order, cursor.Direction
+	}
+
 	userID := request.UserID(r)
 	categoryID = request.QueryInt64Param(r, "category_id", categoryID)
 	if categoryID > 0 && !h.store.CategoryIDExists(userID, categoryID) {
@@ -140,33 +188,68 @@ func (h *handler) findEntries(w http.ResponseWriter, r *http.Request, feedID int
 	tags := request.QueryStringParamList(r, "tags")
 
 	builder := h.store.NewEntryQueryBuilder(userID)
+	builder.WithSearchQuery(savedQuery)
 	builder.WithFeedID(feedID)
 	builder.WithCategoryID(categoryID)
 	builder.WithStatuses(statuses)
-	builder.WithOrder(order)
-	builder.WithDirection(direction)
 	builder.WithOffset(offset)
-	builder.WithLimit(limit)
 	builder.WithTags(tags)
 	configureFilters(builder, r)
 
-	entries, err := builder.GetEntries()
+	// The order is set after the filters, a full-text search keeps the relevance order set by the search query.
+	if !fullTextSearch {
+		builder.WithOrder(order)
+		builder.WithDirection(direction)
+	}
+
+	// The total doesn't depend on the cursor position.
+	count, err := builder.CountEntries()
 	if err != nil {
 		json.ServerError(w, r, err)
 		return
 	}
 
-	count, err := builder.CountEntries()
+	if cursor != nil {
+		builder.WithCursor(cursor)
+	}
+
+	// One more entry is fetched to know if there is another page.
+	if limit > 0 {
+		builder.WithLimit(limit + 1)
+	}
+
+	entries, err := builder.GetEntries()
 	if err != nil {
 		json.ServerError(w, r, err)
 		return
 	}
 
+	backward := cursor != nil && cursor.Backward
+	hasMore := limit > 0 && len(entries) > limit
+	if hasMore {
+		if backward {
+			entries = entries[1:]
+		} else {
+			entries = entries[:limit]
+		}
+	}
+
 	for i := range entries {
-		entries[i].Content = proxy.AbsoluteProxyRewriter(h.router, r.Host, entries[i].Content)
+		entries[i].Content = proxy.AbsoluteProxyRewriter(h.router, r.Host, entries[i].Content, entries[i].Feed.EffectiveProxyName())
 	}
 
-	json.OK(w, r, &entriesResponse{Total: count, Entries: entries})
+	response := &entriesResponse{Total: count, Entries: entries}
+	if len(entries) > 0 && !fullTextSearch {
+		if hasMore || backward {
+			response.NextCursor = model.NewEntryCursor(entries[len(entries)-1], order, direction, false).Encode()
+		}
+
+		if (backward && hasMore) || (!backward && (cursor != nil || offset > 0)) {
+			response.PrevCursor = model.NewEntryCursor(entries[0], order, direction, true).Encode()
+		}
+	}
+
+	json.OK(w, r, response)
 }
 
 func (h *handler) setEntryStatus(w http.ResponseWriter, r *http.Request) {
@@ -199,6 +282,44 @@ func (h *handler) toggleBookmark(w http.ResponseWriter, r *http.Request) {
 	json.NoContent(w, r)
 }
 
+func (h *handler) toggleReadLater(w http.ResponseWriter, r *http.Request) {
+	entryID := request.RouteInt64Param(r, "entryID")
+	if err := h.store.ToggleReadLater(request.UserID(r), entryID); err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	json.NoContent(w, r)
+}
+
+func (h *handler) getEntryRevisions(w http.ResponseWriter, r *http.Request) {
+	userID := request.UserID(r)
+	entryID := request.RouteInt64Param(r, "entryID")
+
+	builder := h.store.NewEntryQueryBuilder(userID)
+	builder.WithEntryID(entryID)
+	builder.WithoutStatus(model.EntryStatusRemoved)
+
+	entry, err := builder.GetEntry()
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	if entry == nil {
+		json.NotFound(w, r)
+		return
+	}
+
+	revisions, err := h.store.EntryRevisions(userID, entry.ID)
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	json.OK(w, r, revisions)
+}
+
 func (h *handler) fetchContent(w http.ResponseWriter, r *http.Request) {
 	loggedUserID := request.UserID(r)
 	entryID := request.RouteInt64Param(r, "entryID")
@@ -283,8 +404,17 @@ func configureFilters(builder *storage.EntryQueryBuilder, r *http.Request) {
 		}
 	}
 
+	if request.HasQueryParam(r, "read_later") {
+		readLater, err := strconv.ParseBool(r.URL.Query().Get("read_later"))
+		if err == nil {
+			builder.WithReadLater(readLater)
+		}
+	}
+
 	searchQuery := request.QueryStringParam(r, "search", "")
 	if searchQuery != "" {
 		builder.WithSearchQuery(searchQuery)
 	}
+
+	builder.WithLanguage(request.QueryStringParam(r, "language", ""))
 }
@@ -6,14 +6,17 @@ package api // import "miniflux.app/api"
 
 import (
 	json_parser "encoding/json"
+	"errors"
 	"net/http"
 	"time"
 
+	"miniflux.app/config"
 	"miniflux.app/http/request"
 	"miniflux.app/http/response/json"
 	"miniflux.app/model"
 	feedHandler "miniflux.app/reader/handler"
 	"miniflux.app/validator"
+	"miniflux.app/worker"
 )
 
 func (h *handler) createFeed(w http.ResponseWriter, r *http.Request) {
@@ -65,9 +68,7 @@ func (h *handler) refreshAllFeeds(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	go func() {
-		h.pool.Push(jobs)
-	}()
+	h.pool.Push(jobs, worker.PriorityInteractive)
 
 	json.NoContent(w, r)
 }
@@ -93,17 +94,26 @@ func (h *handler) updateFeed(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if validationErr := validator.ValidateFeedModification(h.store, userID, &feedModificationRequest); validationErr != nil {
+	if validationErr := validator.ValidateFeedModification(h.store, userID, feedID, &feedModificationRequest); validationErr != nil {
 		json.BadRequest(w, r, validationErr.Error())
 		return
 	}
 
+	previousURL := originalFeed.FeedURL
 	feedModificationRequest.Patch(originalFeed)
 	if err := h.store.UpdateFeed(originalFeed); err != nil {
 		json.ServerError(w, r, err)
 		return
 	}
 
+	if originalFeed.FeedURL != previousURL {
+		change := &model.FeedURLChange{FeedID: feedID, UserID: userID, PreviousURL: previousURL, NewURL: originalFeed.FeedURL, Reason: model.FeedURLChangeReasonUser}
+		if err := h.store.CreateFeedURLChange(change); err != nil {
+			json.ServerError(w, r, err)
+			return
+		}
+	}
+
 	originalFeed, err = h.store.FeedByID(userID, feedID)
 	if err != nil {
 		json.ServerError(w, r, err)
@@ -196,6 +206,100 @@ func (h *handler) getFeed(w http.ResponseWriter, r *http.Request) {
 	json.OK(w, r, feed)
 }
 
+func (h *handler) getFeedHistory(w http.ResponseWriter, r *http.Request) {
+	userID := request.UserID(r)
+	feedID := request.RouteInt64Param(r, "feedID")
+
+	if !h.store.FeedExists(userID, feedID) {
+		json.NotFound(w, r)
+		return
+	}
+
+	limit := request.QueryIntParam(r, "limit", config.Opts.CleanupRefreshHistoryPerFeed())
+	if limit <= 0 {
+		json.BadRequest(w, r, errors.New("Invalid limit"))
+		return
+	}
+
+	refreshes, err := h.store.FeedRefreshes(userID, feedID, limit)
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	json.OK(w, r, refreshes)
+}
+
+func (h *handler) getFeedURLHistory(w http.ResponseWriter, r *http.Request) {
+	userID := request.UserID(r)
+	feedID := request.RouteInt64Param(r, "feedID")
+
+	if !h.store.FeedExists(userID, feedID) {
+		json.NotFound(w, r)
+		return
+	}
+
+	changes, err := h.store.FeedURLChanges(userID, feedID)
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	json.OK(w, r, changes)
+}
+
+func (h *handler) mergeFeed(w http.ResponseWriter, r *http.Request) {
+	userID := request.UserID(r)
+	feedID := request.RouteInt64Param(r, "feedID")
+	targetFeedID := request.RouteInt64Param(r, "targetFeedID")
+
+	if !h.store.FeedExists(userID, feedID) || !h.store.FeedExists(userID, targetFeedID) {
+		json.NotFound(w, r)
+		return
+	}
+
+	if feedID == targetFeedID {
+		json.BadRequest(w, r, errors.New("A feed cannot be merged into itself"))
+		return
+	}
+
+	if err := h.store.MergeFeeds(userID, feedID, targetFeedID); err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	json.NoContent(w, r)
+}
+
+func (h *handler) previewFeed(w http.ResponseWriter, r *http.Request) {
+	userID := request.UserID(r)
+	feedID := request.RouteInt64Param(r, "feedID")
+
+	var feedPreviewRequest model.FeedPreviewRequest
+	if err := json_parser.NewDecoder(r.Body).Decode(&feedPreviewRequest); err != nil {
+		json.BadRequest(w, r, err)
+		return
+	}
+
+	if !h.store.FeedExists(userID, feedID) {
+		json.NotFound(w, r)
+		return
+	}
+
+	if validationErr := validator.ValidateFeedPreview(&feedPreviewRequest); validationErr != nil {
+		json.BadRequest(w, r, validationErr.Error())
+		return
+	}
+
+	preview, err := feedHandler.PreviewFeed(h.store, userID, feedID, &feedPreviewRequest)
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	json.OK(w, r, preview)
+}
+
 func (h *handler) removeFeed(w http.ResponseWriter, r *http.Request) {
 	feedID := request.RouteInt64Param(r, "feedID")
 	userID := request.UserID(r)
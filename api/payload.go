@@ -15,8 +15,10 @@ type feedIconResponse struct {
 }
 
 type entriesResponse struct {
-	Total   int           `json:"total"`
-	Entries model.Entries `json:"entries"`
+	Total      int           `json:"total"`
+	Entries    model.Entries `json:"entries"`
+	NextCursor string        `json:"next_cursor,omitempty"`
+	PrevCursor string        `json:"prev_cursor,omitempty"`
 }
 
 type feedCreationResponse struct {
@@ -0,0 +1,126 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package api // import "miniflux.app/api"
+
+import (
+	json_parser "encoding/json"
+	"net/http"
+	"time"
+
+	"miniflux.app/http/request"
+	"miniflux.app/http/response/json"
+	"miniflux.app/model"
+	"miniflux.app/validator"
+)
+
+func (h *handler) getSavedSearches(w http.ResponseWriter, r *http.Request) {
+	savedSearches, err := h.store.SavedSearchesWithUnreadCount(request.UserID(r))
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	json.OK(w, r, savedSearches)
+}
+
+func (h *handler) createSavedSearch(w http.ResponseWriter, r *http.Request) {
+	userID := request.UserID(r)
+
+	var savedSearchRequest model.SavedSearchRequest
+	if err := json_parser.NewDecoder(r.Body).Decode(&savedSearchRequest); err != nil {
+		json.BadRequest(w, r, err)
+		return
+	}
+
+	if validationErr := validator.ValidateSavedSearch(h.store, userID, 0, &savedSearchRequest); validationErr != nil {
+		json.BadRequest(w, r, validationErr.Error())
+		return
+	}
+
+	savedSearch := &model.SavedSearch{UserID: userID}
+	savedSearchRequest.Patch(savedSearch)
+	if err := h.store.CreateSavedSearch(savedSearch); err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	json.Created(w, r, savedSearch)
+}
+
+func (h *handler) updateSavedSearch(w http.ResponseWriter, r *http.Request) {
+	userID := request.UserID(r)
+
+	savedSearch, err := h.store.SavedSearch(userID, request.RouteInt64Param(r, "savedSearchID"))
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	if savedSearch == nil {
+		json.NotFound(w, r)
+		return
+	}
+
+	var savedSearchRequest model.SavedSearchRequest
+	if err := json_parser.NewDecoder(r.Body).Decode(&savedSearchRequest); err != nil {
+		json.BadRequest(w, r, err)
+		return
+	}
+
+	if validationErr := validator.ValidateSavedSearch(h.store, userID, savedSearch.ID, &savedSearchRequest); validationErr != nil {
+		json.BadRequest(w, r, validationErr.Error())
+		return
+	}
+
+	savedSearchRequest.Patch(savedSearch)
+	if err := h.store.UpdateSavedSearch(savedSearch); err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	json.Created(w, r, savedSearch)
+}
+
+func (h *handler) removeSavedSearch(w http.ResponseWriter, r *http.Request) {
+	userID := request.UserID(r)
+
+	savedSearch, err := h.store.SavedSearch(userID, request.RouteInt64Param(r, "savedSearchID"))
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	if savedSearch == nil {
+		json.NotFound(w, r)
+		return
+	}
+
+	if err := h.store.RemoveSavedSearch(userID, savedSearch.ID); err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	json.NoContent(w, r)
+}
+
+func (h *handler) markSavedSearchAsRead(w http.ResponseWriter, r *http.Request) {
+	savedSearch, err := h.store.SavedSearch(request.UserID(r), request.RouteInt64Param(r, "savedSearchID"))
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	if savedSearch == nil {
+		json.NotFound(w, r)
+		return
+	}
+
+	if err := h.store.MarkSavedSearchAsRead(savedSearch, time.Now()); err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	json.NoContent(w, r)
+}
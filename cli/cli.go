@@ -25,6 +25,7 @@ const (
 	flagCreateAdminHelp     = "Create admin user"
 	flagResetPasswordHelp   = "Reset user password"
 	flagResetFeedErrorsHelp = "Clear all feed errors for all users"
+	flagPurgeRemovedHelp    = "Delete the content of the entries removed for more than CLEANUP_PURGE_REMOVED_DAYS days"
 	flagDebugModeHelp       = "Show debug logs"
 	flagConfigFileHelp      = "Load configuration file"
 	flagConfigDumpHelp      = "Print parsed configuration values"
@@ -42,6 +43,7 @@ func Parse() {
 		flagCreateAdmin     bool
 		flagResetPassword   bool
 		flagResetFeedErrors bool
+		flagPurgeRemoved    bool
 		flagDebugMode       bool
 		flagConfigFile      string
 		flagConfigDump      bool
@@ -57,6 +59,7 @@ func Parse() {
 	flag.BoolVar(&flagCreateAdmin, "create-admin", false, flagCreateAdminHelp)
 	flag.BoolVar(&flagResetPassword, "reset-password", false, flagResetPasswordHelp)
 	flag.BoolVar(&flagResetFeedErrors, "reset-feed-errors", false, flagResetFeedErrorsHelp)
+	flag.BoolVar(&flagPurgeRemoved, "purge-removed-entries", false, flagPurgeRemovedHelp)
 	flag.BoolVar(&flagDebugMode, "debug", false, flagDebugModeHelp)
 	flag.StringVar(&flagConfigFile, "config-file", "", flagConfigFileHelp)
 	flag.StringVar(&flagConfigFile, "c", "", flagConfigFileHelp)
@@ -162,6 +165,11 @@ func Parse() {
 		return
 	}
 
+	if flagPurgeRemoved {
+		purgeRemovedEntries(store)
+		return
+	}
+
 	if flagCreateAdmin {
 		createAdmin(store)
 		return
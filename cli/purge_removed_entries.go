@@ -0,0 +1,23 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package cli // import "miniflux.app/cli"
+
+import (
+	"fmt"
+	"os"
+
+	"miniflux.app/config"
+	"miniflux.app/storage"
+)
+
+func purgeRemovedEntries(store *storage.Storage) {
+	fmt.Printf("Purging the entries removed for more than %d days\n", config.Opts.CleanupPurgeRemovedDays())
+	count, err := store.PurgeRemovedEntries(config.Opts.CleanupPurgeRemovedDays(), config.Opts.CleanupArchiveBatchSize())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("%d entries purged\n", count)
+}
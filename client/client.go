@@ -148,6 +148,160 @@ func (c *Client) MarkAllAsRead(userID int64) error {
 	return err
 }
 
+// UserFilterRules gets the global filter rules of a user with the number of entries they matched.
+func (c *Client) UserFilterRules(userID int64) (FilterRules, error) {
+	body, err := c.request.Get(fmt.Sprintf("/v1/users/%d/filter-rules", userID))
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var filterRules FilterRules
+	decoder := json.NewDecoder(body)
+	if err := decoder.Decode(&filterRules); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%v)", err)
+	}
+
+	return filterRules, nil
+}
+
+// AutomationRules gets the automation rules of the current user.
+func (c *Client) AutomationRules() (AutomationRules, error) {
+	body, err := c.request.Get("/v1/automation-rules")
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var rules AutomationRules
+	decoder := json.NewDecoder(body)
+	if err := decoder.Decode(&rules); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%v)", err)
+	}
+
+	return rules, nil
+}
+
+// CreateAutomationRule creates a new automation rule.
+func (c *Client) CreateAutomationRule(ruleRequest *AutomationRuleRequest) (*AutomationRule, error) {
+	body, err := c.request.Post("/v1/automation-rules", ruleRequest)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var rule *AutomationRule
+	decoder := json.NewDecoder(body)
+	if err := decoder.Decode(&rule); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%v)", err)
+	}
+
+	return rule, nil
+}
+
+// UpdateAutomationRule updates an automation rule.
+func (c *Client) UpdateAutomationRule(ruleID int64, ruleRequest *AutomationRuleRequest) (*AutomationRule, error) {
+	body, err := c.request.Put(fmt.Sprintf("/v1/automation-rules/%d", ruleID), ruleRequest)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var rule *AutomationRule
+	decoder := json.NewDecoder(body)
+	if err := decoder.Decode(&rule); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%v)", err)
+	}
+
+	return rule, nil
+}
+
+// DeleteAutomationRule removes an automation rule.
+func (c *Client) DeleteAutomationRule(ruleID int64) error {
+	return c.request.Delete(fmt.Sprintf("/v1/automation-rules/%d", ruleID))
+}
+
+// SavedSearches gets the saved searches of the current user with their unread count.
+func (c *Client) SavedSearches() (SavedSearches, error) {
+	body, err := c.request.Get("/v1/saved-searches")
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var savedSearches SavedSearches
+	decoder := json.NewDecoder(body)
+	if err := decoder.Decode(&savedSearches); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%v)", err)
+	}
+
+	return savedSearches, nil
+}
+
+// CreateSavedSearch saves a search query.
+func (c *Client) CreateSavedSearch(savedSearchRequest *SavedSearchRequest) (*SavedSearch, error) {
+	body, err := c.request.Post("/v1/saved-searches", savedSearchRequest)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var savedSearch *SavedSearch
+	decoder := json.NewDecoder(body)
+	if err := decoder.Decode(&savedSearch); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%v)", err)
+	}
+
+	return savedSearch, nil
+}
+
+// UpdateSavedSearch updates a saved search.
+func (c *Client) UpdateSavedSearch(savedSearchID int64, savedSearchRequest *SavedSearchRequest) (*SavedSearch, error) {
+	body, err := c.request.Put(fmt.Sprintf("/v1/saved-searches/%d", savedSearchID), savedSearchRequest)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var savedSearch *SavedSearch
+	decoder := json.NewDecoder(body)
+	if err := decoder.Decode(&savedSearch); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%v)", err)
+	}
+
+	return savedSearch, nil
+}
+
+// DeleteSavedSearch removes a saved search.
+func (c *Client) DeleteSavedSearch(savedSearchID int64) error {
+	return c.request.Delete(fmt.Sprintf("/v1/saved-searches/%d", savedSearchID))
+}
+
+// MarkSavedSearchAsRead marks all unread entries matching a saved search as read.
+func (c *Client) MarkSavedSearchAsRead(savedSearchID int64) error {
+	_, err := c.request.Put(fmt.Sprintf("/v1/saved-searches/%d/mark-all-as-read", savedSearchID), nil)
+	return err
+}
+
+// SavedSearchEntries fetches the entries matching a saved search.
+func (c *Client) SavedSearchEntries(savedSearchID int64, filter *Filter) (*EntryResultSet, error) {
+	path := buildFilterQueryString(fmt.Sprintf("/v1/saved-searches/%d/entries", savedSearchID), filter)
+
+	body, err := c.request.Get(path)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var result EntryResultSet
+	decoder := json.NewDecoder(body)
+	if err := decoder.Decode(&result); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%v)", err)
+	}
+
+	return &result, nil
+}
+
 // Discover try to find subscriptions from a website.
 func (c *Client) Discover(url string) (Subscriptions, error) {
 	body, err := c.request.Post("/v1/discover", map[string]string{"url": url})
@@ -387,6 +541,62 @@ func (c *Client) FeedIcon(feedID int64) (*FeedIcon, error) {
 	return feedIcon, nil
 }
 
+// PreviewFeed fetches and processes a feed with the given rules, nothing is saved.
+func (c *Client) PreviewFeed(feedID int64, feedPreviewRequest *FeedPreviewRequest) (*FeedPreview, error) {
+	body, err := c.request.Post(fmt.Sprintf("/v1/feeds/%d/preview", feedID), feedPreviewRequest)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var preview *FeedPreview
+	if err := json.NewDecoder(body).Decode(&preview); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%v)", err)
+	}
+
+	return preview, nil
+}
+
+// FeedHistory gets the most recent refresh attempts of a feed.
+func (c *Client) FeedHistory(feedID int64) (FeedRefreshes, error) {
+	body, err := c.request.Get(fmt.Sprintf("/v1/feeds/%d/history", feedID))
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var refreshes FeedRefreshes
+	decoder := json.NewDecoder(body)
+	if err := decoder.Decode(&refreshes); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%v)", err)
+	}
+
+	return refreshes, nil
+}
+
+// FeedURLHistory gets the URL changes of a feed, the most recent first.
+func (c *Client) FeedURLHistory(feedID int64) (FeedURLChanges, error) {
+	body, err := c.request.Get(fmt.Sprintf("/v1/feeds/%d/url-history", feedID))
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var changes FeedURLChanges
+	decoder := json.NewDecoder(body)
+	if err := decoder.Decode(&changes); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%v)", err)
+	}
+
+	return changes, nil
+}
+
+// MergeFeed moves the entries of a feed to the target feed and removes it.
+func (c *Client) MergeFeed(feedID, targetFeedID int64) error {
+	_, err := c.request.Put(fmt.Sprintf("/v1/feeds/%d/merge/%d", feedID, targetFeedID), nil)
+	return err
+}
+
 // FeedEntry gets a single feed entry.
 func (c *Client) FeedEntry(feedID, entryID int64) (*Entry, error) {
 	body, err := c.request.Get(fmt.Sprintf("/v1/feeds/%d/entries/%d", feedID, entryID))
@@ -512,6 +722,29 @@ func (c *Client) ToggleBookmark(entryID int64) error {
 	return err
 }
 
+// ToggleReadLater toggles entry read later value.
+func (c *Client) ToggleReadLater(entryID int64) error {
+	_, err := c.request.Put(fmt.Sprintf("/v1/entries/%d/read-later", entryID), nil)
+	return err
+}
+
+// EntryRevisions gets the previous versions of an entry.
+func (c *Client) EntryRevisions(entryID int64) (EntryRevisions, error) {
+	body, err := c.request.Get(fmt.Sprintf("/v1/entries/%d/revisions", entryID))
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var revisions EntryRevisions
+	decoder := json.NewDecoder(body)
+	if err := decoder.Decode(&revisions); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%v)", err)
+	}
+
+	return revisions, nil
+}
+
 // FetchCounters
 func (c *Client) FetchCounters() (*FeedCounters, error) {
 	body, err := c.request.Get("/v1/feeds/counters")
@@ -573,6 +806,10 @@ func buildFilterQueryString(path string, filter *Filter) string {
 			values.Set("starred", filter.Starred)
 		}
 
+		if filter.ReadLater != "" {
+			values.Set("read_later", filter.ReadLater)
+		}
+
 		if filter.Search != "" {
 			values.Set("search", filter.Search)
 		}
@@ -585,6 +822,14 @@ func buildFilterQueryString(path string, filter *Filter) string {
 			values.Set("feed_id", strconv.FormatInt(filter.FeedID, 10))
 		}
 
+		if filter.Language != "" {
+			values.Set("language", filter.Language)
+		}
+
+		if filter.Cursor != "" {
+			values.Set("cursor", filter.Cursor)
+		}
+
 		for _, status := range filter.Statuses {
 			values.Add("status", status)
 		}
@@ -29,5 +29,15 @@ This one discover subscriptions on a website:
 		return
 	}
 	fmt.Println(subscriptions)
+
+This one iterates over all unread entries, page by page:
+
+	iterator := client.IterateEntries(&miniflux.Filter{Status: miniflux.EntryStatusUnread, Limit: 100})
+	for iterator.Next() {
+		fmt.Println(iterator.Entry().Title)
+	}
+	if err := iterator.Err(); err != nil {
+		fmt.Println(err)
+	}
 */
 package client // import "miniflux.app/client"
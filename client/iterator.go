@@ -0,0 +1,84 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package client // import "miniflux.app/client"
+
+// EntryIterator fetches the entries page by page with the pagination cursors.
+//
+// The full-text searches have no cursor, their pages are fetched with the offset.
+type EntryIterator struct {
+	fetch   func(filter *Filter) (*EntryResultSet, error)
+	filter  Filter
+	entries Entries
+	entry   *Entry
+	done    bool
+	err     error
+}
+
+func newEntryIterator(fetch func(filter *Filter) (*EntryResultSet, error), filter *Filter) *EntryIterator {
+	iterator := &EntryIterator{fetch: fetch}
+	if filter != nil {
+		iterator.filter = *filter
+	}
+	return iterator
+}
+
+// Next advances to the next entry, it returns false when there are no more entries or an error occurred.
+func (i *EntryIterator) Next() bool {
+	for len(i.entries) == 0 {
+		if i.done || i.err != nil {
+			return false
+		}
+
+		result, err := i.fetch(&i.filter)
+		if err != nil {
+			i.err = err
+			return false
+		}
+
+		i.entries = result.Entries
+		switch {
+		case result.NextCursor != "":
+			i.filter.Cursor = result.NextCursor
+			i.filter.Offset = 0
+		case i.filter.Cursor == "" && i.filter.Limit > 0 && len(result.Entries) == i.filter.Limit && i.filter.Offset+i.filter.Limit < result.Total:
+			i.filter.Offset += i.filter.Limit
+		default:
+			i.done = true
+		}
+	}
+
+	i.entry = i.entries[0]
+	i.entries = i.entries[1:]
+	return true
+}
+
+// Entry returns the current entry.
+func (i *EntryIterator) Entry() *Entry {
+	return i.entry
+}
+
+// Err returns the error that stopped the iteration.
+func (i *EntryIterator) Err() error {
+	return i.err
+}
+
+// IterateEntries returns an iterator over the entries matching the filter.
+func (c *Client) IterateEntries(filter *Filter) *EntryIterator {
+	return newEntryIterator(c.Entries, filter)
+}
+
+// IterateFeedEntries returns an iterator over the entries of a feed.
+func (c *Client) IterateFeedEntries(feedID int64, filter *Filter) *EntryIterator {
+	return newEntryIterator(func(filter *Filter) (*EntryResultSet, error) {
+		return c.FeedEntries(feedID, filter)
+	}, filter)
+}
+
+// IterateCategoryEntries returns an iterator over the entries of a category.
+func (c *Client) IterateCategoryEntries(categoryID int64, filter *Filter) *EntryIterator {
+	return newEntryIterator(func(filter *Filter) (*EntryResultSet, error) {
+		return c.CategoryEntries(categoryID, filter)
+	}, filter)
+}
@@ -41,6 +41,10 @@ type User struct {
 	CJKReadingSpeed        int        `json:"cjk_reading_speed"`
 	DefaultHomePage        string     `json:"default_home_page"`
 	CategoriesSortingOrder string     `json:"categories_sorting_order"`
+	BlocklistRules         string     `json:"blocklist_rules"`
+	KeeplistRules          string     `json:"keeplist_rules"`
+	DuplicateEntries       string     `json:"duplicate_entries"`
+	DuplicateTitleMatching bool       `json:"duplicate_title_matching"`
 }
 
 func (u User) String() string {
@@ -79,16 +83,77 @@ type UserModificationRequest struct {
 	CJKReadingSpeed        *int    `json:"cjk_reading_speed"`
 	DefaultHomePage        *string `json:"default_home_page"`
 	CategoriesSortingOrder *string `json:"categories_sorting_order"`
+	BlocklistRules         *string `json:"blocklist_rules"`
+	KeeplistRules          *string `json:"keeplist_rules"`
+	DuplicateEntries       *string `json:"duplicate_entries"`
+	DuplicateTitleMatching *bool   `json:"duplicate_title_matching"`
 }
 
 // Users represents a list of users.
 type Users []User
 
+// FilterRule represents a global filter rule of a user and the number of entries it matched.
+type FilterRule struct {
+	List    string `json:"list"`
+	Rule    string `json:"rule"`
+	Matches int64  `json:"matches"`
+}
+
+// FilterRules represents a list of filter rules.
+type FilterRules []*FilterRule
+
+// AutomationRule represents an action applied to the new entries that match the rules.
+type AutomationRule struct {
+	ID        int64     `json:"id"`
+	UserID    int64     `json:"user_id"`
+	Name      string    `json:"name"`
+	Rules     string    `json:"rules"`
+	Action    string    `json:"action"`
+	Value     string    `json:"value"`
+	Disabled  bool      `json:"disabled"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// AutomationRules represents a list of automation rules.
+type AutomationRules []*AutomationRule
+
+// AutomationRuleRequest represents the request to create or update an automation rule.
+type AutomationRuleRequest struct {
+	Name     string `json:"name"`
+	Rules    string `json:"rules"`
+	Action   string `json:"action"`
+	Value    string `json:"value"`
+	Disabled bool   `json:"disabled"`
+}
+
+// SavedSearch represents a search query saved as a virtual feed.
+type SavedSearch struct {
+	ID          int64     `json:"id"`
+	UserID      int64     `json:"user_id"`
+	Title       string    `json:"title"`
+	Query       string    `json:"query"`
+	CreatedAt   time.Time `json:"created_at"`
+	UnreadCount int       `json:"unread_count"`
+}
+
+// SavedSearches represents a list of saved searches.
+type SavedSearches []*SavedSearch
+
+// SavedSearchRequest represents the request to create or update a saved search.
+type SavedSearchRequest struct {
+	Title string `json:"title"`
+	Query string `json:"query"`
+}
+
 // Category represents a feed category.
 type Category struct {
-	ID     int64  `json:"id,omitempty"`
-	Title  string `json:"title,omitempty"`
-	UserID int64  `json:"user_id,omitempty"`
+	ID                  int64  `json:"id,omitempty"`
+	Title               string `json:"title,omitempty"`
+	UserID              int64  `json:"user_id,omitempty"`
+	ProxyName           string `json:"proxy_name,omitempty"`
+	RetentionReadDays   int    `json:"retention_read_days,omitempty"`
+	RetentionUnreadDays int    `json:"retention_unread_days,omitempty"`
+	RetentionMaxEntries int    `json:"retention_max_entries,omitempty"`
 }
 
 func (c Category) String() string {
@@ -114,73 +179,136 @@ type Subscriptions []*Subscription
 
 // Feed represents a Miniflux feed.
 type Feed struct {
-	ID                          int64     `json:"id"`
-	UserID                      int64     `json:"user_id"`
-	FeedURL                     string    `json:"feed_url"`
-	SiteURL                     string    `json:"site_url"`
-	Title                       string    `json:"title"`
-	CheckedAt                   time.Time `json:"checked_at,omitempty"`
-	EtagHeader                  string    `json:"etag_header,omitempty"`
-	LastModifiedHeader          string    `json:"last_modified_header,omitempty"`
-	ParsingErrorMsg             string    `json:"parsing_error_message,omitempty"`
-	ParsingErrorCount           int       `json:"parsing_error_count,omitempty"`
-	Disabled                    bool      `json:"disabled"`
-	IgnoreHTTPCache             bool      `json:"ignore_http_cache"`
-	AllowSelfSignedCertificates bool      `json:"allow_self_signed_certificates"`
-	FetchViaProxy               bool      `json:"fetch_via_proxy"`
-	ScraperRules                string    `json:"scraper_rules"`
-	RewriteRules                string    `json:"rewrite_rules"`
-	BlocklistRules              string    `json:"blocklist_rules"`
-	KeeplistRules               string    `json:"keeplist_rules"`
-	Crawler                     bool      `json:"crawler"`
-	UserAgent                   string    `json:"user_agent"`
-	Cookie                      string    `json:"cookie"`
-	Username                    string    `json:"username"`
-	Password                    string    `json:"password"`
-	Category                    *Category `json:"category,omitempty"`
-	HideGlobally                bool      `json:"hide_globally"`
+	ID                          int64             `json:"id"`
+	UserID                      int64             `json:"user_id"`
+	FeedURL                     string            `json:"feed_url"`
+	SiteURL                     string            `json:"site_url"`
+	Title                       string            `json:"title"`
+	CheckedAt                   time.Time         `json:"checked_at,omitempty"`
+	NextCheckAt                 time.Time         `json:"next_check_at,omitempty"`
+	NextCheckReason             string            `json:"next_check_reason,omitempty"`
+	EtagHeader                  string            `json:"etag_header,omitempty"`
+	LastModifiedHeader          string            `json:"last_modified_header,omitempty"`
+	ParsingErrorMsg             string            `json:"parsing_error_message,omitempty"`
+	ParsingErrorCount           int               `json:"parsing_error_count,omitempty"`
+	Disabled                    bool              `json:"disabled"`
+	IgnoreHTTPCache             bool              `json:"ignore_http_cache"`
+	AllowSelfSignedCertificates bool              `json:"allow_self_signed_certificates"`
+	FetchViaProxy               bool              `json:"fetch_via_proxy"`
+	ProxyName                   string            `json:"proxy_name"`
+	EntryIdentity               string            `json:"entry_identity"`
+	RetentionReadDays           int               `json:"retention_read_days"`
+	RetentionUnreadDays         int               `json:"retention_unread_days"`
+	RetentionMaxEntries         int               `json:"retention_max_entries"`
+	ScraperRules                string            `json:"scraper_rules"`
+	RewriteRules                string            `json:"rewrite_rules"`
+	BlocklistRules              string            `json:"blocklist_rules"`
+	KeeplistRules               string            `json:"keeplist_rules"`
+	Crawler                     bool              `json:"crawler"`
+	UserAgent                   string            `json:"user_agent"`
+	Cookie                      string            `json:"cookie"`
+	Headers                     map[string]string `json:"headers"`
+	TLSClientCertificate        string            `json:"tls_client_certificate"`
+	TLSCABundle                 string            `json:"tls_ca_bundle"`
+	Username                    string            `json:"username"`
+	Password                    string            `json:"password"`
+	Category                    *Category         `json:"category,omitempty"`
+	HideGlobally                bool              `json:"hide_globally"`
+	WebSubActive                bool              `json:"websub_active"`
+	ClaimedBy                   string            `json:"claimed_by"`
+	MovedToURL                  string            `json:"moved_to_url"`
 }
 
 // FeedCreationRequest represents the request to create a feed.
 type FeedCreationRequest struct {
-	FeedURL                     string `json:"feed_url"`
-	CategoryID                  int64  `json:"category_id"`
-	UserAgent                   string `json:"user_agent"`
-	Cookie                      string `json:"cookie"`
-	Username                    string `json:"username"`
-	Password                    string `json:"password"`
-	Crawler                     bool   `json:"crawler"`
-	Disabled                    bool   `json:"disabled"`
-	IgnoreHTTPCache             bool   `json:"ignore_http_cache"`
-	AllowSelfSignedCertificates bool   `json:"allow_self_signed_certificates"`
-	FetchViaProxy               bool   `json:"fetch_via_proxy"`
-	ScraperRules                string `json:"scraper_rules"`
-	RewriteRules                string `json:"rewrite_rules"`
-	BlocklistRules              string `json:"blocklist_rules"`
-	KeeplistRules               string `json:"keeplist_rules"`
-	HideGlobally                bool   `json:"hide_globally"`
+	FeedURL                     string            `json:"feed_url"`
+	CategoryID                  int64             `json:"category_id"`
+	UserAgent                   string            `json:"user_agent"`
+	Cookie                      string            `json:"cookie"`
+	Headers                     map[string]string `json:"headers,omitempty"`
+	TLSClientCertificate        string            `json:"tls_client_certificate,omitempty"`
+	TLSClientKey                string            `json:"tls_client_key,omitempty"`
+	TLSCABundle                 string            `json:"tls_ca_bundle,omitempty"`
+	Username                    string            `json:"username"`
+	Password                    string            `json:"password"`
+	Crawler                     bool              `json:"crawler"`
+	Disabled                    bool              `json:"disabled"`
+	IgnoreHTTPCache             bool              `json:"ignore_http_cache"`
+	AllowSelfSignedCertificates bool              `json:"allow_self_signed_certificates"`
+	FetchViaProxy               bool              `json:"fetch_via_proxy"`
+	ProxyName                   string            `json:"proxy_name,omitempty"`
+	EntryIdentity               string            `json:"entry_identity,omitempty"`
+	RetentionReadDays           int               `json:"retention_read_days,omitempty"`
+	RetentionUnreadDays         int               `json:"retention_unread_days,omitempty"`
+	RetentionMaxEntries         int               `json:"retention_max_entries,omitempty"`
+	ScraperRules                string            `json:"scraper_rules"`
+	RewriteRules                string            `json:"rewrite_rules"`
+	BlocklistRules              string            `json:"blocklist_rules"`
+	KeeplistRules               string            `json:"keeplist_rules"`
+	HideGlobally                bool              `json:"hide_globally"`
 }
 
 // FeedModificationRequest represents the request to update a feed.
 type FeedModificationRequest struct {
-	FeedURL                     *string `json:"feed_url"`
-	SiteURL                     *string `json:"site_url"`
-	Title                       *string `json:"title"`
-	ScraperRules                *string `json:"scraper_rules"`
-	RewriteRules                *string `json:"rewrite_rules"`
-	BlocklistRules              *string `json:"blocklist_rules"`
-	KeeplistRules               *string `json:"keeplist_rules"`
-	Crawler                     *bool   `json:"crawler"`
-	UserAgent                   *string `json:"user_agent"`
-	Cookie                      *string `json:"cookie"`
-	Username                    *string `json:"username"`
-	Password                    *string `json:"password"`
-	CategoryID                  *int64  `json:"category_id"`
-	Disabled                    *bool   `json:"disabled"`
-	IgnoreHTTPCache             *bool   `json:"ignore_http_cache"`
-	AllowSelfSignedCertificates *bool   `json:"allow_self_signed_certificates"`
-	FetchViaProxy               *bool   `json:"fetch_via_proxy"`
-	HideGlobally                *bool   `json:"hide_globally"`
+	FeedURL                     *string            `json:"feed_url"`
+	SiteURL                     *string            `json:"site_url"`
+	Title                       *string            `json:"title"`
+	ScraperRules                *string            `json:"scraper_rules"`
+	RewriteRules                *string            `json:"rewrite_rules"`
+	BlocklistRules              *string            `json:"blocklist_rules"`
+	KeeplistRules               *string            `json:"keeplist_rules"`
+	Crawler                     *bool              `json:"crawler"`
+	UserAgent                   *string            `json:"user_agent"`
+	Cookie                      *string            `json:"cookie"`
+	Headers                     *map[string]string `json:"headers"`
+	TLSClientCertificate        *string            `json:"tls_client_certificate"`
+	TLSClientKey                *string            `json:"tls_client_key"`
+	TLSCABundle                 *string            `json:"tls_ca_bundle"`
+	Username                    *string            `json:"username"`
+	Password                    *string            `json:"password"`
+	CategoryID                  *int64             `json:"category_id"`
+	Disabled                    *bool              `json:"disabled"`
+	IgnoreHTTPCache             *bool              `json:"ignore_http_cache"`
+	AllowSelfSignedCertificates *bool              `json:"allow_self_signed_certificates"`
+	FetchViaProxy               *bool              `json:"fetch_via_proxy"`
+	ProxyName                   *string            `json:"proxy_name"`
+	EntryIdentity               *string            `json:"entry_identity"`
+	RetentionReadDays           *int               `json:"retention_read_days"`
+	RetentionUnreadDays         *int               `json:"retention_unread_days"`
+	RetentionMaxEntries         *int               `json:"retention_max_entries"`
+	HideGlobally                *bool              `json:"hide_globally"`
+}
+
+// FeedPreviewRequest represents the rules to try on a feed without saving them.
+type FeedPreviewRequest struct {
+	ScraperRules    *string `json:"scraper_rules"`
+	RewriteRules    *string `json:"rewrite_rules"`
+	BlocklistRules  *string `json:"blocklist_rules"`
+	KeeplistRules   *string `json:"keeplist_rules"`
+	UrlRewriteRules *string `json:"urlrewrite_rules"`
+	Crawler         *bool   `json:"crawler"`
+}
+
+// FeedPreview represents the result of a refresh that is not saved.
+type FeedPreview struct {
+	FeedID       int64           `json:"feed_id"`
+	EffectiveURL string          `json:"effective_url"`
+	Entries      []*EntryPreview `json:"entries"`
+}
+
+// EntryPreview describes an entry as it would be saved and the rules applied to it.
+type EntryPreview struct {
+	Hash          string   `json:"hash"`
+	Title         string   `json:"title"`
+	URL           string   `json:"url"`
+	Content       string   `json:"content"`
+	New           bool     `json:"new"`
+	Blocked       bool     `json:"blocked"`
+	BlocklistRule string   `json:"blocklist_rule,omitempty"`
+	KeeplistRule  string   `json:"keeplist_rule,omitempty"`
+	Crawled       bool     `json:"crawled"`
+	CrawlerError  string   `json:"crawler_error,omitempty"`
+	RewriteRules  []string `json:"rewrite_rules"`
 }
 
 // FeedIcon represents the feed icon.
@@ -198,6 +326,43 @@ type FeedCounters struct {
 // Feeds represents a list of feeds.
 type Feeds []*Feed
 
+// FeedRefresh represents one attempt to refresh a feed.
+type FeedRefresh struct {
+	ID             int64          `json:"id"`
+	FeedID         int64          `json:"feed_id"`
+	StartedAt      time.Time      `json:"started_at"`
+	Duration       int64          `json:"duration"`
+	StatusCode     int            `json:"status_code"`
+	EffectiveURL   string         `json:"effective_url"`
+	Redirects      []FeedRedirect `json:"redirects"`
+	NotModified    bool           `json:"not_modified"`
+	NewEntries     int            `json:"new_entries"`
+	UpdatedEntries int            `json:"updated_entries"`
+	Error          string         `json:"error"`
+}
+
+// FeedRedirect represents an HTTP redirection followed while fetching a feed.
+type FeedRedirect struct {
+	StatusCode int    `json:"status_code"`
+	URL        string `json:"url"`
+}
+
+// FeedRefreshes represents the refresh history of a feed.
+type FeedRefreshes []*FeedRefresh
+
+// FeedURLChange represents a change of the URL of a feed.
+type FeedURLChange struct {
+	ID          int64     `json:"id"`
+	FeedID      int64     `json:"feed_id"`
+	PreviousURL string    `json:"previous_url"`
+	NewURL      string    `json:"new_url"`
+	Reason      string    `json:"reason"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// FeedURLChanges represents the URL history of a feed.
+type FeedURLChanges []*FeedURLChange
+
 // Entry represents a subscription item in the system.
 type Entry struct {
 	ID          int64      `json:"id"`
@@ -215,12 +380,31 @@ type Entry struct {
 	Author      string     `json:"author"`
 	ShareCode   string     `json:"share_code"`
 	Starred     bool       `json:"starred"`
+	ReadLater   bool       `json:"read_later"`
 	ReadingTime int        `json:"reading_time"`
 	Enclosures  Enclosures `json:"enclosures,omitempty"`
 	Feed        *Feed      `json:"feed,omitempty"`
 	Tags        []string   `json:"tags"`
+
+	DuplicateGroupID int64  `json:"duplicate_group_id"`
+	RevisionCount    int    `json:"revision_count"`
+	Language         string `json:"language"`
 }
 
+// EntryRevision represents a previous version of an entry.
+type EntryRevision struct {
+	ID        int64     `json:"id"`
+	UserID    int64     `json:"user_id"`
+	EntryID   int64     `json:"entry_id"`
+	Title     string    `json:"title"`
+	URL       string    `json:"url"`
+	Content   string    `json:"content"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// EntryRevisions represents a list of entry revisions.
+type EntryRevisions []*EntryRevision
+
 // Entries represents a list of entries.
 type Entries []*Entry
 
@@ -240,6 +424,9 @@ type Enclosures []*Enclosure
 const (
 	FilterNotStarred  = "0"
 	FilterOnlyStarred = "1"
+
+	FilterNotReadLater  = "0"
+	FilterOnlyReadLater = "1"
 )
 
 // Filter is used to filter entries.
@@ -250,6 +437,7 @@ type Filter struct {
 	Order         string
 	Direction     string
 	Starred       string
+	ReadLater     string
 	Before        int64
 	After         int64
 	BeforeEntryID int64
@@ -258,10 +446,14 @@ type Filter struct {
 	CategoryID    int64
 	FeedID        int64
 	Statuses      []string
+	Language      string
+	Cursor        string
 }
 
 // EntryResultSet represents the response when fetching entries.
 type EntryResultSet struct {
-	Total   int     `json:"total"`
-	Entries Entries `json:"entries"`
+	Total      int     `json:"total"`
+	Entries    Entries `json:"entries"`
+	NextCursor string  `json:"next_cursor,omitempty"`
+	PrevCursor string  `json:"prev_cursor,omitempty"`
 }
@@ -7,6 +7,7 @@ package config // import "miniflux.app/config"
 import (
 	"os"
 	"testing"
+	"time"
 )
 
 func TestDebugModeOn(t *testing.T) {
@@ -550,6 +551,111 @@ func TestWorkerPoolSize(t *testing.T) {
 	}
 }
 
+func TestDefaultWorkerHostMaxConnectionsValue(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := defaultWorkerHostMaxConnections
+	result := opts.WorkerHostMaxConnections()
+
+	if result != expected {
+		t.Fatalf(`Unexpected WORKER_HOST_MAX_CONNECTIONS value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestWorkerHostMaxConnections(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("WORKER_HOST_MAX_CONNECTIONS", "4")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := 4
+	result := opts.WorkerHostMaxConnections()
+
+	if result != expected {
+		t.Fatalf(`Unexpected WORKER_HOST_MAX_CONNECTIONS value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestDefaultWorkerHostRequestDelayValue(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := time.Duration(defaultWorkerHostRequestDelay) * time.Second
+	result := opts.WorkerHostRequestDelay()
+
+	if result != expected {
+		t.Fatalf(`Unexpected WORKER_HOST_REQUEST_DELAY value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestWorkerHostRequestDelay(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("WORKER_HOST_REQUEST_DELAY", "5")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := 5 * time.Second
+	result := opts.WorkerHostRequestDelay()
+
+	if result != expected {
+		t.Fatalf(`Unexpected WORKER_HOST_REQUEST_DELAY value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestDefaultWorkerHostMaxQueueSizeValue(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := defaultWorkerHostMaxQueueSize
+	result := opts.WorkerHostMaxQueueSize()
+
+	if result != expected {
+		t.Fatalf(`Unexpected WORKER_HOST_MAX_QUEUE_SIZE value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestWorkerHostMaxQueueSize(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("WORKER_HOST_MAX_QUEUE_SIZE", "10")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := 10
+	result := opts.WorkerHostMaxQueueSize()
+
+	if result != expected {
+		t.Fatalf(`Unexpected WORKER_HOST_MAX_QUEUE_SIZE value, got %v instead of %v`, result, expected)
+	}
+}
+
 func TestDefautPollingFrequencyValue(t *testing.T) {
 	os.Clearenv()
 
@@ -743,6 +849,41 @@ func TestPollingParsingErrorLimit(t *testing.T) {
 	}
 }
 
+func TestDefaultSchedulerErrorBackoffMaxIntervalValue(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := defaultSchedulerErrorBackoffMaxInterval
+	result := opts.SchedulerErrorBackoffMaxInterval()
+
+	if result != expected {
+		t.Fatalf(`Unexpected SCHEDULER_ERROR_BACKOFF_MAX_INTERVAL value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestSchedulerErrorBackoffMaxInterval(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("SCHEDULER_ERROR_BACKOFF_MAX_INTERVAL", "720")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := 720
+	result := opts.SchedulerErrorBackoffMaxInterval()
+
+	if result != expected {
+		t.Fatalf(`Unexpected SCHEDULER_ERROR_BACKOFF_MAX_INTERVAL value, got %v instead of %v`, result, expected)
+	}
+}
+
 func TestOAuth2UserCreationWhenUnset(t *testing.T) {
 	os.Clearenv()
 
@@ -1617,6 +1758,251 @@ func TestFetchYouTubeWatchTime(t *testing.T) {
 	}
 }
 
+func TestDefaultWebSubValue(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := defaultWebSub
+	result := opts.HasWebSub()
+
+	if result != expected {
+		t.Fatalf(`Unexpected WEBSUB value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestWebSub(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("WEBSUB", "1")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := true
+	result := opts.HasWebSub()
+
+	if result != expected {
+		t.Fatalf(`Unexpected WEBSUB value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestDefaultCleanupRefreshHistoryDaysValue(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := 7
+	result := opts.CleanupRefreshHistoryDays()
+
+	if result != expected {
+		t.Fatalf(`Unexpected CLEANUP_REFRESH_HISTORY_DAYS value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestCleanupRefreshHistoryDays(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("CLEANUP_REFRESH_HISTORY_DAYS", "30")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := 30
+	result := opts.CleanupRefreshHistoryDays()
+
+	if result != expected {
+		t.Fatalf(`Unexpected CLEANUP_REFRESH_HISTORY_DAYS value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestDefaultCleanupRefreshHistoryPerFeedValue(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := 100
+	result := opts.CleanupRefreshHistoryPerFeed()
+
+	if result != expected {
+		t.Fatalf(`Unexpected CLEANUP_REFRESH_HISTORY_PER_FEED value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestCleanupRefreshHistoryPerFeed(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("CLEANUP_REFRESH_HISTORY_PER_FEED", "20")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := 20
+	result := opts.CleanupRefreshHistoryPerFeed()
+
+	if result != expected {
+		t.Fatalf(`Unexpected CLEANUP_REFRESH_HISTORY_PER_FEED value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestDefaultCleanupPurgeRemovedDaysValue(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := 30
+	result := opts.CleanupPurgeRemovedDays()
+
+	if result != expected {
+		t.Fatalf(`Unexpected CLEANUP_PURGE_REMOVED_DAYS value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestCleanupPurgeRemovedDays(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("CLEANUP_PURGE_REMOVED_DAYS", "7")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := 7
+	result := opts.CleanupPurgeRemovedDays()
+
+	if result != expected {
+		t.Fatalf(`Unexpected CLEANUP_PURGE_REMOVED_DAYS value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestDefaultEntryRevisionsLimitValue(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := 5
+	result := opts.EntryRevisionsLimit()
+
+	if result != expected {
+		t.Fatalf(`Unexpected ENTRY_REVISIONS_LIMIT value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestEntryRevisionsLimit(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("ENTRY_REVISIONS_LIMIT", "10")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := 10
+	result := opts.EntryRevisionsLimit()
+
+	if result != expected {
+		t.Fatalf(`Unexpected ENTRY_REVISIONS_LIMIT value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestDefaultNodeNameValue(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected, _ := os.Hostname()
+	result := opts.NodeName()
+
+	if result != expected {
+		t.Fatalf(`Unexpected NODE_NAME value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestNodeName(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("NODE_NAME", "node-1")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := "node-1"
+	result := opts.NodeName()
+
+	if result != expected {
+		t.Fatalf(`Unexpected NODE_NAME value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestDefaultEncryptionKeyValue(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := defaultEncryptionKey
+	result := opts.EncryptionKey()
+
+	if result != expected {
+		t.Fatalf(`Unexpected ENCRYPTION_KEY value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestEncryptionKey(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("ENCRYPTION_KEY", "secret")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := "secret"
+	result := opts.EncryptionKey()
+
+	if result != expected {
+		t.Fatalf(`Unexpected ENCRYPTION_KEY value, got %v instead of %v`, result, expected)
+	}
+}
+
 func TestParseConfigDumpOutput(t *testing.T) {
 	os.Clearenv()
 
@@ -1651,3 +2037,98 @@ func TestParseConfigDumpOutput(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestDefaultHTTPClientAllowedNetworksValue(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	if len(opts.HTTPClientAllowedNetworks()) != 0 {
+		t.Fatalf(`Unexpected HTTP_CLIENT_ALLOWED_NETWORKS value, got %v instead of an empty list`, opts.HTTPClientAllowedNetworks())
+	}
+}
+
+func TestHTTPClientAllowedNetworks(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("HTTP_CLIENT_ALLOWED_NETWORKS", "192.168.1.0/24, 10.0.0.1,rss-bridge")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := []string{"192.168.1.0/24", "10.0.0.1", "rss-bridge"}
+	result := opts.HTTPClientAllowedNetworks()
+
+	if len(result) != len(expected) {
+		t.Fatalf(`Unexpected HTTP_CLIENT_ALLOWED_NETWORKS value, got %v instead of %v`, result, expected)
+	}
+
+	for i := range expected {
+		if result[i] != expected[i] {
+			t.Fatalf(`Unexpected HTTP_CLIENT_ALLOWED_NETWORKS value, got %v instead of %v`, result, expected)
+		}
+	}
+}
+
+func TestInvalidHTTPClientAllowedNetworks(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("HTTP_CLIENT_ALLOWED_NETWORKS", "192.168.1.0/33")
+
+	parser := NewParser()
+	if _, err := parser.ParseEnvironmentVariables(); err == nil {
+		t.Fatal(`Invalid networks should be rejected`)
+	}
+}
+
+func TestDefaultHTTPClientProxiesValue(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	if len(opts.HTTPClientProxies()) != 0 {
+		t.Fatalf(`Unexpected HTTP_CLIENT_PROXIES value, got %v instead of an empty list`, opts.HTTPClientProxies())
+	}
+}
+
+func TestHTTPClientProxies(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("HTTP_CLIENT_PROXIES", "tor=socks5://127.0.0.1:9050, geo=http://proxy:3128")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	proxies := opts.HTTPClientProxies()
+	if len(proxies) != 2 || proxies["tor"] != "socks5://127.0.0.1:9050" || proxies["geo"] != "http://proxy:3128" {
+		t.Fatalf(`Unexpected HTTP_CLIENT_PROXIES value, got %v`, proxies)
+	}
+
+	names := opts.HTTPClientProxyNames()
+	if len(names) != 2 || names[0] != "geo" || names[1] != "tor" {
+		t.Fatalf(`Unexpected proxy names, got %v`, names)
+	}
+}
+
+func TestInvalidHTTPClientProxies(t *testing.T) {
+	for _, value := range []string{"tor", "direct=http://proxy:3128", "geo=ftp://proxy", "geo=not a URL"} {
+		os.Clearenv()
+		os.Setenv("HTTP_CLIENT_PROXIES", value)
+
+		parser := NewParser()
+		if _, err := parser.ParseEnvironmentVariables(); err == nil {
+			t.Errorf(`The value %q should be rejected`, value)
+		}
+	}
+}
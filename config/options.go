@@ -7,6 +7,7 @@ package config // import "miniflux.app/config"
 import (
 	"crypto/rand"
 	"fmt"
+	"os"
 	"sort"
 	"strings"
 	"time"
@@ -26,11 +27,15 @@ const (
 	defaultRootURL                            = "http://localhost"
 	defaultBasePath                           = ""
 	defaultWorkerPoolSize                     = 5
+	defaultWorkerHostMaxConnections           = 2
+	defaultWorkerHostRequestDelay             = 1
+	defaultWorkerHostMaxQueueSize             = 100
 	defaultPollingFrequency                   = 60
 	defaultBatchSize                          = 100
 	defaultPollingScheduler                   = "round_robin"
 	defaultSchedulerEntryFrequencyMinInterval = 5
 	defaultSchedulerEntryFrequencyMaxInterval = 24 * 60
+	defaultSchedulerErrorBackoffMaxInterval   = 24 * 60
 	defaultPollingParsingErrorLimit           = 3
 	defaultRunMigrations                      = false
 	defaultDatabaseURL                        = "user=postgres password=postgres dbname=miniflux2 sslmode=disable"
@@ -46,8 +51,13 @@ const (
 	defaultCleanupArchiveUnreadDays           = 180
 	defaultCleanupArchiveBatchSize            = 10000
 	defaultCleanupRemoveSessionsDays          = 30
+	defaultCleanupRefreshHistoryDays          = 7
+	defaultCleanupRefreshHistoryPerFeed       = 100
+	defaultCleanupPurgeRemovedDays            = 30
+	defaultEntryRevisionsLimit                = 5
 	defaultProxyHTTPClientTimeout             = 120
 	defaultProxyOption                        = "http-only"
+	defaultEncryptionKey                      = ""
 	defaultProxyMediaTypes                    = "image"
 	defaultProxyUrl                           = ""
 	defaultFetchYouTubeWatchTime              = false
@@ -74,7 +84,9 @@ const (
 	defaultMetricsAllowedNetworks             = "127.0.0.1/8"
 	defaultMetricsUsername                    = ""
 	defaultMetricsPassword                    = ""
+	defaultNodeName                           = ""
 	defaultWatchdog                           = true
+	defaultWebSub                             = false
 	defaultInvidiousInstance                  = "yewtu.be"
 )
 
@@ -112,18 +124,27 @@ type Options struct {
 	cleanupArchiveUnreadDays           int
 	cleanupArchiveBatchSize            int
 	cleanupRemoveSessionsDays          int
+	cleanupRefreshHistoryDays          int
+	cleanupRefreshHistoryPerFeed       int
+	cleanupPurgeRemovedDays            int
+	entryRevisionsLimit                int
 	pollingFrequency                   int
 	batchSize                          int
 	pollingScheduler                   string
 	schedulerEntryFrequencyMinInterval int
 	schedulerEntryFrequencyMaxInterval int
+	schedulerErrorBackoffMaxInterval   int
 	pollingParsingErrorLimit           int
 	workerPoolSize                     int
+	workerHostMaxConnections           int
+	workerHostRequestDelay             int
+	workerHostMaxQueueSize             int
 	createAdmin                        bool
 	adminUsername                      string
 	adminPassword                      string
 	proxyHTTPClientTimeout             int
 	proxyOption                        string
+	encryptionKey                      string
 	proxyMediaTypes                    []string
 	proxyUrl                           string
 	fetchYouTubeWatchTime              bool
@@ -137,6 +158,8 @@ type Options struct {
 	httpClientTimeout                  int
 	httpClientMaxBodySize              int64
 	httpClientProxy                    string
+	httpClientProxies                  map[string]string
+	httpClientAllowedNetworks          []string
 	httpClientUserAgent                string
 	httpServerTimeout                  int
 	authProxyHeader                    string
@@ -148,7 +171,9 @@ type Options struct {
 	metricsAllowedNetworks             []string
 	metricsUsername                    string
 	metricsPassword                    string
+	nodeName                           string
 	watchdog                           bool
+	webSub                             bool
 	invidiousInstance                  string
 	proxyPrivateKey                    []byte
 }
@@ -183,16 +208,25 @@ func NewOptions() *Options {
 		cleanupArchiveUnreadDays:           defaultCleanupArchiveUnreadDays,
 		cleanupArchiveBatchSize:            defaultCleanupArchiveBatchSize,
 		cleanupRemoveSessionsDays:          defaultCleanupRemoveSessionsDays,
+		cleanupRefreshHistoryDays:          defaultCleanupRefreshHistoryDays,
+		cleanupRefreshHistoryPerFeed:       defaultCleanupRefreshHistoryPerFeed,
+		cleanupPurgeRemovedDays:            defaultCleanupPurgeRemovedDays,
+		entryRevisionsLimit:                defaultEntryRevisionsLimit,
 		pollingFrequency:                   defaultPollingFrequency,
 		batchSize:                          defaultBatchSize,
 		pollingScheduler:                   defaultPollingScheduler,
 		schedulerEntryFrequencyMinInterval: defaultSchedulerEntryFrequencyMinInterval,
 		schedulerEntryFrequencyMaxInterval: defaultSchedulerEntryFrequencyMaxInterval,
+		schedulerErrorBackoffMaxInterval:   defaultSchedulerErrorBackoffMaxInterval,
 		pollingParsingErrorLimit:           defaultPollingParsingErrorLimit,
 		workerPoolSize:                     defaultWorkerPoolSize,
+		workerHostMaxConnections:           defaultWorkerHostMaxConnections,
+		workerHostRequestDelay:             defaultWorkerHostRequestDelay,
+		workerHostMaxQueueSize:             defaultWorkerHostMaxQueueSize,
 		createAdmin:                        defaultCreateAdmin,
 		proxyHTTPClientTimeout:             defaultProxyHTTPClientTimeout,
 		proxyOption:                        defaultProxyOption,
+		encryptionKey:                      defaultEncryptionKey,
 		proxyMediaTypes:                    []string{defaultProxyMediaTypes},
 		proxyUrl:                           defaultProxyUrl,
 		fetchYouTubeWatchTime:              defaultFetchYouTubeWatchTime,
@@ -206,6 +240,8 @@ func NewOptions() *Options {
 		httpClientTimeout:                  defaultHTTPClientTimeout,
 		httpClientMaxBodySize:              defaultHTTPClientMaxBodySize * 1024 * 1024,
 		httpClientProxy:                    defaultHTTPClientProxy,
+		httpClientProxies:                  make(map[string]string),
+		httpClientAllowedNetworks:          nil,
 		httpClientUserAgent:                defaultHTTPClientUserAgent,
 		httpServerTimeout:                  defaultHTTPServerTimeout,
 		authProxyHeader:                    defaultAuthProxyHeader,
@@ -217,7 +253,9 @@ func NewOptions() *Options {
 		metricsAllowedNetworks:             []string{defaultMetricsAllowedNetworks},
 		metricsUsername:                    defaultMetricsUsername,
 		metricsPassword:                    defaultMetricsPassword,
+		nodeName:                           defaultNodeName,
 		watchdog:                           defaultWatchdog,
+		webSub:                             defaultWebSub,
 		invidiousInstance:                  defaultInvidiousInstance,
 		proxyPrivateKey:                    randomKey,
 	}
@@ -333,11 +371,46 @@ func (o *Options) CleanupRemoveSessionsDays() int {
 	return o.cleanupRemoveSessionsDays
 }
 
+// CleanupRefreshHistoryDays returns the number of days after which to remove the refresh history of feeds.
+func (o *Options) CleanupRefreshHistoryDays() int {
+	return o.cleanupRefreshHistoryDays
+}
+
+// CleanupRefreshHistoryPerFeed returns the maximum number of refresh attempts to keep for each feed.
+func (o *Options) CleanupRefreshHistoryPerFeed() int {
+	return o.cleanupRefreshHistoryPerFeed
+}
+
+// CleanupPurgeRemovedDays returns the number of days after which the content of removed entries is deleted.
+func (o *Options) CleanupPurgeRemovedDays() int {
+	return o.cleanupPurgeRemovedDays
+}
+
+// EntryRevisionsLimit returns the number of previous versions kept for each entry.
+func (o *Options) EntryRevisionsLimit() int {
+	return o.entryRevisionsLimit
+}
+
 // WorkerPoolSize returns the number of background worker.
 func (o *Options) WorkerPoolSize() int {
 	return o.workerPoolSize
 }
 
+// WorkerHostMaxConnections returns the maximum number of concurrent requests sent to the same host.
+func (o *Options) WorkerHostMaxConnections() int {
+	return o.workerHostMaxConnections
+}
+
+// WorkerHostRequestDelay returns the minimum delay between two requests sent to the same host.
+func (o *Options) WorkerHostRequestDelay() time.Duration {
+	return time.Duration(o.workerHostRequestDelay) * time.Second
+}
+
+// WorkerHostMaxQueueSize returns the maximum number of background jobs waiting for the same host.
+func (o *Options) WorkerHostMaxQueueSize() int {
+	return o.workerHostMaxQueueSize
+}
+
 // PollingFrequency returns the interval to refresh feeds in the background.
 func (o *Options) PollingFrequency() int {
 	return o.pollingFrequency
@@ -363,7 +436,12 @@ func (o *Options) SchedulerEntryFrequencyMinInterval() int {
 	return o.schedulerEntryFrequencyMinInterval
 }
 
-// PollingParsingErrorLimit returns the limit of errors when to stop polling.
+// SchedulerErrorBackoffMaxInterval returns the maximum interval in minutes between two checks of a feed in error.
+func (o *Options) SchedulerErrorBackoffMaxInterval() int {
+	return o.schedulerErrorBackoffMaxInterval
+}
+
+// PollingParsingErrorLimit returns the number of errors after which a feed is reported as broken.
 func (o *Options) PollingParsingErrorLimit() int {
 	return o.pollingParsingErrorLimit
 }
@@ -434,6 +512,16 @@ func (o *Options) ProxyOption() string {
 	return o.proxyOption
 }
 
+// EncryptionKey returns the secret used to encrypt the sensitive data stored in the database, like the TLS client keys of feeds.
+func (o *Options) EncryptionKey() string {
+	return o.encryptionKey
+}
+
+// HasEncryptionKey returns true if an encryption key is configured.
+func (o *Options) HasEncryptionKey() bool {
+	return o.encryptionKey != ""
+}
+
 // ProxyMediaTypes returns a slice of media types to proxy.
 func (o *Options) ProxyMediaTypes() []string {
 	return o.proxyMediaTypes
@@ -487,6 +575,26 @@ func (o *Options) HTTPServerTimeout() int {
 	return o.httpServerTimeout
 }
 
+// HTTPClientProxies returns the named proxies that can be selected for each feed and category.
+func (o *Options) HTTPClientProxies() map[string]string {
+	return o.httpClientProxies
+}
+
+// HTTPClientProxyNames returns the sorted names of the configured proxies.
+func (o *Options) HTTPClientProxyNames() []string {
+	names := make([]string, 0, len(o.httpClientProxies))
+	for name := range o.httpClientProxies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// HTTPClientAllowedNetworks returns the private networks and hosts that the HTTP client is allowed to reach.
+func (o *Options) HTTPClientAllowedNetworks() []string {
+	return o.httpClientAllowedNetworks
+}
+
 // HasHTTPClientProxyConfigured returns true if the HTTP proxy is configured.
 func (o *Options) HasHTTPClientProxyConfigured() bool {
 	return o.httpClientProxy != ""
@@ -527,6 +635,16 @@ func (o *Options) MetricsPassword() string {
 	return o.metricsPassword
 }
 
+// NodeName returns the name of this instance, the hostname by default.
+func (o *Options) NodeName() string {
+	if o.nodeName == "" {
+		if hostname, err := os.Hostname(); err == nil {
+			return hostname
+		}
+	}
+	return o.nodeName
+}
+
 // HTTPClientUserAgent returns the global User-Agent header for miniflux.
 func (o *Options) HTTPClientUserAgent() string {
 	return o.httpClientUserAgent
@@ -537,6 +655,11 @@ func (o *Options) HasWatchdog() bool {
 	return o.watchdog
 }
 
+// HasWebSub returns true if feeds advertising a WebSub hub should receive push notifications.
+func (o *Options) HasWebSub() bool {
+	return o.webSub
+}
+
 // InvidiousInstance returns the invidious instance used by miniflux
 func (o *Options) InvidiousInstance() string {
 	return o.invidiousInstance
@@ -563,6 +686,10 @@ func (o *Options) SortedOptions(redactSecret bool) []*Option {
 		"CLEANUP_ARCHIVE_UNREAD_DAYS":            o.cleanupArchiveUnreadDays,
 		"CLEANUP_ARCHIVE_BATCH_SIZE":             o.cleanupArchiveBatchSize,
 		"CLEANUP_FREQUENCY_HOURS":                o.cleanupFrequencyHours,
+		"CLEANUP_REFRESH_HISTORY_DAYS":           o.cleanupRefreshHistoryDays,
+		"CLEANUP_REFRESH_HISTORY_PER_FEED":       o.cleanupRefreshHistoryPerFeed,
+		"CLEANUP_PURGE_REMOVED_DAYS":             o.cleanupPurgeRemovedDays,
+		"ENTRY_REVISIONS_LIMIT":                  o.entryRevisionsLimit,
 		"CLEANUP_REMOVE_SESSIONS_DAYS":           o.cleanupRemoveSessionsDays,
 		"CREATE_ADMIN":                           o.createAdmin,
 		"DATABASE_MAX_CONNS":                     o.databaseMaxConns,
@@ -573,9 +700,12 @@ func (o *Options) SortedOptions(redactSecret bool) []*Option {
 		"DISABLE_HSTS":                           !o.hsts,
 		"DISABLE_SCHEDULER_SERVICE":              !o.schedulerService,
 		"DISABLE_HTTP_SERVICE":                   !o.httpService,
+		"ENCRYPTION_KEY":                         redactSecretValue(o.encryptionKey, redactSecret),
 		"FETCH_YOUTUBE_WATCH_TIME":               o.fetchYouTubeWatchTime,
 		"HTTPS":                                  o.HTTPS,
+		"HTTP_CLIENT_ALLOWED_NETWORKS":           strings.Join(o.httpClientAllowedNetworks, ","),
 		"HTTP_CLIENT_MAX_BODY_SIZE":              o.httpClientMaxBodySize,
+		"HTTP_CLIENT_PROXIES":                    strings.Join(o.HTTPClientProxyNames(), ","),
 		"HTTP_CLIENT_PROXY":                      o.httpClientProxy,
 		"HTTP_CLIENT_TIMEOUT":                    o.httpClientTimeout,
 		"HTTP_CLIENT_USER_AGENT":                 o.httpClientUserAgent,
@@ -592,6 +722,7 @@ func (o *Options) SortedOptions(redactSecret bool) []*Option {
 		"METRICS_REFRESH_INTERVAL":               o.metricsRefreshInterval,
 		"METRICS_USERNAME":                       o.metricsUsername,
 		"METRICS_PASSWORD":                       redactSecretValue(o.metricsPassword, redactSecret),
+		"NODE_NAME":                              o.nodeName,
 		"OAUTH2_CLIENT_ID":                       o.oauth2ClientID,
 		"OAUTH2_CLIENT_SECRET":                   redactSecretValue(o.oauth2ClientSecret, redactSecret),
 		"OAUTH2_OIDC_DISCOVERY_ENDPOINT":         o.oauth2OidcDiscoveryEndpoint,
@@ -611,10 +742,15 @@ func (o *Options) SortedOptions(redactSecret bool) []*Option {
 		"RUN_MIGRATIONS":                         o.runMigrations,
 		"SCHEDULER_ENTRY_FREQUENCY_MAX_INTERVAL": o.schedulerEntryFrequencyMaxInterval,
 		"SCHEDULER_ENTRY_FREQUENCY_MIN_INTERVAL": o.schedulerEntryFrequencyMinInterval,
+		"SCHEDULER_ERROR_BACKOFF_MAX_INTERVAL":   o.schedulerErrorBackoffMaxInterval,
 		"SCHEDULER_SERVICE":                      o.schedulerService,
 		"SERVER_TIMING_HEADER":                   o.serverTimingHeader,
+		"WORKER_HOST_MAX_CONNECTIONS":            o.workerHostMaxConnections,
+		"WORKER_HOST_REQUEST_DELAY":              o.workerHostRequestDelay,
+		"WORKER_HOST_MAX_QUEUE_SIZE":             o.workerHostMaxQueueSize,
 		"WORKER_POOL_SIZE":                       o.workerPoolSize,
 		"WATCHDOG":                               o.watchdog,
+		"WEBSUB":                                 o.webSub,
 	}
 
 	keys := make([]string, 0, len(keyValues))
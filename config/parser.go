@@ -11,6 +11,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net"
 	url_parser "net/url"
 	"os"
 	"strconv"
@@ -124,8 +125,22 @@ func (p *Parser) parseLines(lines []string) (err error) {
 			p.opts.cleanupArchiveBatchSize = parseInt(value, defaultCleanupArchiveBatchSize)
 		case "CLEANUP_REMOVE_SESSIONS_DAYS":
 			p.opts.cleanupRemoveSessionsDays = parseInt(value, defaultCleanupRemoveSessionsDays)
+		case "CLEANUP_REFRESH_HISTORY_DAYS":
+			p.opts.cleanupRefreshHistoryDays = parseInt(value, defaultCleanupRefreshHistoryDays)
+		case "CLEANUP_REFRESH_HISTORY_PER_FEED":
+			p.opts.cleanupRefreshHistoryPerFeed = parseInt(value, defaultCleanupRefreshHistoryPerFeed)
+		case "CLEANUP_PURGE_REMOVED_DAYS":
+			p.opts.cleanupPurgeRemovedDays = parseInt(value, defaultCleanupPurgeRemovedDays)
+		case "ENTRY_REVISIONS_LIMIT":
+			p.opts.entryRevisionsLimit = parseInt(value, defaultEntryRevisionsLimit)
 		case "WORKER_POOL_SIZE":
 			p.opts.workerPoolSize = parseInt(value, defaultWorkerPoolSize)
+		case "WORKER_HOST_MAX_CONNECTIONS":
+			p.opts.workerHostMaxConnections = parseInt(value, defaultWorkerHostMaxConnections)
+		case "WORKER_HOST_REQUEST_DELAY":
+			p.opts.workerHostRequestDelay = parseInt(value, defaultWorkerHostRequestDelay)
+		case "WORKER_HOST_MAX_QUEUE_SIZE":
+			p.opts.workerHostMaxQueueSize = parseInt(value, defaultWorkerHostMaxQueueSize)
 		case "POLLING_FREQUENCY":
 			p.opts.pollingFrequency = parseInt(value, defaultPollingFrequency)
 		case "BATCH_SIZE":
@@ -136,6 +151,8 @@ func (p *Parser) parseLines(lines []string) (err error) {
 			p.opts.schedulerEntryFrequencyMaxInterval = parseInt(value, defaultSchedulerEntryFrequencyMaxInterval)
 		case "SCHEDULER_ENTRY_FREQUENCY_MIN_INTERVAL":
 			p.opts.schedulerEntryFrequencyMinInterval = parseInt(value, defaultSchedulerEntryFrequencyMinInterval)
+		case "SCHEDULER_ERROR_BACKOFF_MAX_INTERVAL":
+			p.opts.schedulerErrorBackoffMaxInterval = parseInt(value, defaultSchedulerErrorBackoffMaxInterval)
 		case "POLLING_PARSING_ERROR_LIMIT":
 			p.opts.pollingParsingErrorLimit = parseInt(value, defaultPollingParsingErrorLimit)
 		// kept for compatibility purpose
@@ -145,6 +162,10 @@ func (p *Parser) parseLines(lines []string) (err error) {
 			p.opts.proxyHTTPClientTimeout = parseInt(value, defaultProxyHTTPClientTimeout)
 		case "PROXY_OPTION":
 			p.opts.proxyOption = parseString(value, defaultProxyOption)
+		case "ENCRYPTION_KEY":
+			p.opts.encryptionKey = parseString(value, defaultEncryptionKey)
+		case "ENCRYPTION_KEY_FILE":
+			p.opts.encryptionKey = readSecretFile(value, defaultEncryptionKey)
 		case "PROXY_MEDIA_TYPES":
 			p.opts.proxyMediaTypes = parseStringList(value, []string{defaultProxyMediaTypes})
 		// kept for compatibility purpose
@@ -188,6 +209,16 @@ func (p *Parser) parseLines(lines []string) (err error) {
 			p.opts.httpClientMaxBodySize = int64(parseInt(value, defaultHTTPClientMaxBodySize) * 1024 * 1024)
 		case "HTTP_CLIENT_PROXY":
 			p.opts.httpClientProxy = parseString(value, defaultHTTPClientProxy)
+		case "HTTP_CLIENT_PROXIES":
+			p.opts.httpClientProxies, err = parseProxies(value)
+			if err != nil {
+				return err
+			}
+		case "HTTP_CLIENT_ALLOWED_NETWORKS":
+			p.opts.httpClientAllowedNetworks, err = parseAllowedNetworks(value)
+			if err != nil {
+				return err
+			}
 		case "HTTP_CLIENT_USER_AGENT":
 			p.opts.httpClientUserAgent = parseString(value, defaultHTTPClientUserAgent)
 		case "HTTP_SERVER_TIMEOUT":
@@ -214,10 +245,14 @@ func (p *Parser) parseLines(lines []string) (err error) {
 			p.opts.metricsPassword = parseString(value, defaultMetricsPassword)
 		case "METRICS_PASSWORD_FILE":
 			p.opts.metricsPassword = readSecretFile(value, defaultMetricsPassword)
+		case "NODE_NAME":
+			p.opts.nodeName = parseString(value, defaultNodeName)
 		case "FETCH_YOUTUBE_WATCH_TIME":
 			p.opts.fetchYouTubeWatchTime = parseBool(value, defaultFetchYouTubeWatchTime)
 		case "WATCHDOG":
 			p.opts.watchdog = parseBool(value, defaultWatchdog)
+		case "WEBSUB":
+			p.opts.webSub = parseBool(value, defaultWebSub)
 		case "INVIDIOUS_INSTANCE":
 			p.opts.invidiousInstance = parseString(value, defaultInvidiousInstance)
 		case "PROXY_PRIVATE_KEY":
@@ -311,6 +346,61 @@ func parseStringList(value string, fallback []string) []string {
 	return strList
 }
 
+// parseProxies converts a list of "name=URL" pairs separated by commas.
+//
+// The name "direct" is reserved to fetch resources without any proxy.
+func parseProxies(value string) (map[string]string, error) {
+	proxies := make(map[string]string)
+	if value == "" {
+		return proxies, nil
+	}
+
+	for _, item := range strings.Split(value, ",") {
+		fields := strings.SplitN(strings.TrimSpace(item), "=", 2)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("HTTP_CLIENT_PROXIES: invalid proxy definition %q", item)
+		}
+
+		name := strings.TrimSpace(fields[0])
+		proxyURL := strings.TrimSpace(fields[1])
+		if name == "" || name == "direct" {
+			return nil, fmt.Errorf("HTTP_CLIENT_PROXIES: invalid proxy name %q", name)
+		}
+
+		parsedURL, err := url_parser.Parse(proxyURL)
+		if err != nil || parsedURL.Host == "" {
+			return nil, fmt.Errorf("HTTP_CLIENT_PROXIES: invalid URL for the proxy %q", name)
+		}
+
+		switch parsedURL.Scheme {
+		case "http", "https", "socks5":
+		default:
+			return nil, fmt.Errorf("HTTP_CLIENT_PROXIES: unsupported scheme %q for the proxy %q", parsedURL.Scheme, name)
+		}
+
+		proxies[name] = proxyURL
+	}
+
+	return proxies, nil
+}
+
+// parseAllowedNetworks converts a list of CIDR ranges, IP addresses and host names separated by commas.
+func parseAllowedNetworks(value string) ([]string, error) {
+	networks := parseStringList(value, nil)
+	for _, network := range networks {
+		if network == "" {
+			return nil, fmt.Errorf("HTTP_CLIENT_ALLOWED_NETWORKS: empty network")
+		}
+
+		if strings.Contains(network, "/") {
+			if _, _, err := net.ParseCIDR(network); err != nil {
+				return nil, fmt.Errorf("HTTP_CLIENT_ALLOWED_NETWORKS: invalid network %q", network)
+			}
+		}
+	}
+	return networks, nil
+}
+
 func parseBytes(value string, fallback []byte) []byte {
 	if value == "" {
 		return fallback
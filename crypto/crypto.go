@@ -5,10 +5,13 @@
 package crypto // import "miniflux.app/crypto"
 
 import (
+	"crypto/aes"
+	"crypto/cipher"
 	"crypto/rand"
 	"crypto/sha256"
 	"encoding/base64"
 	"encoding/hex"
+	"errors"
 	"fmt"
 )
 
@@ -42,3 +45,46 @@ func GenerateRandomString(size int) string {
 func GenerateRandomStringHex(size int) string {
 	return hex.EncodeToString(GenerateRandomBytes(size))
 }
+
+// Encrypt encrypts the data with AES-256-GCM, the key is derived from the given secret.
+//
+// The random nonce is prepended to the encrypted data.
+func Encrypt(secret string, data []byte) ([]byte, error) {
+	aead, err := newAEAD(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := GenerateRandomBytes(aead.NonceSize())
+	return aead.Seal(nonce, nonce, data, nil), nil
+}
+
+// Decrypt decrypts the data encrypted by Encrypt with the same secret.
+func Decrypt(secret string, data []byte) ([]byte, error) {
+	aead, err := newAEAD(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) < aead.NonceSize() {
+		return nil, errors.New("crypto: encrypted data too short")
+	}
+
+	nonce, ciphertext := data[:aead.NonceSize()], data[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, fmt.Errorf("crypto: unable to decrypt data: %v", err)
+	}
+
+	return plaintext, nil
+}
+
+func newAEAD(secret string) (cipher.AEAD, error) {
+	key := sha256.Sum256([]byte(secret))
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,38 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package crypto // import "miniflux.app/crypto"
+
+import "testing"
+
+func TestEncryptDecrypt(t *testing.T) {
+	encrypted, err := Encrypt("secret", []byte("private key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(encrypted) == "private key" {
+		t.Fatal(`The data should be encrypted`)
+	}
+
+	decrypted, err := Decrypt("secret", encrypted)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(decrypted) != "private key" {
+		t.Errorf(`Unexpected decrypted data: %q`, decrypted)
+	}
+}
+
+func TestDecryptWithWrongSecret(t *testing.T) {
+	encrypted, err := Encrypt("secret", []byte("private key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Decrypt("another secret", encrypted); err == nil {
+		t.Error(`The data should not be decrypted with another secret`)
+	}
+}
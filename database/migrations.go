@@ -6,6 +6,10 @@ package database // import "miniflux.app/database"
 
 import (
 	"database/sql"
+
+	"miniflux.app/url"
+
+	"github.com/lib/pq"
 )
 
 var schemaVersion = len(migrations)
@@ -668,4 +672,290 @@ var migrations = []func(tx *sql.Tx) error{
 		_, err = tx.Exec(sql)
 		return err
 	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE feeds ADD COLUMN ttl int not null default 0;
+			ALTER TABLE feeds ADD COLUMN skip_hours int[] default '{}';
+			ALTER TABLE feeds ADD COLUMN skip_days text[] default '{}';
+			ALTER TABLE feeds ADD COLUMN next_check_reason text not null default '';
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			CREATE TABLE websub_subscriptions (
+				feed_id bigint not null references feeds(id) on delete cascade,
+				user_id int not null references users(id) on delete cascade,
+				hub_url text not null,
+				topic_url text not null,
+				secret text not null,
+				callback_token text not null unique,
+				state text not null default 'pending',
+				lease_expires_at timestamp with time zone,
+				requested_at timestamp with time zone not null default now(),
+				primary key(feed_id)
+			);
+			CREATE INDEX websub_subscriptions_lease_expires_at_idx ON websub_subscriptions(lease_expires_at);
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			CREATE TABLE feed_refreshes (
+				id bigserial not null,
+				feed_id bigint not null references feeds(id) on delete cascade,
+				user_id int not null references users(id) on delete cascade,
+				started_at timestamp with time zone not null default now(),
+				duration int not null default 0,
+				status_code int not null default 0,
+				effective_url text not null default '',
+				redirects jsonb not null default '[]',
+				not_modified bool not null default 'f',
+				new_entries int not null default 0,
+				updated_entries int not null default 0,
+				error_msg text not null default '',
+				primary key(id)
+			);
+			CREATE INDEX feed_refreshes_feed_id_started_at_idx ON feed_refreshes(feed_id, started_at);
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE feeds ADD COLUMN claimed_by text not null default '';
+			ALTER TABLE feeds ADD COLUMN claimed_at timestamp with time zone;
+			CREATE TABLE scheduler_leases (
+				name text not null,
+				node text not null,
+				expires_at timestamp with time zone not null,
+				primary key(name)
+			);
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		_, err = tx.Exec(`ALTER TABLE feeds ADD COLUMN headers jsonb not null default '{}'`)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE feeds ADD COLUMN tls_client_certificate text not null default '';
+			ALTER TABLE feeds ADD COLUMN tls_client_key bytea;
+			ALTER TABLE feeds ADD COLUMN tls_ca_bundle text not null default '';
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE feeds ADD COLUMN moved_to_url text not null default '';
+			CREATE TABLE feed_url_changes (
+				id bigserial not null,
+				feed_id bigint not null references feeds(id) on delete cascade,
+				user_id int not null references users(id) on delete cascade,
+				previous_url text not null,
+				new_url text not null,
+				reason text not null,
+				created_at timestamp with time zone not null default now(),
+				primary key(id)
+			);
+			CREATE INDEX feed_url_changes_feed_id_idx ON feed_url_changes(feed_id);
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE feeds ADD COLUMN proxy_name text not null default '';
+			ALTER TABLE categories ADD COLUMN proxy_name text not null default '';
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE users ADD COLUMN blocklist_rules text not null default '';
+			ALTER TABLE users ADD COLUMN keeplist_rules text not null default '';
+			CREATE TABLE filter_rule_matches (
+				user_id int not null references users(id) on delete cascade,
+				list text not null,
+				rule text not null,
+				matches bigint not null default 0,
+				primary key(user_id, list, rule)
+			);
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE entries ADD COLUMN read_later bool not null default 'f';
+			CREATE TABLE automation_rules (
+				id bigserial not null,
+				user_id int not null references users(id) on delete cascade,
+				name text not null,
+				rules text not null,
+				action text not null,
+				value text not null default '',
+				disabled bool not null default 'f',
+				created_at timestamp with time zone not null default now(),
+				primary key(id),
+				unique(user_id, name)
+			);
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE users ADD COLUMN duplicate_entries text not null default 'keep';
+			ALTER TABLE users ADD COLUMN duplicate_title_matching bool not null default 'f';
+			ALTER TABLE entries ADD COLUMN normalized_url text not null default '';
+			ALTER TABLE entries ADD COLUMN duplicate_group_id bigint;
+			CREATE INDEX entries_user_normalized_url_idx ON entries(user_id, normalized_url) WHERE normalized_url <> '';
+			CREATE INDEX entries_duplicate_group_idx ON entries(duplicate_group_id) WHERE duplicate_group_id IS NOT NULL;
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE feeds ADD COLUMN entry_identity text not null default 'guid';
+			ALTER TABLE feeds ADD COLUMN previous_entry_identity text not null default '';
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE categories ADD COLUMN retention_read_days int not null default 0;
+			ALTER TABLE categories ADD COLUMN retention_unread_days int not null default 0;
+			ALTER TABLE categories ADD COLUMN retention_max_entries int not null default 0;
+			ALTER TABLE feeds ADD COLUMN retention_read_days int not null default 0;
+			ALTER TABLE feeds ADD COLUMN retention_unread_days int not null default 0;
+			ALTER TABLE feeds ADD COLUMN retention_max_entries int not null default 0;
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			CREATE TABLE entry_tombstones (
+				feed_id bigint not null references feeds(id) on delete cascade,
+				hash text not null,
+				created_at timestamp with time zone not null default now(),
+				primary key (feed_id, hash)
+			);
+			CREATE INDEX entries_removed_changed_at_idx ON entries(changed_at) WHERE status = 'removed';
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE entries ADD COLUMN revision_count int not null default 0;
+			CREATE TABLE entry_revisions (
+				id bigserial not null,
+				user_id int not null references users(id) on delete cascade,
+				entry_id bigint not null references entries(id) on delete cascade,
+				title text not null default '',
+				url text not null default '',
+				content text not null default '',
+				created_at timestamp with time zone not null default now(),
+				primary key (id)
+			);
+			CREATE INDEX entry_revisions_entry_id_idx ON entry_revisions(entry_id);
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `ALTER TABLE entries ADD COLUMN language text not null default ''`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			CREATE TABLE saved_searches (
+				id serial not null,
+				user_id int not null references users(id) on delete cascade,
+				title text not null,
+				query text not null,
+				created_at timestamp with time zone not null default now(),
+				primary key (id),
+				unique (user_id, title)
+			);
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			CREATE TABLE filter_rule_matched_entries (
+				user_id int not null references users(id) on delete cascade,
+				feed_id bigint not null references feeds(id) on delete cascade,
+				list text not null,
+				rule text not null,
+				hash text not null,
+				primary key(user_id, list, rule, feed_id, hash)
+			);
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		// The normalized URL is computed in Go, the entries created before it existed are updated by batches.
+		_, err = tx.Exec(`DECLARE entries_cursor CURSOR FOR SELECT id, url FROM entries WHERE normalized_url='' AND url <> ''`)
+		if err != nil {
+			return err
+		}
+		defer tx.Exec("CLOSE entries_cursor")
+
+		for {
+			rows, err := tx.Query(`FETCH 1000 FROM entries_cursor`)
+			if err != nil {
+				return err
+			}
+
+			var entryIDs []int64
+			var normalizedURLs []string
+			for rows.Next() {
+				var entryID int64
+				var entryURL string
+				if err := rows.Scan(&entryID, &entryURL); err != nil {
+					rows.Close()
+					return err
+				}
+				entryIDs = append(entryIDs, entryID)
+				normalizedURLs = append(normalizedURLs, url.Normalize(entryURL))
+			}
+			rows.Close()
+
+			if err := rows.Err(); err != nil {
+				return err
+			}
+
+			if len(entryIDs) == 0 {
+				return nil
+			}
+
+			_, err = tx.Exec(`
+				UPDATE
+					entries
+				SET
+					normalized_url=batch.normalized_url
+				FROM
+					unnest($1::bigint[], $2::text[]) AS batch(id, normalized_url)
+				WHERE
+					entries.id=batch.id
+			`, pq.Array(entryIDs), pq.Array(normalizedURLs))
+			if err != nil {
+				return err
+			}
+		}
+	},
 }
@@ -0,0 +1,147 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package diff // import "miniflux.app/diff"
+
+import (
+	"html"
+	"strings"
+	"unicode"
+)
+
+// Above this number of comparisons, the changed parts are replaced as a whole.
+const maxComparisons = 4_000_000
+
+// Operation describes how a chunk of text has changed.
+type Operation int
+
+// Chunk operations.
+const (
+	Equal Operation = iota
+	Insert
+	Delete
+)
+
+// Chunk is a part of the text that has been kept, inserted or deleted.
+type Chunk struct {
+	Operation Operation
+	Text      string
+}
+
+// Words returns the chunks needed to turn the previous text into the current one.
+//
+// The spaces are compared like words, so the chunks join back to the original texts.
+func Words(previous, current string) []Chunk {
+	a, b := tokenize(previous), tokenize(current)
+
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	var chunks []Chunk
+	chunks = appendChunk(chunks, Equal, a[:prefix]...)
+	chunks = append(chunks, compare(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])...)
+	chunks = appendChunk(chunks, Equal, a[len(a)-suffix:]...)
+	return chunks
+}
+
+// HTML returns the current text where the deleted words are wrapped in a <del> element
+// and the inserted words in an <ins> element.
+func HTML(previous, current string) string {
+	var builder strings.Builder
+	for _, chunk := range Words(previous, current) {
+		text := html.EscapeString(chunk.Text)
+		switch chunk.Operation {
+		case Insert:
+			builder.WriteString("<ins>" + text + "</ins>")
+		case Delete:
+			builder.WriteString("<del>" + text + "</del>")
+		default:
+			builder.WriteString(text)
+		}
+	}
+	return builder.String()
+}
+
+// compare returns the chunks of two token lists, from their longest common subsequence.
+func compare(a, b []string) []Chunk {
+	if len(a)*len(b) > maxComparisons {
+		return appendChunk(appendChunk(nil, Delete, a...), Insert, b...)
+	}
+
+	// lengths[i][j] is the length of the longest common subsequence of a[i:] and b[j:].
+	lengths := make([][]int, len(a)+1)
+	for i := range lengths {
+		lengths[i] = make([]int, len(b)+1)
+	}
+
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			} else if lengths[i+1][j] >= lengths[i][j+1] {
+				lengths[i][j] = lengths[i+1][j]
+			} else {
+				lengths[i][j] = lengths[i][j+1]
+			}
+		}
+	}
+
+	var chunks []Chunk
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			chunks = appendChunk(chunks, Equal, a[i])
+			i++
+			j++
+		case lengths[i+1][j] >= lengths[i][j+1]:
+			chunks = appendChunk(chunks, Delete, a[i])
+			i++
+		default:
+			chunks = appendChunk(chunks, Insert, b[j])
+			j++
+		}
+	}
+
+	chunks = appendChunk(chunks, Delete, a[i:]...)
+	return appendChunk(chunks, Insert, b[j:]...)
+}
+
+// appendChunk adds the tokens to the last chunk when it has the same operation.
+func appendChunk(chunks []Chunk, operation Operation, tokens ...string) []Chunk {
+	if len(tokens) == 0 {
+		return chunks
+	}
+
+	text := strings.Join(tokens, "")
+	if last := len(chunks) - 1; last >= 0 && chunks[last].Operation == operation {
+		chunks[last].Text += text
+		return chunks
+	}
+
+	return append(chunks, Chunk{Operation: operation, Text: text})
+}
+
+// tokenize splits the text into words and runs of spaces.
+func tokenize(text string) []string {
+	var tokens []string
+	start := 0
+	runes := []rune(text)
+	for i := 1; i <= len(runes); i++ {
+		if i == len(runes) || unicode.IsSpace(runes[i]) != unicode.IsSpace(runes[i-1]) {
+			if i > start {
+				tokens = append(tokens, string(runes[start:i]))
+			}
+			start = i
+		}
+	}
+	return tokens
+}
@@ -0,0 +1,44 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package diff // import "miniflux.app/diff"
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestWords(t *testing.T) {
+	chunks := Words("The quick brown fox", "The slow brown fox jumps")
+	expected := []Chunk{
+		{Equal, "The "},
+		{Delete, "quick"},
+		{Insert, "slow"},
+		{Equal, " brown fox"},
+		{Insert, " jumps"},
+	}
+
+	if !reflect.DeepEqual(chunks, expected) {
+		t.Errorf(`Unexpected chunks: %v`, chunks)
+	}
+}
+
+func TestWordsWithIdenticalTexts(t *testing.T) {
+	chunks := Words("Same text", "Same text")
+	if len(chunks) != 1 || chunks[0].Operation != Equal || chunks[0].Text != "Same text" {
+		t.Errorf(`Unexpected chunks: %v`, chunks)
+	}
+
+	if chunks := Words("", ""); len(chunks) != 0 {
+		t.Errorf(`Empty texts should not have chunks, got %v`, chunks)
+	}
+}
+
+func TestHTML(t *testing.T) {
+	result := HTML("Version 1.0 <stable>", "Version 1.1 <stable>")
+	expected := "Version <del>1.0</del><ins>1.1</ins> &lt;stable&gt;"
+	if result != expected {
+		t.Errorf(`Unexpected HTML, got %q instead of %q`, result, expected)
+	}
+}
@@ -0,0 +1,8 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+/*
+Package diff compares two versions of a text word by word.
+*/
+package diff // import "miniflux.app/diff"
@@ -21,6 +21,12 @@ import (
 	"github.com/gorilla/mux"
 )
 
+// savedSearchGroupOffset is added to the ID of the saved searches exposed as groups.
+//
+// The Fever API requires positive group IDs. The category IDs are 32-bit serials,
+// the group IDs from this offset can never be the ID of a category.
+const savedSearchGroupOffset int64 = 1 << 31
+
 // Serve handles Fever API calls.
 func Serve(router *mux.Router, store *storage.Storage) {
 	handler := &handler{store, router}
@@ -95,12 +101,19 @@ func (h *handler) handleGroups(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	savedSearchGroups, savedSearchFeedsGroups, err := h.buildSavedSearchGroups(userID)
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
 	var result groupsResponse
 	for _, category := range categories {
 		result.Groups = append(result.Groups, group{ID: category.ID, Title: category.Title})
 	}
+	result.Groups = append(result.Groups, savedSearchGroups...)
 
-	result.FeedsGroups = h.buildFeedGroups(feeds)
+	result.FeedsGroups = append(h.buildFeedGroups(feeds), savedSearchFeedsGroups...)
 	result.SetCommonValues()
 	json.OK(w, r, result)
 }
@@ -139,6 +152,12 @@ func (h *handler) handleFeeds(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	_, savedSearchFeedsGroups, err := h.buildSavedSearchGroups(userID)
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
 	var result feedsResponse
 	result.Feeds = make([]feed, 0)
 	for _, f := range feeds {
@@ -158,7 +177,7 @@ func (h *handler) handleFeeds(w http.ResponseWriter, r *http.Request) {
 		result.Feeds = append(result.Feeds, subscripion)
 	}
 
-	result.FeedsGroups = h.buildFeedGroups(feeds)
+	result.FeedsGroups = append(h.buildFeedGroups(feeds), savedSearchFeedsGroups...)
 	result.SetCommonValues()
 	json.OK(w, r, result)
 }
@@ -239,8 +258,12 @@ func (h *handler) handleItems(w http.ResponseWriter, r *http.Request) {
 
 	userID := request.UserID(r)
 
+	// The search parameter is not part of the Fever API, the items keep their order for the pagination.
+	searchQuery := request.QueryStringParam(r, "search", "")
+
 	builder := h.store.NewEntryQueryBuilder(userID)
 	builder.WithoutStatus(model.EntryStatusRemoved)
+	builder.WithSearchQuery(searchQuery)
 	builder.WithLimit(50)
 	builder.WithOrder("id")
 	builder.WithDirection(model.DefaultSortingDirection)
@@ -287,6 +310,7 @@ func (h *handler) handleItems(w http.ResponseWriter, r *http.Request) {
 
 	builder = h.store.NewEntryQueryBuilder(userID)
 	builder.WithoutStatus(model.EntryStatusRemoved)
+	builder.WithSearchQuery(searchQuery)
 	result.Total, err = builder.CountEntries()
 	if err != nil {
 		json.ServerError(w, r, err)
@@ -310,7 +334,7 @@ func (h *handler) handleItems(w http.ResponseWriter, r *http.Request) {
 			FeedID:    entry.FeedID,
 			Title:     entry.Title,
 			Author:    entry.Author,
-			HTML:      proxy.AbsoluteProxyRewriter(h.router, r.Host, entry.Content),
+			HTML:      proxy.AbsoluteProxyRewriter(h.router, r.Host, entry.Content, entry.Feed.EffectiveProxyName()),
 			URL:       entry.URL,
 			IsSaved:   isSaved,
 			IsRead:    isRead,
@@ -494,9 +518,12 @@ func (h *handler) handleWriteGroups(w http.ResponseWriter, r *http.Request) {
 	go func() {
 		var err error
 
-		if groupID == 0 {
+		switch {
+		case groupID == 0:
 			err = h.store.MarkAllAsRead(userID)
-		} else {
+		case groupID >= savedSearchGroupOffset:
+			err = h.markSavedSearchAsRead(userID, groupID-savedSearchGroupOffset, before)
+		default:
 			err = h.store.MarkCategoryAsRead(userID, groupID, before)
 		}
 
@@ -530,3 +557,57 @@ func (h *handler) buildFeedGroups(feeds model.Feeds) []feedsGroups {
 
 	return result
 }
+
+// buildSavedSearchGroups returns the saved searches as groups.
+//
+// The Fever API has no way to filter the items of a group: the clients show every item
+// of the feeds in the group. A saved search group lists the feeds of the matching entries,
+// so it also shows the entries of these feeds that don't match the search.
+// Marking the group as read only marks the matching entries.
+func (h *handler) buildSavedSearchGroups(userID int64) ([]group, []feedsGroups, error) {
+	savedSearches, err := h.store.SavedSearches(userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	groups := make([]group, 0)
+	result := make([]feedsGroups, 0)
+	for _, savedSearch := range savedSearches {
+		groupID := savedSearchGroupOffset + savedSearch.ID
+		groups = append(groups, group{ID: groupID, Title: savedSearch.Title})
+
+		feedIDs, err := h.store.NewSavedSearchQueryBuilder(savedSearch).GetFeedIDs()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if len(feedIDs) == 0 {
+			continue
+		}
+
+		var formattedIDs []string
+		for _, feedID := range feedIDs {
+			formattedIDs = append(formattedIDs, strconv.FormatInt(feedID, 10))
+		}
+
+		result = append(result, feedsGroups{
+			GroupID: groupID,
+			FeedIDs: strings.Join(formattedIDs, ","),
+		})
+	}
+
+	return groups, result, nil
+}
+
+func (h *handler) markSavedSearchAsRead(userID, savedSearchID int64, before time.Time) error {
+	savedSearch, err := h.store.SavedSearch(userID, savedSearchID)
+	if err != nil {
+		return err
+	}
+
+	if savedSearch == nil {
+		return nil
+	}
+
+	return h.store.MarkSavedSearchAsRead(savedSearch, before)
+}
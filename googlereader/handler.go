@@ -94,6 +94,8 @@ const (
 	ParamDestination = "dest"
 	// ParamContinuation -  name of the parameter for callers to pass to receive the next page of results
 	ParamContinuation = "c"
+	// ParamSearchQuery - name of the parameter containing the search query
+	ParamSearchQuery = "q"
 )
 
 // StreamType represents the possible stream types
@@ -219,6 +221,7 @@ func Serve(router *mux.Router, store *storage.Storage) {
 	sr.HandleFunc("/subscription/quickadd", handler.quickAdd).Methods(http.MethodPost).Name("QuickAdd")
 	sr.HandleFunc("/stream/items/ids", handler.streamItemIDs).Methods(http.MethodGet).Name("StreamItemIDs")
 	sr.HandleFunc("/stream/items/contents", handler.streamItemContents).Methods(http.MethodPost).Name("StreamItemsContents")
+	sr.HandleFunc("/search/items/ids", handler.searchItemIDs).Methods(http.MethodGet).Name("SearchItemIDs")
 	sr.PathPrefix("/").HandlerFunc(handler.serve).Methods(http.MethodPost, http.MethodGet).Name("GoogleReaderApiEndpoint")
 }
 
@@ -590,6 +593,8 @@ func getOrCreateCategory(category Stream, store *storage.Storage, userID int64)
 		return store.FirstCategory(userID)
 	} else if store.CategoryTitleExists(userID, category.ID) {
 		return store.CategoryByTitle(userID, category.ID)
+	} else if store.SavedSearchExists(userID, 0, category.ID) {
+		return nil, fmt.Errorf("the label %q is a saved search", category.ID)
 	} else {
 		catRequest := model.CategoryRequest{
 			Title: category.ID,
@@ -841,14 +846,14 @@ func (h *handler) streamItemContents(w http.ResponseWriter, r *http.Request) {
 			categories = append(categories, userStarred)
 		}
 
-		entry.Content = proxy.AbsoluteProxyRewriter(h.router, r.Host, entry.Content)
+		entry.Content = proxy.AbsoluteProxyRewriter(h.router, r.Host, entry.Content, entry.Feed.EffectiveProxyName())
 		proxyOption := config.Opts.ProxyOption()
 
 		for i := range entry.Enclosures {
 			if proxyOption == "all" || proxyOption != "none" && !url.IsHTTPS(entry.Enclosures[i].URL) {
 				for _, mediaType := range config.Opts.ProxyMediaTypes() {
 					if strings.HasPrefix(entry.Enclosures[i].MimeType, mediaType+"/") {
-						entry.Enclosures[i].URL = proxy.AbsoluteProxifyURL(h.router, r.Host, entry.Enclosures[i].URL)
+						entry.Enclosures[i].URL = proxy.AbsoluteProxifyURL(h.router, r.Host, entry.Enclosures[i].URL, entry.Feed.EffectiveProxyName())
 						break
 					}
 				}
@@ -1022,6 +1027,19 @@ func (h *handler) tagList(w http.ResponseWriter, r *http.Request) {
 			Type:  "folder",
 		})
 	}
+
+	savedSearches, err := h.store.SavedSearches(userID)
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+	for _, savedSearch := range savedSearches {
+		result.Tags = append(result.Tags, subscriptionCategory{
+			ID:    fmt.Sprintf(UserLabelPrefix, userID) + savedSearch.Title,
+			Label: savedSearch.Title,
+			Type:  "tag",
+		})
+	}
 	json.OK(w, r, result)
 }
 
@@ -1119,6 +1137,8 @@ func (h *handler) streamItemIDs(w http.ResponseWriter, r *http.Request) {
 		h.handleReadStream(w, r, rm)
 	case FeedStream:
 		h.handleFeedStream(w, r, rm)
+	case LabelStream:
+		h.handleLabelStream(w, r, rm)
 	default:
 		dump, _ := httputil.DumpRequest(r, true)
 		logger.Info("[GoogleReader][/stream/items/ids] [ClientIP=%s] Unknown Stream: %s", clientIP, dump)
@@ -1129,6 +1149,48 @@ func (h *handler) streamItemIDs(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func (h *handler) searchItemIDs(w http.ResponseWriter, r *http.Request) {
+	userID := request.UserID(r)
+	clientIP := request.ClientIP(r)
+
+	logger.Info("[GoogleReader][/search/items/ids][ClientIP=%s] Incoming Request for userID #%d", clientIP, userID)
+
+	if err := checkOutputFormat(w, r); err != nil {
+		err := fmt.Errorf("output only as json supported")
+		logger.Error("[GoogleReader][/search/items/ids] [ClientIP=%s] %v", clientIP, err)
+		json.ServerError(w, r, err)
+		return
+	}
+
+	searchQuery := request.QueryStringParam(r, ParamSearchQuery, "")
+	if searchQuery == "" {
+		err := fmt.Errorf("no search query")
+		logger.Error("[GoogleReader][/search/items/ids] [ClientIP=%s] %v", clientIP, err)
+		json.BadRequest(w, r, err)
+		return
+	}
+
+	builder := h.store.NewEntryQueryBuilder(userID)
+	builder.WithoutStatus(model.EntryStatusRemoved)
+	builder.WithSearchQuery(searchQuery)
+	builder.WithLimit(request.QueryIntParam(r, ParamStreamMaxItems, 0))
+	builder.WithOffset(request.QueryIntParam(r, ParamContinuation, 0))
+
+	rawEntryIDs, err := builder.GetEntryIDs()
+	if err != nil {
+		logger.Error("[GoogleReader][/search/items/ids] [ClientIP=%s] %v", clientIP, err)
+		json.ServerError(w, r, err)
+		return
+	}
+
+	var itemRefs = make([]itemRef, 0)
+	for _, entryID := range rawEntryIDs {
+		itemRefs = append(itemRefs, itemRef{ID: strconv.FormatInt(entryID, 10)})
+	}
+
+	json.OK(w, r, searchResponse{itemRefs})
+}
+
 func (h *handler) handleReadingListStream(w http.ResponseWriter, r *http.Request, rm RequestModifiers) {
 	clientIP := request.ClientIP(r)
 
@@ -1313,3 +1375,83 @@ func (h *handler) handleFeedStream(w http.ResponseWriter, r *http.Request, rm Re
 
 	json.OK(w, r, streamIDResponse{itemRefs, continuation})
 }
+
+// handleLabelStream returns the entries of the saved search or of the category with this title.
+//
+// The validation prevents a saved search and a category from sharing a title.
+func (h *handler) handleLabelStream(w http.ResponseWriter, r *http.Request, rm RequestModifiers) {
+	clientIP := request.ClientIP(r)
+	label := rm.Streams[0].ID
+
+	savedSearch, err := h.store.SavedSearchByTitle(rm.UserID, label)
+	if err != nil {
+		logger.Error("[GoogleReader][/stream/items/ids#label] [ClientIP=%s] %v", clientIP, err)
+		json.ServerError(w, r, err)
+		return
+	}
+
+	var builder *storage.EntryQueryBuilder
+	if savedSearch != nil {
+		builder = h.store.NewSavedSearchQueryBuilder(savedSearch)
+	} else {
+		category, err := h.store.CategoryByTitle(rm.UserID, label)
+		if err != nil {
+			logger.Error("[GoogleReader][/stream/items/ids#label] [ClientIP=%s] %v", clientIP, err)
+			json.ServerError(w, r, err)
+			return
+		}
+
+		if category == nil {
+			json.NotFound(w, r)
+			return
+		}
+
+		builder = h.store.NewEntryQueryBuilder(rm.UserID)
+		builder.WithoutStatus(model.EntryStatusRemoved)
+		builder.WithCategoryID(category.ID)
+	}
+
+	for _, s := range rm.ExcludeTargets {
+		switch s.Type {
+		case ReadStream:
+			builder.WithStatus(model.EntryStatusUnread)
+		default:
+			logger.Info("[GoogleReader][LabelStreamIDs][ClientIP=%s] xt filter type: %#v", clientIP, s)
+		}
+	}
+	builder.WithLimit(rm.Count)
+	builder.WithOffset(rm.Offset)
+	builder.WithOrder(model.DefaultSortingOrder)
+	builder.WithDirection(rm.SortDirection)
+	if rm.StartTime > 0 {
+		builder.AfterDate(time.Unix(rm.StartTime, 0))
+	}
+	if rm.StopTime > 0 {
+		builder.BeforeDate(time.Unix(rm.StopTime, 0))
+	}
+
+	rawEntryIDs, err := builder.GetEntryIDs()
+	if err != nil {
+		logger.Error("[GoogleReader][/stream/items/ids#label] [ClientIP=%s] %v", clientIP, err)
+		json.ServerError(w, r, err)
+		return
+	}
+	var itemRefs = make([]itemRef, 0)
+	for _, entryID := range rawEntryIDs {
+		formattedID := strconv.FormatInt(entryID, 10)
+		itemRefs = append(itemRefs, itemRef{ID: formattedID})
+	}
+
+	totalEntries, err := builder.CountEntries()
+	if err != nil {
+		logger.Error("[GoogleReader][/stream/items/ids#label] [ClientIP=%s] %v", clientIP, err)
+		json.ServerError(w, r, err)
+		return
+	}
+	continuation := 0
+	if len(itemRefs)+rm.Offset < totalEntries {
+		continuation = len(itemRefs) + rm.Offset
+	}
+
+	json.OK(w, r, streamIDResponse{itemRefs, continuation})
+}
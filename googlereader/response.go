@@ -65,6 +65,10 @@ type streamIDResponse struct {
 	Continuation int       `json:"continuation,omitempty,string"`
 }
 
+type searchResponse struct {
+	Results []itemRef `json:"results"`
+}
+
 type tagsResponse struct {
 	Tags []subscriptionCategory `json:"tags"`
 }
@@ -26,14 +26,30 @@ import (
 const (
 	defaultHTTPClientTimeout     = 20
 	defaultHTTPClientMaxBodySize = 15 * 1024 * 1024
+
+	// DirectProxy is the proxy name used to fetch resources without any proxy.
+	DirectProxy = "direct"
+
+	// maxRedirects is the same limit as the default policy of the Go HTTP client.
+	maxRedirects = 10
 )
 
 var (
 	errInvalidCertificate = "Invalid SSL certificate (original error: %q)"
 	errNetworkOperation   = "This website is unreachable (original error: %q)"
 	errRequestTimeout     = "Website unreachable, the request timed out after %d seconds"
+	errForbiddenNetwork   = "This website is on a private network that is not allowed (%s)"
 )
 
+// TLSSettings represents a client certificate and the certificate authorities trusted in addition to the system ones.
+//
+// The certificates and the key are PEM encoded.
+type TLSSettings struct {
+	ClientCertificate string
+	ClientKey         string
+	CABundle          string
+}
+
 // Client builds and executes HTTP requests.
 type Client struct {
 	inputURL string
@@ -45,13 +61,18 @@ type Client struct {
 	requestPassword            string
 	requestUserAgent           string
 	requestCookie              string
+	requestHeaders             map[string]string
+	requestTLSSettings         TLSSettings
 
 	useProxy             bool
+	proxyName            string
 	doNotFollowRedirects bool
 
 	ClientTimeout               int
 	ClientMaxBodySize           int64
 	ClientProxyURL              string
+	ClientProxies               map[string]string
+	ClientNetworkGuard          *NetworkGuard
 	AllowSelfSignedCertificates bool
 }
 
@@ -67,11 +88,13 @@ func New(url string) *Client {
 // NewClientWithConfig initializes a new HTTP client with application config options.
 func NewClientWithConfig(url string, opts *config.Options) *Client {
 	return &Client{
-		inputURL:          url,
-		requestUserAgent:  opts.HTTPClientUserAgent(),
-		ClientTimeout:     opts.HTTPClientTimeout(),
-		ClientMaxBodySize: opts.HTTPClientMaxBodySize(),
-		ClientProxyURL:    opts.HTTPClientProxy(),
+		inputURL:           url,
+		requestUserAgent:   opts.HTTPClientUserAgent(),
+		ClientTimeout:      opts.HTTPClientTimeout(),
+		ClientMaxBodySize:  opts.HTTPClientMaxBodySize(),
+		ClientProxyURL:     opts.HTTPClientProxy(),
+		ClientProxies:      opts.HTTPClientProxies(),
+		ClientNetworkGuard: NewNetworkGuard(opts.HTTPClientAllowedNetworks()),
 	}
 }
 
@@ -119,12 +142,32 @@ func (c *Client) WithCacheHeaders(etagHeader, lastModifiedHeader string) *Client
 	return c
 }
 
+// WithHeaders defines custom HTTP headers, they replace the default headers with the same name.
+func (c *Client) WithHeaders(headers map[string]string) *Client {
+	c.requestHeaders = headers
+	return c
+}
+
+// WithTLSSettings defines the client certificate and the certificate authorities used to verify the server.
+func (c *Client) WithTLSSettings(settings TLSSettings) *Client {
+	c.requestTLSSettings = settings
+	return c
+}
+
 // WithProxy enables proxy for the current HTTP request.
 func (c *Client) WithProxy() *Client {
 	c.useProxy = true
 	return c
 }
 
+// WithNamedProxy selects one of the configured proxies, or DirectProxy to bypass any proxy.
+//
+// The named proxy takes precedence over WithProxy, an empty name keeps the default behavior.
+func (c *Client) WithNamedProxy(name string) *Client {
+	c.proxyName = name
+	return c
+}
+
 // WithoutRedirects disables HTTP redirects.
 func (c *Client) WithoutRedirects() *Client {
 	c.doNotFollowRedirects = true
@@ -192,13 +235,34 @@ func (c *Client) executeRequest(request *http.Request) (*Response, error) {
 		c.String(),
 	)
 
-	client := c.buildClient()
+	client, err := c.buildClient()
+	if err != nil {
+		return nil, err
+	}
+
+	var redirects []Redirect
+	if !c.doNotFollowRedirects {
+		client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxRedirects {
+				return fmt.Errorf("client: stopped after %d redirects", maxRedirects)
+			}
+
+			redirects = append(redirects, Redirect{StatusCode: req.Response.StatusCode, URL: req.URL.String()})
+			return nil
+		}
+	}
+
 	resp, err := client.Do(request)
 	if resp != nil {
 		defer resp.Body.Close()
 	}
 
 	if err != nil {
+		if address, found := forbiddenDestination(err); found {
+			logger.Error("[HttpClient] Connection refused by the network guard: %v", err)
+			return nil, errors.NewLocalizedError(errForbiddenNetwork, address)
+		}
+
 		if uerr, ok := err.(*url.Error); ok {
 			switch uerr.Err.(type) {
 			case x509.CertificateInvalidError, x509.HostnameError:
@@ -232,8 +296,11 @@ func (c *Client) executeRequest(request *http.Request) (*Response, error) {
 		LastModified:  resp.Header.Get("Last-Modified"),
 		ETag:          resp.Header.Get("ETag"),
 		Expires:       resp.Header.Get("Expires"),
+		CacheControl:  resp.Header.Get("Cache-Control"),
+		RetryAfter:    resp.Header.Get("Retry-After"),
 		ContentType:   resp.Header.Get("Content-Type"),
 		ContentLength: resp.ContentLength,
+		Redirects:     redirects,
 	}
 
 	logger.Debug("[HttpClient:After] Method=%s %s; Response => %s",
@@ -267,20 +334,22 @@ func (c *Client) buildRequest(method string, body io.Reader) (*http.Request, err
 	return request, nil
 }
 
-func (c *Client) buildClient() http.Client {
+func (c *Client) buildClient() (http.Client, error) {
 	client := http.Client{
 		Timeout: time.Duration(c.ClientTimeout) * time.Second,
 	}
 
-	transport := &http.Transport{
-		Proxy: http.ProxyFromEnvironment,
-		DialContext: (&net.Dialer{
-			// Default is 30s.
-			Timeout: 10 * time.Second,
+	dialer := &net.Dialer{
+		// Default is 30s.
+		Timeout: 10 * time.Second,
 
-			// Default is 30s.
-			KeepAlive: 15 * time.Second,
-		}).DialContext,
+		// Default is 30s.
+		KeepAlive: 15 * time.Second,
+	}
+
+	transport := &http.Transport{
+		Proxy:       http.ProxyFromEnvironment,
+		DialContext: dialer.DialContext,
 
 		// Default is 100.
 		MaxIdleConns: 50,
@@ -289,9 +358,11 @@ func (c *Client) buildClient() http.Client {
 		IdleConnTimeout: 10 * time.Second,
 	}
 
-	if c.AllowSelfSignedCertificates {
-		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	tlsConfig, err := c.buildTLSConfig()
+	if err != nil {
+		return client, err
 	}
+	transport.TLSClientConfig = tlsConfig
 
 	if c.doNotFollowRedirects {
 		client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
@@ -299,7 +370,23 @@ func (c *Client) buildClient() http.Client {
 		}
 	}
 
-	if c.useProxy && c.ClientProxyURL != "" {
+	switch {
+	case c.proxyName == DirectProxy:
+		transport.Proxy = nil
+	case c.proxyName != "":
+		rawProxyURL, found := c.ClientProxies[c.proxyName]
+		if !found {
+			return client, fmt.Errorf("client: the proxy %q is not configured", c.proxyName)
+		}
+
+		proxyURL, err := url.Parse(rawProxyURL)
+		if err != nil {
+			return client, fmt.Errorf("client: invalid URL for the proxy %q: %v", c.proxyName, err)
+		}
+
+		logger.Debug("[HttpClient] Use proxy %q: %s", c.proxyName, proxyURL.Redacted())
+		transport.Proxy = http.ProxyURL(proxyURL)
+	case c.useProxy && c.ClientProxyURL != "":
 		proxyURL, err := url.Parse(c.ClientProxyURL)
 		if err != nil {
 			logger.Error("[HttpClient] Proxy URL error: %v", err)
@@ -309,9 +396,44 @@ func (c *Client) buildClient() http.Client {
 		}
 	}
 
+	if c.ClientNetworkGuard != nil {
+		c.ClientNetworkGuard.Protect(transport, dialer)
+	}
+
 	client.Transport = transport
 
-	return client
+	return client, nil
+}
+
+func (c *Client) buildTLSConfig() (*tls.Config, error) {
+	settings := c.requestTLSSettings
+	if !c.AllowSelfSignedCertificates && settings.ClientCertificate == "" && settings.CABundle == "" {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{InsecureSkipVerify: c.AllowSelfSignedCertificates}
+
+	if settings.ClientCertificate != "" {
+		certificate, err := tls.X509KeyPair([]byte(settings.ClientCertificate), []byte(settings.ClientKey))
+		if err != nil {
+			return nil, fmt.Errorf("client: invalid client certificate: %v", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	if settings.CABundle != "" {
+		rootCAs, err := x509.SystemCertPool()
+		if err != nil {
+			rootCAs = x509.NewCertPool()
+		}
+
+		if !rootCAs.AppendCertsFromPEM([]byte(settings.CABundle)) {
+			return nil, fmt.Errorf("client: no valid certificate found in the CA bundle")
+		}
+		tlsConfig.RootCAs = rootCAs
+	}
+
+	return tlsConfig, nil
 }
 
 func (c *Client) buildHeaders() http.Header {
@@ -338,6 +460,10 @@ func (c *Client) buildHeaders() http.Header {
 		headers.Add("Cookie", c.requestCookie)
 	}
 
+	for name, value := range c.requestHeaders {
+		headers.Set(name, value)
+	}
+
 	headers.Add("Connection", "close")
 	return headers
 }
@@ -5,7 +5,20 @@
 package client // import "miniflux.app/http/client"
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"log"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 )
 
 func TestClientWithDelay(t *testing.T) {
@@ -51,3 +64,173 @@ func TestClientWithBasicAuth(t *testing.T) {
 		t.Fatalf(`The client should be authenticated successfully: %v`, err)
 	}
 }
+
+func TestClientTracksRedirects(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/old", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/moved", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/moved", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/feed", http.StatusFound)
+	})
+	mux.HandleFunc("/feed", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("OK"))
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	response, err := New(server.URL + "/old").Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if response.EffectiveURL != server.URL+"/feed" {
+		t.Fatalf(`Unexpected effective URL: %s`, response.EffectiveURL)
+	}
+
+	expected := []Redirect{
+		{StatusCode: http.StatusMovedPermanently, URL: server.URL + "/moved"},
+		{StatusCode: http.StatusFound, URL: server.URL + "/feed"},
+	}
+
+	if len(response.Redirects) != len(expected) {
+		t.Fatalf(`Unexpected redirects: %+v`, response.Redirects)
+	}
+
+	for i, redirect := range expected {
+		if response.Redirects[i] != redirect {
+			t.Errorf(`Unexpected redirect #%d, got %+v instead of %+v`, i, response.Redirects[i], redirect)
+		}
+	}
+}
+
+func TestClientWithCustomHeaders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" || r.Header.Get("Accept") != "application/atom+xml" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte("OK"))
+	}))
+	defer server.Close()
+
+	clt := New(server.URL)
+	clt.WithHeaders(map[string]string{"Authorization": "Bearer token", "Accept": "application/atom+xml"})
+
+	response, err := clt.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if response.StatusCode != http.StatusOK {
+		t.Fatalf(`The custom headers should be sent, got status code %d`, response.StatusCode)
+	}
+}
+
+func TestClientWithNamedProxy(t *testing.T) {
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Host != "feeds.example.org" {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte("proxied"))
+	}))
+	defer proxy.Close()
+
+	clt := New("http://feeds.example.org/feed.xml")
+	clt.ClientProxies = map[string]string{"geo": proxy.URL}
+	clt.WithNamedProxy("geo")
+
+	response, err := clt.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if body := response.BodyAsString(); body != "proxied" {
+		t.Fatalf(`The request should go through the proxy, got %q`, body)
+	}
+}
+
+func TestClientWithUnknownNamedProxy(t *testing.T) {
+	clt := New("http://feeds.example.org/feed.xml")
+	clt.WithNamedProxy("unknown")
+
+	if _, err := clt.Get(); err == nil {
+		t.Fatal(`An unknown proxy should be rejected`)
+	}
+}
+
+func TestClientWithTLSSettings(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.TLS.PeerCertificates) == 0 {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte("OK"))
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	server.Config.ErrorLog = log.New(io.Discard, "", 0)
+	server.StartTLS()
+	defer server.Close()
+
+	caBundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	clientCertificate, clientKey := generateTestCertificate(t)
+
+	clt := New(server.URL)
+	clt.WithTLSSettings(TLSSettings{
+		ClientCertificate: clientCertificate,
+		ClientKey:         clientKey,
+		CABundle:          string(caBundle),
+	})
+
+	response, err := clt.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if response.StatusCode != http.StatusOK {
+		t.Fatalf(`The client certificate should be sent, got status code %d`, response.StatusCode)
+	}
+
+	if _, err := New(server.URL).Get(); err == nil {
+		t.Fatal(`The server certificate should not be trusted without the CA bundle`)
+	}
+}
+
+func TestClientWithInvalidCABundle(t *testing.T) {
+	clt := New("https://example.org/")
+	clt.WithTLSSettings(TLSSettings{CABundle: "invalid"})
+
+	if _, err := clt.Get(); err == nil {
+		t.Fatal(`An invalid CA bundle should be rejected`)
+	}
+}
+
+func generateTestCertificate(t *testing.T) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "miniflux"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+
+	certificate, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	privateKey, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificate})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: privateKey}))
+}
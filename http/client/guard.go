@@ -0,0 +1,179 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package client // import "miniflux.app/http/client"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"syscall"
+)
+
+// ForbiddenDestinationError is returned when the NetworkGuard refuses a connection.
+type ForbiddenDestinationError struct {
+	Address string
+}
+
+func (e *ForbiddenDestinationError) Error() string {
+	return fmt.Sprintf("client: connection to %s is not allowed", e.Address)
+}
+
+// IsForbiddenDestination returns true if the error was caused by the NetworkGuard.
+func IsForbiddenDestination(err error) bool {
+	_, found := forbiddenDestination(err)
+	return found
+}
+
+func forbiddenDestination(err error) (string, bool) {
+	var ferr *ForbiddenDestinationError
+	if errors.As(err, &ferr) {
+		return ferr.Address, true
+	}
+	return "", false
+}
+
+// NetworkGuard refuses connections to loopback, link-local, private and multicast addresses.
+//
+// The addresses are checked when dialing, after DNS resolution, so redirects are covered as well.
+// The allowed networks and hosts are used for legitimate internal resources.
+type NetworkGuard struct {
+	allowedNetworks []*net.IPNet
+	allowedHosts    map[string]bool
+}
+
+// NewNetworkGuard returns a guard that accepts the given CIDR ranges, IP addresses and host names.
+func NewNetworkGuard(allowlist []string) *NetworkGuard {
+	guard := &NetworkGuard{allowedHosts: make(map[string]bool)}
+
+	for _, item := range allowlist {
+		if _, network, err := net.ParseCIDR(item); err == nil {
+			guard.allowedNetworks = append(guard.allowedNetworks, network)
+		} else if ip := net.ParseIP(item); ip != nil {
+			bits := len(ip) * 8
+			guard.allowedNetworks = append(guard.allowedNetworks, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+		} else if item != "" {
+			guard.allowedHosts[strings.ToLower(item)] = true
+		}
+	}
+
+	return guard
+}
+
+// IsAllowedIP returns true if the IP address is public or belongs to one of the allowed networks.
+func (g *NetworkGuard) IsAllowedIP(ip net.IP) bool {
+	for _, network := range g.allowedNetworks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+
+	return !(ip.IsLoopback() ||
+		ip.IsPrivate() ||
+		ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() ||
+		ip.IsMulticast() ||
+		ip.IsUnspecified())
+}
+
+// Protect applies the guard to the transport.
+//
+// Connections to the proxies returned by the transport are not checked, they are configured by the administrator.
+// The proxy connects to the destination itself, so the destination of each request sent through a proxy,
+// including each redirect, is resolved and checked before the request is sent.
+func (g *NetworkGuard) Protect(transport *http.Transport, dialer *net.Dialer) {
+	var proxyAddresses sync.Map
+	if proxyFunc := transport.Proxy; proxyFunc != nil {
+		transport.Proxy = func(request *http.Request) (*url.URL, error) {
+			proxyURL, err := proxyFunc(request)
+			if err != nil || proxyURL == nil {
+				return proxyURL, err
+			}
+
+			if err := g.checkDestination(request); err != nil {
+				return nil, err
+			}
+
+			proxyAddresses.Store(proxyAddress(proxyURL), true)
+			return proxyURL, nil
+		}
+	}
+
+	guardedDialer := *dialer
+	guardedDialer.Control = func(network, address string, _ syscall.RawConn) error {
+		host, _, err := net.SplitHostPort(address)
+		if err != nil {
+			return err
+		}
+
+		if ip := net.ParseIP(host); ip == nil || !g.IsAllowedIP(ip) {
+			return &ForbiddenDestinationError{Address: address}
+		}
+		return nil
+	}
+
+	transport.DialContext = func(ctx context.Context, network, address string) (net.Conn, error) {
+		if _, found := proxyAddresses.Load(address); found {
+			return dialer.DialContext(ctx, network, address)
+		}
+
+		if host, _, err := net.SplitHostPort(address); err == nil && g.allowedHosts[strings.ToLower(host)] {
+			return dialer.DialContext(ctx, network, address)
+		}
+
+		return guardedDialer.DialContext(ctx, network, address)
+	}
+}
+
+// checkDestination resolves the host of a request sent through a proxy and checks its addresses.
+//
+// The names that cannot be resolved locally are left to the proxy, like the .onion addresses of a Tor proxy.
+func (g *NetworkGuard) checkDestination(request *http.Request) error {
+	host := request.URL.Hostname()
+	if g.allowedHosts[strings.ToLower(host)] {
+		return nil
+	}
+
+	address := request.URL.Host
+	if ip := net.ParseIP(host); ip != nil {
+		if !g.IsAllowedIP(ip) {
+			return &ForbiddenDestinationError{Address: address}
+		}
+		return nil
+	}
+
+	addresses, err := net.DefaultResolver.LookupIPAddr(request.Context(), host)
+	if err != nil {
+		return nil
+	}
+
+	for _, resolvedAddress := range addresses {
+		if !g.IsAllowedIP(resolvedAddress.IP) {
+			return &ForbiddenDestinationError{Address: address}
+		}
+	}
+	return nil
+}
+
+// proxyAddress returns the address dialed by the transport to reach the proxy.
+func proxyAddress(proxyURL *url.URL) string {
+	port := proxyURL.Port()
+	if port == "" {
+		switch proxyURL.Scheme {
+		case "https":
+			port = "443"
+		case "socks5":
+			port = "1080"
+		default:
+			port = "80"
+		}
+	}
+	return net.JoinHostPort(proxyURL.Hostname(), port)
+}
@@ -0,0 +1,143 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package client // import "miniflux.app/http/client"
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNetworkGuardIsAllowedIP(t *testing.T) {
+	guard := NewNetworkGuard([]string{"192.168.1.0/24", "10.0.0.1"})
+
+	scenarios := map[string]bool{
+		"93.184.216.34":   true,
+		"2606:2800:220::": true,
+		"127.0.0.1":       false,
+		"::1":             false,
+		"169.254.169.254": false,
+		"fe80::1":         false,
+		"172.16.0.1":      false,
+		"fd00::1":         false,
+		"224.0.0.1":       false,
+		"0.0.0.0":         false,
+		"::ffff:10.0.0.2": false,
+		"192.168.1.10":    true,
+		"192.168.2.10":    false,
+		"10.0.0.1":        true,
+	}
+
+	for input, expected := range scenarios {
+		if result := guard.IsAllowedIP(net.ParseIP(input)); result != expected {
+			t.Errorf(`Unexpected result for %s, got %v instead of %v`, input, result, expected)
+		}
+	}
+}
+
+func TestClientWithNetworkGuard(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("OK"))
+	}))
+	defer server.Close()
+
+	clt := New(server.URL)
+	clt.ClientNetworkGuard = NewNetworkGuard(nil)
+
+	if _, err := clt.Get(); err == nil {
+		t.Fatal(`The loopback address should be refused`)
+	}
+
+	clt = New(server.URL)
+	clt.ClientNetworkGuard = NewNetworkGuard([]string{"127.0.0.0/8"})
+
+	if _, err := clt.Get(); err != nil {
+		t.Fatalf(`The allowed network should be accepted: %v`, err)
+	}
+}
+
+func TestClientWithNetworkGuardAfterRedirect(t *testing.T) {
+	var port string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/redirect" {
+			http.Redirect(w, r, "http://127.0.0.1:"+port+"/feed", http.StatusFound)
+			return
+		}
+		w.Write([]byte("OK"))
+	}))
+	defer server.Close()
+
+	_, port, _ = net.SplitHostPort(strings.TrimPrefix(server.URL, "http://"))
+
+	clt := New("http://localhost:" + port + "/feed")
+	clt.ClientNetworkGuard = NewNetworkGuard([]string{"localhost"})
+
+	if _, err := clt.Get(); err != nil {
+		t.Fatalf(`The allowed host should be accepted: %v`, err)
+	}
+
+	clt = New("http://localhost:" + port + "/redirect")
+	clt.ClientNetworkGuard = NewNetworkGuard([]string{"localhost"})
+
+	if _, err := clt.Get(); err == nil {
+		t.Fatal(`The redirect to the loopback address should be refused`)
+	}
+}
+
+func TestClientWithNetworkGuardAndProxy(t *testing.T) {
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("proxied"))
+	}))
+	defer proxy.Close()
+
+	clt := New("http://feeds.example.org/feed.xml")
+	clt.ClientProxies = map[string]string{"local": proxy.URL}
+	clt.ClientNetworkGuard = NewNetworkGuard(nil)
+	clt.WithNamedProxy("local")
+
+	if _, err := clt.Get(); err != nil {
+		t.Fatalf(`The configured proxy should be trusted: %v`, err)
+	}
+}
+
+func TestClientWithNetworkGuardAndProxyToPrivateDestination(t *testing.T) {
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("proxied"))
+	}))
+	defer proxy.Close()
+
+	for _, target := range []string{"http://169.254.169.254/latest/meta-data/", "http://127.0.0.1:8080/", "http://localhost/"} {
+		clt := New(target)
+		clt.ClientProxies = map[string]string{"local": proxy.URL}
+		clt.ClientNetworkGuard = NewNetworkGuard(nil)
+		clt.WithNamedProxy("local")
+
+		if _, err := clt.Get(); err == nil {
+			t.Errorf(`The private destination %q should be refused through the proxy`, target)
+		}
+	}
+}
+
+func TestClientWithNetworkGuardAndProxyRedirectToPrivateDestination(t *testing.T) {
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Host == "feeds.example.org" {
+			http.Redirect(w, r, "http://169.254.169.254/", http.StatusFound)
+			return
+		}
+		w.Write([]byte("proxied"))
+	}))
+	defer proxy.Close()
+
+	clt := New("http://feeds.example.org/feed.xml")
+	clt.ClientProxies = map[string]string{"local": proxy.URL}
+	clt.ClientNetworkGuard = NewNetworkGuard(nil)
+	clt.WithNamedProxy("local")
+
+	if _, err := clt.Get(); err == nil {
+		t.Fatalf(`The redirect to a private destination should be refused through the proxy`)
+	}
+}
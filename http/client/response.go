@@ -8,8 +8,11 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"net/http"
 	"regexp"
+	"strconv"
 	"strings"
+	"time"
 	"unicode/utf8"
 
 	"golang.org/x/net/html/charset"
@@ -25,18 +28,29 @@ type Response struct {
 	LastModified  string
 	ETag          string
 	Expires       string
+	CacheControl  string
+	RetryAfter    string
 	ContentType   string
 	ContentLength int64
+	Redirects     []Redirect
+}
+
+// Redirect represents an HTTP redirection followed by the client.
+type Redirect struct {
+	StatusCode int
+	URL        string
 }
 
 func (r *Response) String() string {
 	return fmt.Sprintf(
-		`StatusCode=%d EffectiveURL=%q LastModified=%q ETag=%s Expires=%s ContentType=%q ContentLength=%d`,
+		`StatusCode=%d EffectiveURL=%q LastModified=%q ETag=%s Expires=%s CacheControl=%q RetryAfter=%q ContentType=%q ContentLength=%d`,
 		r.StatusCode,
 		r.EffectiveURL,
 		r.LastModified,
 		r.ETag,
 		r.Expires,
+		r.CacheControl,
+		r.RetryAfter,
 		r.ContentType,
 		r.ContentLength,
 	)
@@ -74,6 +88,60 @@ func (r *Response) IsModified(etag, lastModified string) bool {
 	return true
 }
 
+// PermanentURL returns the URL reached by following only permanent redirects (301 and 308).
+//
+// The redirects that come after a temporary redirect are ignored, an empty string is returned
+// when the resource has not been moved permanently.
+func (r *Response) PermanentURL() string {
+	var permanentURL string
+	for _, redirect := range r.Redirects {
+		if redirect.StatusCode != 301 && redirect.StatusCode != 308 {
+			break
+		}
+		permanentURL = redirect.URL
+	}
+	return permanentURL
+}
+
+// RetryAfterDelay returns the delay requested by the server with the Retry-After header.
+//
+// The header is only taken into consideration for 429 and 503 responses.
+func (r *Response) RetryAfterDelay() time.Duration {
+	if r.StatusCode != 429 && r.StatusCode != 503 {
+		return 0
+	}
+
+	return parseDelay(r.RetryAfter)
+}
+
+// CacheLifetime returns how long the resource is considered fresh according to
+// the Cache-Control and Expires headers.
+func (r *Response) CacheLifetime() time.Duration {
+	var maxAge time.Duration
+	var hasMaxAge bool
+
+	for _, directive := range strings.Split(r.CacheControl, ",") {
+		directive = strings.ToLower(strings.TrimSpace(directive))
+
+		switch {
+		case directive == "no-cache" || directive == "no-store":
+			return 0
+		case strings.HasPrefix(directive, "max-age="):
+			if seconds, err := strconv.Atoi(strings.TrimPrefix(directive, "max-age=")); err == nil && seconds > 0 {
+				maxAge = time.Duration(seconds) * time.Second
+				hasMaxAge = true
+			}
+		}
+	}
+
+	// The max-age directive takes precedence over the Expires header.
+	if hasMaxAge {
+		return maxAge
+	}
+
+	return parseDelay(r.Expires)
+}
+
 // EnsureUnicodeBody makes sure the body is encoded in UTF-8.
 //
 // If a charset other than UTF-8 is detected, we convert the document to UTF-8.
@@ -118,3 +186,26 @@ func (r *Response) BodyAsString() string {
 	bytes, _ := io.ReadAll(r.Body)
 	return string(bytes)
 }
+
+// parseDelay converts a header value expressed in seconds or as an HTTP date to a duration.
+func parseDelay(value string) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds > 0 {
+			return time.Duration(seconds) * time.Second
+		}
+		return 0
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		if delay := time.Until(date); delay > 0 {
+			return delay
+		}
+	}
+
+	return 0
+}
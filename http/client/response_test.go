@@ -6,9 +6,11 @@ package client // import "miniflux.app/http/client"
 
 import (
 	"bytes"
+	"net/http"
 	"os"
 	"strings"
 	"testing"
+	"time"
 	"unicode/utf8"
 )
 
@@ -91,6 +93,55 @@ func TestIsModifiedWithDifferentHeaders(t *testing.T) {
 	}
 }
 
+func TestRetryAfterDelayWithSeconds(t *testing.T) {
+	r := &Response{StatusCode: 429, RetryAfter: "120"}
+	if delay := r.RetryAfterDelay(); delay != 2*time.Minute {
+		t.Errorf(`Unexpected delay, got %v instead of 2m`, delay)
+	}
+}
+
+func TestRetryAfterDelayWithDate(t *testing.T) {
+	r := &Response{StatusCode: 503, RetryAfter: time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)}
+	if delay := r.RetryAfterDelay(); delay < 59*time.Minute || delay > time.Hour {
+		t.Errorf(`Unexpected delay, got %v instead of 1h`, delay)
+	}
+}
+
+func TestRetryAfterDelayIgnoredForOtherStatusCodes(t *testing.T) {
+	r := &Response{StatusCode: 200, RetryAfter: "120"}
+	if delay := r.RetryAfterDelay(); delay != 0 {
+		t.Errorf(`The Retry-After header should be ignored, got %v`, delay)
+	}
+}
+
+func TestCacheLifetimeWithMaxAge(t *testing.T) {
+	r := &Response{CacheControl: "public, max-age=3600", Expires: time.Now().Add(5 * time.Hour).UTC().Format(http.TimeFormat)}
+	if lifetime := r.CacheLifetime(); lifetime != time.Hour {
+		t.Errorf(`Unexpected cache lifetime, got %v instead of 1h`, lifetime)
+	}
+}
+
+func TestCacheLifetimeWithNoCache(t *testing.T) {
+	r := &Response{CacheControl: "max-age=3600, no-cache"}
+	if lifetime := r.CacheLifetime(); lifetime != 0 {
+		t.Errorf(`Unexpected cache lifetime, got %v instead of 0`, lifetime)
+	}
+}
+
+func TestCacheLifetimeWithExpires(t *testing.T) {
+	r := &Response{Expires: time.Now().Add(2 * time.Hour).UTC().Format(http.TimeFormat)}
+	if lifetime := r.CacheLifetime(); lifetime < 119*time.Minute || lifetime > 2*time.Hour {
+		t.Errorf(`Unexpected cache lifetime, got %v instead of 2h`, lifetime)
+	}
+}
+
+func TestCacheLifetimeWithInvalidExpires(t *testing.T) {
+	r := &Response{Expires: "0"}
+	if lifetime := r.CacheLifetime(); lifetime != 0 {
+		t.Errorf(`Unexpected cache lifetime, got %v instead of 0`, lifetime)
+	}
+}
+
 func TestToString(t *testing.T) {
 	input := `test`
 	r := &Response{Body: strings.NewReader(input)}
@@ -147,3 +198,24 @@ func TestEnsureUnicodeWithHTMLDocuments(t *testing.T) {
 		}
 	}
 }
+
+func TestPermanentURL(t *testing.T) {
+	scenarios := []struct {
+		redirects []Redirect
+		expected  string
+	}{
+		{nil, ""},
+		{[]Redirect{{301, "https://a.example.org/feed"}}, "https://a.example.org/feed"},
+		{[]Redirect{{308, "https://a.example.org/feed"}, {301, "https://b.example.org/feed"}}, "https://b.example.org/feed"},
+		{[]Redirect{{302, "https://a.example.org/feed"}}, ""},
+		{[]Redirect{{307, "https://a.example.org/feed"}, {301, "https://b.example.org/feed"}}, ""},
+		{[]Redirect{{301, "https://a.example.org/feed"}, {302, "https://b.example.org/feed"}}, "https://a.example.org/feed"},
+	}
+
+	for _, scenario := range scenarios {
+		r := &Response{Redirects: scenario.redirects}
+		if result := r.PermanentURL(); result != scenario.expected {
+			t.Errorf(`Unexpected permanent URL for %v, got %q instead of %q`, scenario.redirects, result, scenario.expected)
+		}
+	}
+}
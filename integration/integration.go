@@ -19,9 +19,37 @@ import (
 	"miniflux.app/model"
 )
 
+// Integrations that receive the entries saved by the user.
+const (
+	Pinboard    = "pinboard"
+	Instapaper  = "instapaper"
+	Wallabag    = "wallabag"
+	NunuxKeeper = "nunux_keeper"
+	Espial      = "espial"
+	Pocket      = "pocket"
+	Linkding    = "linkding"
+)
+
+// SavingIntegrations returns the names of the integrations that receive the entries saved by the user.
+func SavingIntegrations() []string {
+	return []string{Pinboard, Instapaper, Wallabag, NunuxKeeper, Espial, Pocket, Linkding}
+}
+
 // SendEntry sends the entry to third-party providers when the user click on "Save".
 func SendEntry(entry *model.Entry, integration *model.Integration) {
-	if integration.PinboardEnabled {
+	for _, name := range SavingIntegrations() {
+		SendEntryTo(name, entry, integration)
+	}
+}
+
+// SendEntryTo sends the entry to a single third-party provider, if it is enabled.
+func SendEntryTo(name string, entry *model.Entry, integration *model.Integration) {
+	switch name {
+	case Pinboard:
+		if !integration.PinboardEnabled {
+			return
+		}
+
 		logger.Debug("[Integration] Sending Entry #%d %q for User #%d to Pinboard", entry.ID, entry.URL, integration.UserID)
 
 		client := pinboard.NewClient(integration.PinboardToken)
@@ -35,18 +63,22 @@ func SendEntry(entry *model.Entry, integration *model.Integration) {
 		if err != nil {
 			logger.Error("[Integration] UserID #%d: %v", integration.UserID, err)
 		}
-	}
+	case Instapaper:
+		if !integration.InstapaperEnabled {
+			return
+		}
 
-	if integration.InstapaperEnabled {
 		logger.Debug("[Integration] Sending Entry #%d %q for User #%d to Instapaper", entry.ID, entry.URL, integration.UserID)
 
 		client := instapaper.NewClient(integration.InstapaperUsername, integration.InstapaperPassword)
 		if err := client.AddURL(entry.URL, entry.Title); err != nil {
 			logger.Error("[Integration] UserID #%d: %v", integration.UserID, err)
 		}
-	}
+	case Wallabag:
+		if !integration.WallabagEnabled {
+			return
+		}
 
-	if integration.WallabagEnabled {
 		logger.Debug("[Integration] Sending Entry #%d %q for User #%d to Wallabag", entry.ID, entry.URL, integration.UserID)
 
 		client := wallabag.NewClient(
@@ -61,9 +93,11 @@ func SendEntry(entry *model.Entry, integration *model.Integration) {
 		if err := client.AddEntry(entry.URL, entry.Title, entry.Content); err != nil {
 			logger.Error("[Integration] UserID #%d: %v", integration.UserID, err)
 		}
-	}
+	case NunuxKeeper:
+		if !integration.NunuxKeeperEnabled {
+			return
+		}
 
-	if integration.NunuxKeeperEnabled {
 		logger.Debug("[Integration] Sending Entry #%d %q for User #%d to NunuxKeeper", entry.ID, entry.URL, integration.UserID)
 
 		client := nunuxkeeper.NewClient(
@@ -74,9 +108,11 @@ func SendEntry(entry *model.Entry, integration *model.Integration) {
 		if err := client.AddEntry(entry.URL, entry.Title, entry.Content); err != nil {
 			logger.Error("[Integration] UserID #%d: %v", integration.UserID, err)
 		}
-	}
+	case Espial:
+		if !integration.EspialEnabled {
+			return
+		}
 
-	if integration.EspialEnabled {
 		logger.Debug("[Integration] Sending Entry #%d %q for User #%d to Espial", entry.ID, entry.URL, integration.UserID)
 
 		client := espial.NewClient(
@@ -87,18 +123,22 @@ func SendEntry(entry *model.Entry, integration *model.Integration) {
 		if err := client.AddEntry(entry.URL, entry.Title, entry.Content, integration.EspialTags); err != nil {
 			logger.Error("[Integration] UserID #%d: %v", integration.UserID, err)
 		}
-	}
+	case Pocket:
+		if !integration.PocketEnabled {
+			return
+		}
 
-	if integration.PocketEnabled {
 		logger.Debug("[Integration] Sending Entry #%d %q for User #%d to Pocket", entry.ID, entry.URL, integration.UserID)
 
 		client := pocket.NewClient(config.Opts.PocketConsumerKey(integration.PocketConsumerKey), integration.PocketAccessToken)
 		if err := client.AddURL(entry.URL, entry.Title); err != nil {
 			logger.Error("[Integration] UserID #%d: %v", integration.UserID, err)
 		}
-	}
+	case Linkding:
+		if !integration.LinkdingEnabled {
+			return
+		}
 
-	if integration.LinkdingEnabled {
 		logger.Debug("[Integration] Sending Entry #%d %q for User #%d to Linkding", entry.ID, entry.URL, integration.UserID)
 
 		client := linkding.NewClient(
@@ -45,6 +45,32 @@ var (
 		[]string{"status"},
 	)
 
+	PurgedEntries = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "miniflux",
+			Name:      "purged_entries_total",
+			Help:      "Number of removed entries replaced by a tombstone",
+		},
+	)
+
+	WorkerHostQueueDepth = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "miniflux",
+			Name:      "worker_host_queue_depth",
+			Help:      "Number of feeds waiting to be refreshed by host",
+		},
+		[]string{"host"},
+	)
+
+	WorkerQueueLength = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "miniflux",
+			Name:      "worker_queue_length",
+			Help:      "Number of feeds waiting to be refreshed by priority",
+		},
+		[]string{"priority"},
+	)
+
 	usersGauge = prometheus.NewGauge(
 		prometheus.GaugeOpts{
 			Namespace: "miniflux",
@@ -79,6 +105,14 @@ var (
 		[]string{"status"},
 	)
 
+	entryTombstonesGauge = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "miniflux",
+			Name:      "entry_tombstones",
+			Help:      "Number of purged entries remembered to avoid importing them again",
+		},
+	)
+
 	dbOpenConnectionsGauge = prometheus.NewGauge(
 		prometheus.GaugeOpts{
 			Namespace: "miniflux",
@@ -147,10 +181,14 @@ func NewCollector(store *storage.Storage, refreshInterval int) *Collector {
 	prometheus.MustRegister(BackgroundFeedRefreshDuration)
 	prometheus.MustRegister(ScraperRequestDuration)
 	prometheus.MustRegister(ArchiveEntriesDuration)
+	prometheus.MustRegister(PurgedEntries)
+	prometheus.MustRegister(WorkerHostQueueDepth)
+	prometheus.MustRegister(WorkerQueueLength)
 	prometheus.MustRegister(usersGauge)
 	prometheus.MustRegister(feedsGauge)
 	prometheus.MustRegister(brokenFeedsGauge)
 	prometheus.MustRegister(entriesGauge)
+	prometheus.MustRegister(entryTombstonesGauge)
 	prometheus.MustRegister(dbOpenConnectionsGauge)
 	prometheus.MustRegister(dbConnectionsInUseGauge)
 	prometheus.MustRegister(dbConnectionsIdleGauge)
@@ -180,6 +218,8 @@ func (c *Collector) GatherStorageMetrics() {
 			entriesGauge.WithLabelValues(status).Set(float64(count))
 		}
 
+		entryTombstonesGauge.Set(float64(c.store.CountEntryTombstones()))
+
 		dbStats := c.store.DBStats()
 		dbOpenConnectionsGauge.Set(float64(dbStats.OpenConnections))
 		dbConnectionsInUseGauge.Set(float64(dbStats.InUse))
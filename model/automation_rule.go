@@ -0,0 +1,74 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package model // import "miniflux.app/model"
+
+import (
+	"strings"
+	"time"
+)
+
+// Actions of the automation rules.
+const (
+	AutomationActionMarkAsRead        = "mark_as_read"
+	AutomationActionStar              = "star"
+	AutomationActionAddTags           = "add_tags"
+	AutomationActionReadLater         = "read_later"
+	AutomationActionSendToIntegration = "send_to_integration"
+)
+
+// AutomationActions returns the actions of the automation rules.
+func AutomationActions() []string {
+	return []string{
+		AutomationActionMarkAsRead,
+		AutomationActionStar,
+		AutomationActionAddTags,
+		AutomationActionReadLater,
+		AutomationActionSendToIntegration,
+	}
+}
+
+// AutomationRule represents an action applied to the new entries that match the filter rules.
+type AutomationRule struct {
+	ID        int64     `json:"id"`
+	UserID    int64     `json:"user_id"`
+	Name      string    `json:"name"`
+	Rules     string    `json:"rules"`
+	Action    string    `json:"action"`
+	Value     string    `json:"value"`
+	Disabled  bool      `json:"disabled"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Tags returns the tags added by the rule, they are separated by commas.
+func (a *AutomationRule) Tags() []string {
+	var tags []string
+	for _, tag := range strings.Split(a.Value, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// AutomationRules represents a list of automation rules.
+type AutomationRules []*AutomationRule
+
+// AutomationRuleRequest represents the request to create or update an automation rule.
+type AutomationRuleRequest struct {
+	Name     string `json:"name"`
+	Rules    string `json:"rules"`
+	Action   string `json:"action"`
+	Value    string `json:"value"`
+	Disabled bool   `json:"disabled"`
+}
+
+// Patch updates the automation rule fields.
+func (a *AutomationRuleRequest) Patch(rule *AutomationRule) {
+	rule.Name = a.Name
+	rule.Rules = a.Rules
+	rule.Action = a.Action
+	rule.Value = a.Value
+	rule.Disabled = a.Disabled
+}
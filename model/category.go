@@ -8,12 +8,16 @@ import "fmt"
 
 // Category represents a feed category.
 type Category struct {
-	ID           int64  `json:"id"`
-	Title        string `json:"title"`
-	UserID       int64  `json:"user_id"`
-	HideGlobally bool   `json:"hide_globally"`
-	FeedCount    int    `json:"-"`
-	TotalUnread  int    `json:"-"`
+	ID                  int64  `json:"id"`
+	Title               string `json:"title"`
+	UserID              int64  `json:"user_id"`
+	HideGlobally        bool   `json:"hide_globally"`
+	ProxyName           string `json:"proxy_name"`
+	RetentionReadDays   int    `json:"retention_read_days"`
+	RetentionUnreadDays int    `json:"retention_unread_days"`
+	RetentionMaxEntries int    `json:"retention_max_entries"`
+	FeedCount           int    `json:"-"`
+	TotalUnread         int    `json:"-"`
 }
 
 func (c *Category) String() string {
@@ -22,14 +26,22 @@ func (c *Category) String() string {
 
 // CategoryRequest represents the request to create or update a category.
 type CategoryRequest struct {
-	Title        string `json:"title"`
-	HideGlobally string `json:"hide_globally"`
+	Title               string `json:"title"`
+	HideGlobally        string `json:"hide_globally"`
+	ProxyName           string `json:"proxy_name"`
+	RetentionReadDays   int    `json:"retention_read_days"`
+	RetentionUnreadDays int    `json:"retention_unread_days"`
+	RetentionMaxEntries int    `json:"retention_max_entries"`
 }
 
 // Patch updates category fields.
 func (cr *CategoryRequest) Patch(category *Category) {
 	category.Title = cr.Title
 	category.HideGlobally = cr.HideGlobally != ""
+	category.ProxyName = cr.ProxyName
+	category.RetentionReadDays = cr.RetentionReadDays
+	category.RetentionUnreadDays = cr.RetentionUnreadDays
+	category.RetentionMaxEntries = cr.RetentionMaxEntries
 }
 
 // Categories represents a list of categories.
@@ -0,0 +1,21 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package model // import "miniflux.app/model"
+
+// What happens to the copies of an entry already published in another feed.
+const (
+	DuplicateEntriesKeep       = "keep"
+	DuplicateEntriesMarkAsRead = "mark_as_read"
+	DuplicateEntriesCollapse   = "collapse"
+)
+
+// DuplicateEntriesOptions returns the list of available options for the duplicate entries.
+func DuplicateEntriesOptions() map[string]string {
+	return map[string]string{
+		DuplicateEntriesKeep:       "form.prefs.select.duplicate_entries.keep",
+		DuplicateEntriesMarkAsRead: "form.prefs.select.duplicate_entries.mark_as_read",
+		DuplicateEntriesCollapse:   "form.prefs.select.duplicate_entries.collapse",
+	}
+}
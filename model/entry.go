@@ -34,10 +34,20 @@ type Entry struct {
 	Author      string        `json:"author"`
 	ShareCode   string        `json:"share_code"`
 	Starred     bool          `json:"starred"`
+	ReadLater   bool          `json:"read_later"`
 	ReadingTime int           `json:"reading_time"`
 	Enclosures  EnclosureList `json:"enclosures"`
 	Feed        *Feed         `json:"feed,omitempty"`
 	Tags        []string      `json:"tags"`
+
+	// DuplicateGroupID is the ID of the first copy of an entry published in several feeds, 0 if there is no copy.
+	DuplicateGroupID int64 `json:"duplicate_group_id"`
+
+	// RevisionCount is the number of times the feed changed the title or the content of the entry.
+	RevisionCount int `json:"revision_count"`
+
+	// Language is the ISO 639 code declared by the feed or detected from the content, empty if unknown.
+	Language string `json:"language"`
 }
 
 // Entries represents a list of entries.
@@ -0,0 +1,84 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package model // import "miniflux.app/model"
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+)
+
+// EntryCursor is the position of an entry in a list sorted by the given order and direction.
+//
+// A backward cursor points to the entries before the position.
+type EntryCursor struct {
+	Order     string `json:"o"`
+	Direction string `json:"d"`
+	SortKey   string `json:"k,omitempty"`
+	EntryID   int64  `json:"i"`
+	Backward  bool   `json:"b,omitempty"`
+}
+
+// NewEntryCursor returns the cursor of the entry position.
+func NewEntryCursor(entry *Entry, order, direction string, backward bool) *EntryCursor {
+	return &EntryCursor{
+		Order:     order,
+		Direction: direction,
+		SortKey:   EntrySortKey(entry, order),
+		EntryID:   entry.ID,
+		Backward:  backward,
+	}
+}
+
+// Encode returns the cursor as an opaque token.
+func (c *EntryCursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeEntryCursor parses a token returned by Encode.
+func DecodeEntryCursor(token string) (*EntryCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, errors.New("invalid cursor")
+	}
+
+	var cursor EntryCursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.EntryID <= 0 {
+		return nil, errors.New("invalid cursor")
+	}
+
+	return &cursor, nil
+}
+
+// EntrySortKey returns the value used to sort the entry in the given order.
+func EntrySortKey(entry *Entry, order string) string {
+	switch order {
+	case "status":
+		return entry.Status
+	case "changed_at":
+		return entry.ChangedAt.Format(time.RFC3339Nano)
+	case "published_at":
+		return entry.Date.Format(time.RFC3339Nano)
+	case "created_at":
+		return entry.CreatedAt.Format(time.RFC3339Nano)
+	case "category_title":
+		if entry.Feed != nil && entry.Feed.Category != nil {
+			return entry.Feed.Category.Title
+		}
+	case "category_id":
+		if entry.Feed != nil && entry.Feed.Category != nil {
+			return strconv.FormatInt(entry.Feed.Category.ID, 10)
+		}
+	case "title":
+		return entry.Title
+	case "author":
+		return entry.Author
+	}
+
+	return ""
+}
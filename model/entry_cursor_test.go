@@ -0,0 +1,62 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package model // import "miniflux.app/model"
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEntryCursorEncoding(t *testing.T) {
+	entry := &Entry{
+		ID:   42,
+		Date: time.Date(2023, time.March, 4, 10, 30, 0, 123456000, time.UTC),
+	}
+
+	cursor, err := DecodeEntryCursor(NewEntryCursor(entry, "published_at", "desc", true).Encode())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cursor.Order != "published_at" || cursor.Direction != "desc" || cursor.EntryID != 42 || !cursor.Backward {
+		t.Errorf(`Unexpected cursor: %+v`, cursor)
+	}
+
+	if cursor.SortKey != "2023-03-04T10:30:00.123456Z" {
+		t.Errorf(`Unexpected sort key, got %q`, cursor.SortKey)
+	}
+}
+
+func TestDecodeInvalidEntryCursor(t *testing.T) {
+	for _, token := range []string{"", "not a cursor", "e30", "eyJpIjotMX0"} {
+		if _, err := DecodeEntryCursor(token); err == nil {
+			t.Errorf(`The cursor %q should be invalid`, token)
+		}
+	}
+}
+
+func TestEntrySortKey(t *testing.T) {
+	entry := &Entry{
+		Status: EntryStatusUnread,
+		Title:  "Title",
+		Author: "Author",
+		Feed:   &Feed{Category: &Category{ID: 7, Title: "Category"}},
+	}
+
+	scenarios := map[string]string{
+		"id":             "",
+		"status":         "unread",
+		"category_title": "Category",
+		"category_id":    "7",
+		"title":          "Title",
+		"author":         "Author",
+	}
+
+	for order, expected := range scenarios {
+		if result := EntrySortKey(entry, order); result != expected {
+			t.Errorf(`Unexpected sort key for %q, got %q instead of %q`, order, result, expected)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package model // import "miniflux.app/model"
+
+import (
+	"strings"
+
+	"miniflux.app/crypto"
+)
+
+// How the entries of a feed are identified between two refreshes.
+const (
+	EntryIdentityGUID      = "guid"
+	EntryIdentityURL       = "url"
+	EntryIdentityURLTitle  = "url_title"
+	EntryIdentityTitleDate = "title_date"
+)
+
+// EntryIdentities returns the list of available entry identity strategies.
+func EntryIdentities() map[string]string {
+	return map[string]string{
+		EntryIdentityGUID:      "form.feed.select.entry_identity.guid",
+		EntryIdentityURL:       "form.feed.select.entry_identity.url",
+		EntryIdentityURLTitle:  "form.feed.select.entry_identity.url_title",
+		EntryIdentityTitleDate: "form.feed.select.entry_identity.title_date",
+	}
+}
+
+// IsValidEntryIdentity returns true if the entry identity strategy exists.
+func IsValidEntryIdentity(identity string) bool {
+	_, found := EntryIdentities()[identity]
+	return found
+}
+
+// EntryIdentityHash returns the hash of the entry for the given identity strategy.
+//
+// An empty string is returned for the GUID strategy, or when the entry lacks the required fields:
+// the parsers keep their own hash in that case. The date is truncated to the day, because some
+// feeds update the publication time of their entries.
+func EntryIdentityHash(identity string, entry *Entry) string {
+	url := strings.TrimSpace(entry.URL)
+	title := strings.TrimSpace(entry.Title)
+
+	switch identity {
+	case EntryIdentityURL:
+		if url != "" {
+			return crypto.Hash(url)
+		}
+	case EntryIdentityURLTitle:
+		if url != "" && title != "" {
+			return crypto.Hash(url + "\n" + title)
+		}
+	case EntryIdentityTitleDate:
+		if title != "" && !entry.Date.IsZero() {
+			return crypto.Hash(title + "\n" + entry.Date.UTC().Format("2006-01-02"))
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,42 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package model // import "miniflux.app/model"
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEntryIdentityHash(t *testing.T) {
+	entry := &Entry{
+		URL:   "https://example.org/post",
+		Title: "Title",
+		Date:  time.Date(2023, time.March, 4, 10, 0, 0, 0, time.UTC),
+	}
+
+	if hash := EntryIdentityHash(EntryIdentityGUID, entry); hash != "" {
+		t.Errorf(`The GUID strategy should keep the hash of the parser, got %q`, hash)
+	}
+
+	urlHash := EntryIdentityHash(EntryIdentityURL, entry)
+	urlTitleHash := EntryIdentityHash(EntryIdentityURLTitle, entry)
+	titleDateHash := EntryIdentityHash(EntryIdentityTitleDate, entry)
+	if urlHash == "" || urlTitleHash == "" || titleDateHash == "" {
+		t.Fatal(`All strategies should return a hash`)
+	}
+
+	if urlHash == urlTitleHash || urlHash == titleDateHash || urlTitleHash == titleDateHash {
+		t.Error(`Each strategy should return a different hash`)
+	}
+
+	later := &Entry{URL: "https://example.org/other", Title: "Title", Date: entry.Date.Add(5 * time.Hour)}
+	if hash := EntryIdentityHash(EntryIdentityTitleDate, later); hash != titleDateHash {
+		t.Error(`Entries with the same title published the same day should have the same hash`)
+	}
+
+	if hash := EntryIdentityHash(EntryIdentityURLTitle, &Entry{URL: entry.URL}); hash != "" {
+		t.Errorf(`No hash should be returned without title, got %q`, hash)
+	}
+}
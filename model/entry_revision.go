@@ -0,0 +1,21 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package model // import "miniflux.app/model"
+
+import "time"
+
+// EntryRevision is a previous version of an entry, saved when the feed changed its title or its content.
+type EntryRevision struct {
+	ID        int64     `json:"id"`
+	UserID    int64     `json:"user_id"`
+	EntryID   int64     `json:"entry_id"`
+	Title     string    `json:"title"`
+	URL       string    `json:"url"`
+	Content   string    `json:"content"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// EntryRevisions represents a list of entry revisions.
+type EntryRevisions []*EntryRevision
@@ -7,6 +7,7 @@ package model // import "miniflux.app/model"
 import (
 	"fmt"
 	"math"
+	"math/rand"
 	"time"
 
 	"miniflux.app/config"
@@ -22,41 +23,74 @@ const (
 	DefaultFeedSortingDirection = "desc"
 )
 
+// List of reasons explaining the next check date, in addition to the scheduler names.
+const (
+	NextCheckReasonRetryAfter   = "retry_after"
+	NextCheckReasonCacheHeaders = "cache_headers"
+	NextCheckReasonTTL          = "ttl"
+	NextCheckReasonSkipHours    = "skip_hours"
+	NextCheckReasonSkipDays     = "skip_days"
+	NextCheckReasonErrorBackoff = "error_backoff"
+	NextCheckReasonWebSub       = "websub"
+)
+
+// errorBackoffBaseInterval is the delay before retrying a feed after its first error.
+const errorBackoffBaseInterval = time.Hour
+
 // Feed represents a feed in the application.
 type Feed struct {
-	ID                          int64     `json:"id"`
-	UserID                      int64     `json:"user_id"`
-	FeedURL                     string    `json:"feed_url"`
-	SiteURL                     string    `json:"site_url"`
-	Title                       string    `json:"title"`
-	CheckedAt                   time.Time `json:"checked_at"`
-	NextCheckAt                 time.Time `json:"next_check_at"`
-	EtagHeader                  string    `json:"etag_header"`
-	LastModifiedHeader          string    `json:"last_modified_header"`
-	ParsingErrorMsg             string    `json:"parsing_error_message"`
-	ParsingErrorCount           int       `json:"parsing_error_count"`
-	ScraperRules                string    `json:"scraper_rules"`
-	RewriteRules                string    `json:"rewrite_rules"`
-	Crawler                     bool      `json:"crawler"`
-	BlocklistRules              string    `json:"blocklist_rules"`
-	KeeplistRules               string    `json:"keeplist_rules"`
-	UrlRewriteRules             string    `json:"urlrewrite_rules"`
-	UserAgent                   string    `json:"user_agent"`
-	Cookie                      string    `json:"cookie"`
-	Username                    string    `json:"username"`
-	Password                    string    `json:"password"`
-	Disabled                    bool      `json:"disabled"`
-	NoMediaPlayer               bool      `json:"no_media_player"`
-	IgnoreHTTPCache             bool      `json:"ignore_http_cache"`
-	AllowSelfSignedCertificates bool      `json:"allow_self_signed_certificates"`
-	FetchViaProxy               bool      `json:"fetch_via_proxy"`
-	Category                    *Category `json:"category,omitempty"`
-	Entries                     Entries   `json:"entries,omitempty"`
-	IconURL                     string    `json:"icon_url"`
-	Icon                        *FeedIcon `json:"icon"`
-	HideGlobally                bool      `json:"hide_globally"`
-	UnreadCount                 int       `json:"-"`
-	ReadCount                   int       `json:"-"`
+	ID                          int64       `json:"id"`
+	UserID                      int64       `json:"user_id"`
+	FeedURL                     string      `json:"feed_url"`
+	SiteURL                     string      `json:"site_url"`
+	Title                       string      `json:"title"`
+	CheckedAt                   time.Time   `json:"checked_at"`
+	NextCheckAt                 time.Time   `json:"next_check_at"`
+	NextCheckReason             string      `json:"next_check_reason"`
+	EtagHeader                  string      `json:"etag_header"`
+	LastModifiedHeader          string      `json:"last_modified_header"`
+	ParsingErrorMsg             string      `json:"parsing_error_message"`
+	ParsingErrorCount           int         `json:"parsing_error_count"`
+	ScraperRules                string      `json:"scraper_rules"`
+	RewriteRules                string      `json:"rewrite_rules"`
+	Crawler                     bool        `json:"crawler"`
+	BlocklistRules              string      `json:"blocklist_rules"`
+	KeeplistRules               string      `json:"keeplist_rules"`
+	UrlRewriteRules             string      `json:"urlrewrite_rules"`
+	UserAgent                   string      `json:"user_agent"`
+	Cookie                      string      `json:"cookie"`
+	Headers                     FeedHeaders `json:"headers"`
+	TLSClientCertificate        string      `json:"tls_client_certificate"`
+	TLSClientKey                string      `json:"-"`
+	TLSCABundle                 string      `json:"tls_ca_bundle"`
+	Username                    string      `json:"username"`
+	Password                    string      `json:"password"`
+	Disabled                    bool        `json:"disabled"`
+	NoMediaPlayer               bool        `json:"no_media_player"`
+	IgnoreHTTPCache             bool        `json:"ignore_http_cache"`
+	AllowSelfSignedCertificates bool        `json:"allow_self_signed_certificates"`
+	FetchViaProxy               bool        `json:"fetch_via_proxy"`
+	ProxyName                   string      `json:"proxy_name"`
+	EntryIdentity               string      `json:"entry_identity"`
+	PreviousEntryIdentity       string      `json:"-"`
+	RetentionReadDays           int         `json:"retention_read_days"`
+	RetentionUnreadDays         int         `json:"retention_unread_days"`
+	RetentionMaxEntries         int         `json:"retention_max_entries"`
+	Category                    *Category   `json:"category,omitempty"`
+	Entries                     Entries     `json:"entries,omitempty"`
+	IconURL                     string      `json:"icon_url"`
+	Icon                        *FeedIcon   `json:"icon"`
+	HideGlobally                bool        `json:"hide_globally"`
+	TTL                         int         `json:"-"`
+	SkipHours                   []int64     `json:"-"`
+	SkipDays                    []string    `json:"-"`
+	HubURL                      string      `json:"-"`
+	SelfURL                     string      `json:"-"`
+	WebSubActive                bool        `json:"websub_active"`
+	ClaimedBy                   string      `json:"claimed_by"`
+	MovedToURL                  string      `json:"moved_to_url"`
+	UnreadCount                 int         `json:"-"`
+	ReadCount                   int         `json:"-"`
 }
 
 type FeedCounters struct {
@@ -75,11 +109,49 @@ func (f *Feed) String() string {
 	)
 }
 
-// WithClientResponse updates feed attributes from an HTTP request.
+// ChangeEntryIdentity selects another entry identity strategy.
+//
+// The strategy used to store the existing entries is remembered until they are re-keyed during the next refresh.
+func (f *Feed) ChangeEntryIdentity(identity string) {
+	if identity == "" || identity == f.EntryIdentity {
+		return
+	}
+
+	if f.PreviousEntryIdentity == "" {
+		f.PreviousEntryIdentity = f.EntryIdentity
+	}
+
+	f.EntryIdentity = identity
+	if f.PreviousEntryIdentity == f.EntryIdentity {
+		f.PreviousEntryIdentity = ""
+	}
+}
+
+// TLSSettings returns the client certificate and the certificate authorities used to fetch the feed.
+func (f *Feed) TLSSettings() client.TLSSettings {
+	return client.TLSSettings{
+		ClientCertificate: f.TLSClientCertificate,
+		ClientKey:         f.TLSClientKey,
+		CABundle:          f.TLSCABundle,
+	}
+}
+
+// EffectiveProxyName returns the proxy selected for the feed, or the one selected for its category.
+//
+// An empty value means that the feed is fetched according to the FetchViaProxy option.
+func (f *Feed) EffectiveProxyName() string {
+	if f.ProxyName == "" && f.Category != nil {
+		return f.Category.ProxyName
+	}
+	return f.ProxyName
+}
+
+// WithClientResponse updates the caching headers of the feed from an HTTP response.
+//
+// The feed URL is not modified, permanent redirects are handled by the feed handler.
 func (f *Feed) WithClientResponse(response *client.Response) {
 	f.EtagHeader = response.ETag
 	f.LastModifiedHeader = response.LastModified
-	f.FeedURL = response.EffectiveURL
 }
 
 // WithCategoryID initializes the category attribute of the feed.
@@ -108,68 +180,220 @@ func (f *Feed) CheckedNow() {
 	}
 }
 
+// WithRefreshHints copies the refresh instructions declared in the given feed document.
+func (f *Feed) WithRefreshHints(feed *Feed) {
+	f.TTL = feed.TTL
+	f.SkipHours = feed.SkipHours
+	f.SkipDays = feed.SkipDays
+}
+
 // ScheduleNextCheck set "next_check_at" of a feed based on the scheduler selected from the configuration.
-func (f *Feed) ScheduleNextCheck(weeklyCount int) {
-	switch config.Opts.PollingScheduler() {
-	case SchedulerEntryFrequency:
+//
+// Feeds in error are retried with an exponential backoff instead.
+// Feeds with an active WebSub subscription are polled at the maximum interval because the hub pushes new entries.
+// The refresh hints sent by the publisher (Retry-After, caching headers, TTL, skipHours and skipDays)
+// can postpone the next check, within the limits of the scheduler intervals.
+func (f *Feed) ScheduleNextCheck(weeklyCount int, response *client.Response) {
+	minInterval := time.Duration(config.Opts.SchedulerEntryFrequencyMinInterval()) * time.Minute
+	maxInterval := time.Duration(config.Opts.SchedulerEntryFrequencyMaxInterval()) * time.Minute
+
+	var interval time.Duration
+	reason := SchedulerRoundRobin
+
+	switch {
+	case f.ParsingErrorCount > 0:
+		interval = f.errorBackoffInterval()
+		reason = NextCheckReasonErrorBackoff
+
+		if interval > maxInterval {
+			maxInterval = interval
+		}
+	case f.WebSubActive && config.Opts.HasWebSub():
+		interval = maxInterval
+		reason = NextCheckReasonWebSub
+	case config.Opts.PollingScheduler() == SchedulerEntryFrequency:
 		var intervalMinutes int
 		if weeklyCount == 0 {
 			intervalMinutes = config.Opts.SchedulerEntryFrequencyMaxInterval()
 		} else {
 			intervalMinutes = int(math.Round(float64(7*24*60) / float64(weeklyCount)))
 		}
-		intervalMinutes = int(math.Min(float64(intervalMinutes), float64(config.Opts.SchedulerEntryFrequencyMaxInterval())))
-		intervalMinutes = int(math.Max(float64(intervalMinutes), float64(config.Opts.SchedulerEntryFrequencyMinInterval())))
-		f.NextCheckAt = time.Now().Add(time.Minute * time.Duration(intervalMinutes))
-	default:
-		f.NextCheckAt = time.Now()
+		interval = clampInterval(time.Minute*time.Duration(intervalMinutes), minInterval, maxInterval)
+		reason = SchedulerEntryFrequency
+	}
+
+	var hints []refreshHint
+	if response != nil {
+		hints = append(hints, refreshHint{response.RetryAfterDelay(), NextCheckReasonRetryAfter})
+
+		if !f.IgnoreHTTPCache {
+			hints = append(hints, refreshHint{response.CacheLifetime(), NextCheckReasonCacheHeaders})
+		}
+	}
+	hints = append(hints, refreshHint{time.Duration(f.TTL) * time.Minute, NextCheckReasonTTL})
+
+	// Publishers can only ask us to come back later, not sooner.
+	for _, hint := range hints {
+		if hint.delay <= 0 {
+			continue
+		}
+
+		if delay := clampInterval(hint.delay, minInterval, maxInterval); delay > interval {
+			interval = delay
+			reason = hint.reason
+		}
+	}
+
+	now := time.Now()
+	nextCheckAt := now.Add(interval)
+
+	// Per the RSS specs, skipHours are expressed in GMT and skipDays in English.
+	for i := 0; i < 7*24; i++ {
+		utc := nextCheckAt.UTC()
+
+		var skipReason string
+		switch {
+		case f.skipsDay(utc.Weekday()):
+			skipReason = NextCheckReasonSkipDays
+		case f.skipsHour(utc.Hour()):
+			skipReason = NextCheckReasonSkipHours
+		}
+
+		if skipReason == "" {
+			break
+		}
+
+		nextCheckAt = utc.Truncate(time.Hour).Add(time.Hour)
+		reason = skipReason
+	}
+
+	if maxNextCheckAt := now.Add(maxInterval); nextCheckAt.After(maxNextCheckAt) {
+		nextCheckAt = maxNextCheckAt
+	}
+
+	f.NextCheckAt = nextCheckAt
+	f.NextCheckReason = reason
+}
+
+// IsBackingOff returns true if the next check of the feed is delayed because of previous errors.
+func (f *Feed) IsBackingOff() bool {
+	return f.NextCheckReason == NextCheckReasonErrorBackoff
+}
+
+// errorBackoffInterval returns the delay before the next attempt: 1h, 2h, 4h... up to the configured ceiling.
+func (f *Feed) errorBackoffInterval() time.Duration {
+	ceiling := time.Duration(config.Opts.SchedulerErrorBackoffMaxInterval()) * time.Minute
+
+	interval := ceiling
+	if f.ParsingErrorCount <= 20 {
+		if backoff := errorBackoffBaseInterval << (f.ParsingErrorCount - 1); backoff < ceiling {
+			interval = backoff
+		}
+	}
+
+	// Spread the retries to avoid checking all the broken feeds at the same time.
+	if jitter := int64(interval / 10); jitter > 0 {
+		interval -= time.Duration(rand.Int63n(jitter))
+	}
+
+	return interval
+}
+
+func (f *Feed) skipsHour(hour int) bool {
+	for _, skipHour := range f.SkipHours {
+		if int(skipHour) == hour {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *Feed) skipsDay(day time.Weekday) bool {
+	for _, skipDay := range f.SkipDays {
+		if skipDay == day.String() {
+			return true
+		}
+	}
+	return false
+}
+
+type refreshHint struct {
+	delay  time.Duration
+	reason string
+}
+
+func clampInterval(interval, minInterval, maxInterval time.Duration) time.Duration {
+	if interval > maxInterval {
+		interval = maxInterval
 	}
+	if interval < minInterval {
+		interval = minInterval
+	}
+	return interval
 }
 
 // FeedCreationRequest represents the request to create a feed.
 type FeedCreationRequest struct {
-	FeedURL                     string `json:"feed_url"`
-	CategoryID                  int64  `json:"category_id"`
-	UserAgent                   string `json:"user_agent"`
-	Cookie                      string `json:"cookie"`
-	Username                    string `json:"username"`
-	Password                    string `json:"password"`
-	Crawler                     bool   `json:"crawler"`
-	Disabled                    bool   `json:"disabled"`
-	NoMediaPlayer               bool   `json:"no_media_player"`
-	IgnoreHTTPCache             bool   `json:"ignore_http_cache"`
-	AllowSelfSignedCertificates bool   `json:"allow_self_signed_certificates"`
-	FetchViaProxy               bool   `json:"fetch_via_proxy"`
-	ScraperRules                string `json:"scraper_rules"`
-	RewriteRules                string `json:"rewrite_rules"`
-	BlocklistRules              string `json:"blocklist_rules"`
-	KeeplistRules               string `json:"keeplist_rules"`
-	HideGlobally                bool   `json:"hide_globally"`
-	UrlRewriteRules             string `json:"urlrewrite_rules"`
+	FeedURL                     string      `json:"feed_url"`
+	CategoryID                  int64       `json:"category_id"`
+	UserAgent                   string      `json:"user_agent"`
+	Cookie                      string      `json:"cookie"`
+	Headers                     FeedHeaders `json:"headers"`
+	TLSClientCertificate        string      `json:"tls_client_certificate"`
+	TLSClientKey                string      `json:"tls_client_key"`
+	TLSCABundle                 string      `json:"tls_ca_bundle"`
+	Username                    string      `json:"username"`
+	Password                    string      `json:"password"`
+	Crawler                     bool        `json:"crawler"`
+	Disabled                    bool        `json:"disabled"`
+	NoMediaPlayer               bool        `json:"no_media_player"`
+	IgnoreHTTPCache             bool        `json:"ignore_http_cache"`
+	AllowSelfSignedCertificates bool        `json:"allow_self_signed_certificates"`
+	FetchViaProxy               bool        `json:"fetch_via_proxy"`
+	ProxyName                   string      `json:"proxy_name"`
+	EntryIdentity               string      `json:"entry_identity"`
+	RetentionReadDays           int         `json:"retention_read_days"`
+	RetentionUnreadDays         int         `json:"retention_unread_days"`
+	RetentionMaxEntries         int         `json:"retention_max_entries"`
+	ScraperRules                string      `json:"scraper_rules"`
+	RewriteRules                string      `json:"rewrite_rules"`
+	BlocklistRules              string      `json:"blocklist_rules"`
+	KeeplistRules               string      `json:"keeplist_rules"`
+	HideGlobally                bool        `json:"hide_globally"`
+	UrlRewriteRules             string      `json:"urlrewrite_rules"`
 }
 
 // FeedModificationRequest represents the request to update a feed.
 type FeedModificationRequest struct {
-	FeedURL                     *string `json:"feed_url"`
-	SiteURL                     *string `json:"site_url"`
-	Title                       *string `json:"title"`
-	ScraperRules                *string `json:"scraper_rules"`
-	RewriteRules                *string `json:"rewrite_rules"`
-	BlocklistRules              *string `json:"blocklist_rules"`
-	KeeplistRules               *string `json:"keeplist_rules"`
-	UrlRewriteRules             *string `json:"urlrewrite_rules"`
-	Crawler                     *bool   `json:"crawler"`
-	UserAgent                   *string `json:"user_agent"`
-	Cookie                      *string `json:"cookie"`
-	Username                    *string `json:"username"`
-	Password                    *string `json:"password"`
-	CategoryID                  *int64  `json:"category_id"`
-	Disabled                    *bool   `json:"disabled"`
-	NoMediaPlayer               *bool   `json:"no_media_player"`
-	IgnoreHTTPCache             *bool   `json:"ignore_http_cache"`
-	AllowSelfSignedCertificates *bool   `json:"allow_self_signed_certificates"`
-	FetchViaProxy               *bool   `json:"fetch_via_proxy"`
-	HideGlobally                *bool   `json:"hide_globally"`
+	FeedURL                     *string      `json:"feed_url"`
+	SiteURL                     *string      `json:"site_url"`
+	Title                       *string      `json:"title"`
+	ScraperRules                *string      `json:"scraper_rules"`
+	RewriteRules                *string      `json:"rewrite_rules"`
+	BlocklistRules              *string      `json:"blocklist_rules"`
+	KeeplistRules               *string      `json:"keeplist_rules"`
+	UrlRewriteRules             *string      `json:"urlrewrite_rules"`
+	Crawler                     *bool        `json:"crawler"`
+	UserAgent                   *string      `json:"user_agent"`
+	Cookie                      *string      `json:"cookie"`
+	Headers                     *FeedHeaders `json:"headers"`
+	TLSClientCertificate        *string      `json:"tls_client_certificate"`
+	TLSClientKey                *string      `json:"tls_client_key"`
+	TLSCABundle                 *string      `json:"tls_ca_bundle"`
+	Username                    *string      `json:"username"`
+	Password                    *string      `json:"password"`
+	CategoryID                  *int64       `json:"category_id"`
+	Disabled                    *bool        `json:"disabled"`
+	NoMediaPlayer               *bool        `json:"no_media_player"`
+	IgnoreHTTPCache             *bool        `json:"ignore_http_cache"`
+	AllowSelfSignedCertificates *bool        `json:"allow_self_signed_certificates"`
+	FetchViaProxy               *bool        `json:"fetch_via_proxy"`
+	ProxyName                   *string      `json:"proxy_name"`
+	EntryIdentity               *string      `json:"entry_identity"`
+	RetentionReadDays           *int         `json:"retention_read_days"`
+	RetentionUnreadDays         *int         `json:"retention_unread_days"`
+	RetentionMaxEntries         *int         `json:"retention_max_entries"`
+	HideGlobally                *bool        `json:"hide_globally"`
 }
 
 // Patch updates a feed with modified values.
@@ -218,6 +442,27 @@ func (f *FeedModificationRequest) Patch(feed *Feed) {
 		feed.Cookie = *f.Cookie
 	}
 
+	if f.Headers != nil {
+		feed.Headers = *f.Headers
+	}
+
+	if f.TLSClientCertificate != nil {
+		feed.TLSClientCertificate = *f.TLSClientCertificate
+
+		// The key of the previous certificate is useless without it.
+		if feed.TLSClientCertificate == "" {
+			feed.TLSClientKey = ""
+		}
+	}
+
+	if f.TLSClientKey != nil && *f.TLSClientKey != "" {
+		feed.TLSClientKey = *f.TLSClientKey
+	}
+
+	if f.TLSCABundle != nil {
+		feed.TLSCABundle = *f.TLSCABundle
+	}
+
 	if f.Username != nil {
 		feed.Username = *f.Username
 	}
@@ -250,9 +495,29 @@ func (f *FeedModificationRequest) Patch(feed *Feed) {
 		feed.FetchViaProxy = *f.FetchViaProxy
 	}
 
+	if f.ProxyName != nil {
+		feed.ProxyName = *f.ProxyName
+	}
+
 	if f.HideGlobally != nil {
 		feed.HideGlobally = *f.HideGlobally
 	}
+
+	if f.EntryIdentity != nil {
+		feed.ChangeEntryIdentity(*f.EntryIdentity)
+	}
+
+	if f.RetentionReadDays != nil {
+		feed.RetentionReadDays = *f.RetentionReadDays
+	}
+
+	if f.RetentionUnreadDays != nil {
+		feed.RetentionUnreadDays = *f.RetentionUnreadDays
+	}
+
+	if f.RetentionMaxEntries != nil {
+		feed.RetentionMaxEntries = *f.RetentionMaxEntries
+	}
 }
 
 // Feeds is a list of feed
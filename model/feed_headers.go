@@ -0,0 +1,73 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package model // import "miniflux.app/model"
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// FeedHeaders represents the custom HTTP headers sent when fetching a feed, its entries and its icon.
+type FeedHeaders map[string]string
+
+// String returns the headers, one "Name: Value" per line, sorted by name.
+func (f FeedHeaders) String() string {
+	names := make([]string, 0, len(f))
+	for name := range f {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var lines []string
+	for _, name := range names {
+		lines = append(lines, name+": "+f[name])
+	}
+	return strings.Join(lines, "\n")
+}
+
+// ParseFeedHeaders parses headers written one "Name: Value" per line, the blank lines are ignored.
+func ParseFeedHeaders(text string) (FeedHeaders, error) {
+	headers := make(FeedHeaders)
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		name, value, found := strings.Cut(line, ":")
+		if !found {
+			return nil, fmt.Errorf("feed headers: invalid line %q", line)
+		}
+
+		headers[strings.TrimSpace(name)] = strings.TrimSpace(value)
+	}
+	return headers, nil
+}
+
+// Value converts the headers to JSON.
+func (f FeedHeaders) Value() (driver.Value, error) {
+	if f == nil {
+		f = make(FeedHeaders)
+	}
+	return json.Marshal(f)
+}
+
+// Scan converts raw JSON data.
+func (f *FeedHeaders) Scan(src interface{}) error {
+	source, ok := src.([]byte)
+	if !ok {
+		return errors.New("feed headers: unable to assert type of src")
+	}
+
+	if err := json.Unmarshal(source, f); err != nil {
+		return fmt.Errorf("feed headers: %v", err)
+	}
+
+	return nil
+}
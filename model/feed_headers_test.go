@@ -0,0 +1,32 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package model // import "miniflux.app/model"
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseFeedHeaders(t *testing.T) {
+	headers, err := ParseFeedHeaders("Authorization: Bearer a:b\n\n  PRIVATE-TOKEN :secret  \r\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := FeedHeaders{"Authorization": "Bearer a:b", "PRIVATE-TOKEN": "secret"}
+	if !reflect.DeepEqual(headers, expected) {
+		t.Errorf(`Unexpected headers, got %v instead of %v`, headers, expected)
+	}
+
+	if output := headers.String(); output != "Authorization: Bearer a:b\nPRIVATE-TOKEN: secret" {
+		t.Errorf(`Unexpected string representation: %q`, output)
+	}
+}
+
+func TestParseFeedHeadersWithInvalidLine(t *testing.T) {
+	if _, err := ParseFeedHeaders("Authorization"); err == nil {
+		t.Error(`A line without a colon should be rejected`)
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package model // import "miniflux.app/model"
+
+// FeedPreviewRequest represents the rules to try on a feed without saving them.
+//
+// The rules left empty are taken from the feed.
+type FeedPreviewRequest struct {
+	ScraperRules    *string `json:"scraper_rules"`
+	RewriteRules    *string `json:"rewrite_rules"`
+	BlocklistRules  *string `json:"blocklist_rules"`
+	KeeplistRules   *string `json:"keeplist_rules"`
+	UrlRewriteRules *string `json:"urlrewrite_rules"`
+	Crawler         *bool   `json:"crawler"`
+}
+
+// Patch replaces the rules of the feed with the rules to preview.
+func (f *FeedPreviewRequest) Patch(feed *Feed) {
+	if f.ScraperRules != nil {
+		feed.ScraperRules = *f.ScraperRules
+	}
+
+	if f.RewriteRules != nil {
+		feed.RewriteRules = *f.RewriteRules
+	}
+
+	if f.BlocklistRules != nil {
+		feed.BlocklistRules = *f.BlocklistRules
+	}
+
+	if f.KeeplistRules != nil {
+		feed.KeeplistRules = *f.KeeplistRules
+	}
+
+	if f.UrlRewriteRules != nil {
+		feed.UrlRewriteRules = *f.UrlRewriteRules
+	}
+
+	if f.Crawler != nil {
+		feed.Crawler = *f.Crawler
+	}
+}
+
+// FeedPreview represents the result of a refresh that is not saved.
+type FeedPreview struct {
+	FeedID       int64         `json:"feed_id"`
+	EffectiveURL string        `json:"effective_url"`
+	Entries      EntryPreviews `json:"entries"`
+}
+
+// EntryPreview describes an entry as it would be saved and the rules applied to it.
+type EntryPreview struct {
+	Hash          string   `json:"hash"`
+	Title         string   `json:"title"`
+	URL           string   `json:"url"`
+	Content       string   `json:"content"`
+	New           bool     `json:"new"`
+	Blocked       bool     `json:"blocked"`
+	BlocklistRule string   `json:"blocklist_rule,omitempty"` // The blocklist rule that blocked the entry.
+	KeeplistRule  string   `json:"keeplist_rule,omitempty"`  // The keeplist rule that kept the entry.
+	Crawled       bool     `json:"crawled"`
+	CrawlerError  string   `json:"crawler_error,omitempty"`
+	RewriteRules  []string `json:"rewrite_rules"`
+}
+
+// EntryPreviews represents a list of entry previews.
+type EntryPreviews []*EntryPreview
@@ -0,0 +1,92 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package model // import "miniflux.app/model"
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"miniflux.app/http/client"
+)
+
+// FeedRefresh represents one attempt to refresh a feed.
+type FeedRefresh struct {
+	ID             int64         `json:"id"`
+	FeedID         int64         `json:"feed_id"`
+	UserID         int64         `json:"-"`
+	StartedAt      time.Time     `json:"started_at"`
+	Duration       int64         `json:"duration"`
+	StatusCode     int           `json:"status_code"`
+	EffectiveURL   string        `json:"effective_url"`
+	Redirects      FeedRedirects `json:"redirects"`
+	NotModified    bool          `json:"not_modified"`
+	NewEntries     int           `json:"new_entries"`
+	UpdatedEntries int           `json:"updated_entries"`
+	Error          string        `json:"error"`
+}
+
+// NewFeedRefresh starts the history of a new refresh attempt.
+func NewFeedRefresh(userID, feedID int64) *FeedRefresh {
+	return &FeedRefresh{
+		FeedID:    feedID,
+		UserID:    userID,
+		StartedAt: time.Now(),
+		Redirects: make(FeedRedirects, 0),
+	}
+}
+
+// WithClientResponse records the outcome of the HTTP request.
+func (f *FeedRefresh) WithClientResponse(response *client.Response) {
+	f.StatusCode = response.StatusCode
+	f.EffectiveURL = response.EffectiveURL
+	f.NotModified = response.StatusCode == 304
+
+	for _, redirect := range response.Redirects {
+		f.Redirects = append(f.Redirects, FeedRedirect{StatusCode: redirect.StatusCode, URL: redirect.URL})
+	}
+}
+
+// Finish records the duration of the refresh in milliseconds and the error message, if any.
+func (f *FeedRefresh) Finish(errorMessage string) {
+	f.Duration = time.Since(f.StartedAt).Milliseconds()
+	f.Error = errorMessage
+}
+
+// FeedRefreshes represents a list of refresh attempts.
+type FeedRefreshes []*FeedRefresh
+
+// FeedRedirect represents an HTTP redirection followed while fetching a feed.
+type FeedRedirect struct {
+	StatusCode int    `json:"status_code"`
+	URL        string `json:"url"`
+}
+
+// FeedRedirects represents the redirect chain of a request.
+type FeedRedirects []FeedRedirect
+
+// Value converts the redirect chain to JSON.
+func (f FeedRedirects) Value() (driver.Value, error) {
+	if f == nil {
+		f = make(FeedRedirects, 0)
+	}
+	return json.Marshal(f)
+}
+
+// Scan converts raw JSON data.
+func (f *FeedRedirects) Scan(src interface{}) error {
+	source, ok := src.([]byte)
+	if !ok {
+		return errors.New("feed redirects: unable to assert type of src")
+	}
+
+	if err := json.Unmarshal(source, f); err != nil {
+		return fmt.Errorf("feed redirects: %v", err)
+	}
+
+	return nil
+}
@@ -28,8 +28,8 @@ func TestFeedWithResponse(t *testing.T) {
 		t.Fatal(`The LastModified header should be set`)
 	}
 
-	if feed.FeedURL != "Some URL" {
-		t.Fatal(`The Feed URL should be set`)
+	if feed.FeedURL != "" {
+		t.Fatal(`The Feed URL should not be changed`)
 	}
 }
 
@@ -93,7 +93,7 @@ func TestFeedScheduleNextCheckDefault(t *testing.T) {
 
 	feed := &Feed{}
 	weeklyCount := 10
-	feed.ScheduleNextCheck(weeklyCount)
+	feed.ScheduleNextCheck(weeklyCount, nil)
 
 	if feed.NextCheckAt.IsZero() {
 		t.Error(`The next_check_at must be set`)
@@ -116,7 +116,7 @@ func TestFeedScheduleNextCheckEntryCountBasedMaxInterval(t *testing.T) {
 	}
 	feed := &Feed{}
 	weeklyCount := maxInterval * 100
-	feed.ScheduleNextCheck(weeklyCount)
+	feed.ScheduleNextCheck(weeklyCount, nil)
 
 	if feed.NextCheckAt.IsZero() {
 		t.Error(`The next_check_at must be set`)
@@ -143,7 +143,7 @@ func TestFeedScheduleNextCheckEntryCountBasedMinInterval(t *testing.T) {
 	}
 	feed := &Feed{}
 	weeklyCount := minInterval / 2
-	feed.ScheduleNextCheck(weeklyCount)
+	feed.ScheduleNextCheck(weeklyCount, nil)
 
 	if feed.NextCheckAt.IsZero() {
 		t.Error(`The next_check_at must be set`)
@@ -153,3 +153,219 @@ func TestFeedScheduleNextCheckEntryCountBasedMinInterval(t *testing.T) {
 		t.Error(`The next_check_at should not be before the now + min interval`)
 	}
 }
+
+func TestFeedScheduleNextCheckWithRetryAfter(t *testing.T) {
+	os.Clearenv()
+
+	var err error
+	parser := config.NewParser()
+	config.Opts, err = parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	feed := &Feed{}
+	feed.ScheduleNextCheck(0, &client.Response{StatusCode: 429, RetryAfter: "3600"})
+
+	if feed.NextCheckReason != NextCheckReasonRetryAfter {
+		t.Errorf(`Unexpected next check reason, got %q`, feed.NextCheckReason)
+	}
+
+	if feed.NextCheckAt.Before(time.Now().Add(59 * time.Minute)) {
+		t.Error(`The next_check_at should honor the Retry-After header`)
+	}
+}
+
+func TestFeedScheduleNextCheckWithHintClampedByMaxInterval(t *testing.T) {
+	maxInterval := 120
+	os.Clearenv()
+	os.Setenv("SCHEDULER_ENTRY_FREQUENCY_MAX_INTERVAL", fmt.Sprintf("%d", maxInterval))
+
+	var err error
+	parser := config.NewParser()
+	config.Opts, err = parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	feed := &Feed{TTL: 24 * 60}
+	feed.ScheduleNextCheck(0, &client.Response{StatusCode: 200, CacheControl: "max-age=3600"})
+
+	if feed.NextCheckReason != NextCheckReasonTTL {
+		t.Errorf(`Unexpected next check reason, got %q`, feed.NextCheckReason)
+	}
+
+	if feed.NextCheckAt.After(time.Now().Add(time.Minute * time.Duration(maxInterval))) {
+		t.Error(`The next_check_at should not be after the now + max interval`)
+	}
+}
+
+func TestFeedScheduleNextCheckIgnoresCacheHeadersWhenHTTPCacheIsIgnored(t *testing.T) {
+	os.Clearenv()
+
+	var err error
+	parser := config.NewParser()
+	config.Opts, err = parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	feed := &Feed{IgnoreHTTPCache: true}
+	feed.ScheduleNextCheck(0, &client.Response{StatusCode: 200, CacheControl: "max-age=3600"})
+
+	if feed.NextCheckReason != SchedulerRoundRobin {
+		t.Errorf(`Unexpected next check reason, got %q`, feed.NextCheckReason)
+	}
+}
+
+func TestFeedScheduleNextCheckWithSkipHours(t *testing.T) {
+	os.Clearenv()
+
+	var err error
+	parser := config.NewParser()
+	config.Opts, err = parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	currentHour := int64(time.Now().UTC().Hour())
+	feed := &Feed{SkipHours: []int64{currentHour, (currentHour + 1) % 24}}
+	feed.ScheduleNextCheck(0, nil)
+
+	if feed.NextCheckReason != NextCheckReasonSkipHours {
+		t.Errorf(`Unexpected next check reason, got %q`, feed.NextCheckReason)
+	}
+
+	if hour := int64(feed.NextCheckAt.UTC().Hour()); hour != (currentHour+2)%24 {
+		t.Errorf(`The next_check_at should be after the skipped hours, got %v`, feed.NextCheckAt)
+	}
+}
+
+func TestFeedScheduleNextCheckWithErrorBackoff(t *testing.T) {
+	os.Clearenv()
+
+	var err error
+	parser := config.NewParser()
+	config.Opts, err = parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	scenarios := []struct {
+		errorCount int
+		expected   time.Duration
+	}{
+		{1, time.Hour},
+		{2, 2 * time.Hour},
+		{3, 4 * time.Hour},
+		{6, 24 * time.Hour},
+		{100, 24 * time.Hour},
+	}
+
+	for _, scenario := range scenarios {
+		feed := &Feed{ParsingErrorCount: scenario.errorCount}
+		feed.ScheduleNextCheck(0, nil)
+
+		if !feed.IsBackingOff() {
+			t.Errorf(`The feed should be backing off after %d errors`, scenario.errorCount)
+		}
+
+		// The jitter can reduce the interval by 10%.
+		minNextCheckAt := time.Now().Add(scenario.expected * 9 / 10).Add(-time.Minute)
+		maxNextCheckAt := time.Now().Add(scenario.expected)
+		if feed.NextCheckAt.Before(minNextCheckAt) || feed.NextCheckAt.After(maxNextCheckAt) {
+			t.Errorf(`Unexpected next_check_at after %d errors, got %v`, scenario.errorCount, feed.NextCheckAt)
+		}
+	}
+}
+
+func TestFeedScheduleNextCheckAfterSuccessfulRefresh(t *testing.T) {
+	os.Clearenv()
+
+	var err error
+	parser := config.NewParser()
+	config.Opts, err = parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	feed := &Feed{ParsingErrorCount: 5}
+	feed.ResetErrorCounter()
+	feed.ScheduleNextCheck(0, nil)
+
+	if feed.IsBackingOff() {
+		t.Error(`The backoff should be reset after a successful refresh`)
+	}
+
+	if feed.NextCheckAt.After(time.Now()) {
+		t.Error(`The next_check_at should not be delayed after a successful refresh`)
+	}
+}
+
+func TestFeedScheduleNextCheckWithActiveWebSubSubscription(t *testing.T) {
+	maxInterval := 600
+	os.Clearenv()
+	os.Setenv("SCHEDULER_ENTRY_FREQUENCY_MAX_INTERVAL", fmt.Sprintf("%d", maxInterval))
+	os.Setenv("WEBSUB", "1")
+
+	var err error
+	parser := config.NewParser()
+	config.Opts, err = parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	feed := &Feed{WebSubActive: true}
+	feed.ScheduleNextCheck(0, nil)
+
+	if feed.NextCheckReason != NextCheckReasonWebSub {
+		t.Errorf(`Unexpected next check reason, got %q`, feed.NextCheckReason)
+	}
+
+	if feed.NextCheckAt.Before(time.Now().Add(time.Minute * time.Duration(maxInterval-1))) {
+		t.Error(`The next_check_at should be delayed to the max interval`)
+	}
+
+	feed.WithError("Some Error")
+	feed.ScheduleNextCheck(0, nil)
+
+	if !feed.IsBackingOff() {
+		t.Error(`The error backoff should take precedence over the WebSub subscription`)
+	}
+}
+
+func TestFeedEffectiveProxyName(t *testing.T) {
+	feed := &Feed{Category: &Category{ProxyName: "tor"}}
+	if name := feed.EffectiveProxyName(); name != "tor" {
+		t.Errorf(`The proxy of the category should be used, got %q`, name)
+	}
+
+	feed.ProxyName = "direct"
+	if name := feed.EffectiveProxyName(); name != "direct" {
+		t.Errorf(`The proxy of the feed should take precedence, got %q`, name)
+	}
+
+	feed = &Feed{}
+	if name := feed.EffectiveProxyName(); name != "" {
+		t.Errorf(`No proxy should be selected, got %q`, name)
+	}
+}
+
+func TestFeedChangeEntryIdentity(t *testing.T) {
+	feed := &Feed{EntryIdentity: EntryIdentityGUID}
+
+	feed.ChangeEntryIdentity(EntryIdentityURL)
+	if feed.EntryIdentity != EntryIdentityURL || feed.PreviousEntryIdentity != EntryIdentityGUID {
+		t.Errorf(`Unexpected identities: %q, previously %q`, feed.EntryIdentity, feed.PreviousEntryIdentity)
+	}
+
+	feed.ChangeEntryIdentity(EntryIdentityTitleDate)
+	if feed.EntryIdentity != EntryIdentityTitleDate || feed.PreviousEntryIdentity != EntryIdentityGUID {
+		t.Errorf(`The entries are still stored with the first strategy, got %q`, feed.PreviousEntryIdentity)
+	}
+
+	feed.ChangeEntryIdentity(EntryIdentityGUID)
+	if feed.EntryIdentity != EntryIdentityGUID || feed.PreviousEntryIdentity != "" {
+		t.Errorf(`Restoring the first strategy should not re-key the entries, got %q`, feed.PreviousEntryIdentity)
+	}
+}
@@ -0,0 +1,39 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package model // import "miniflux.app/model"
+
+import "time"
+
+// Reasons of a feed URL change.
+const (
+	FeedURLChangeReasonPermanentRedirect = "permanent_redirect"
+	FeedURLChangeReasonMerge             = "merge"
+	FeedURLChangeReasonUser              = "user"
+)
+
+// FeedURLChange represents a change of the URL of a feed.
+type FeedURLChange struct {
+	ID          int64     `json:"id"`
+	FeedID      int64     `json:"feed_id"`
+	UserID      int64     `json:"-"`
+	PreviousURL string    `json:"previous_url"`
+	NewURL      string    `json:"new_url"`
+	Reason      string    `json:"reason"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// NewFeedURLChange records the move of the feed to a new URL.
+func NewFeedURLChange(feed *Feed, newURL, reason string) *FeedURLChange {
+	return &FeedURLChange{
+		FeedID:      feed.ID,
+		UserID:      feed.UserID,
+		PreviousURL: feed.FeedURL,
+		NewURL:      newURL,
+		Reason:      reason,
+	}
+}
+
+// FeedURLChanges represents the URL history of a feed.
+type FeedURLChanges []*FeedURLChange
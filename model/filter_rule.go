@@ -0,0 +1,21 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package model // import "miniflux.app/model"
+
+// Lists of filter rules.
+const (
+	FilterRuleBlocklist = "blocklist"
+	FilterRuleKeeplist  = "keeplist"
+)
+
+// FilterRule represents a global filter rule of a user and the number of entries it matched.
+type FilterRule struct {
+	List    string `json:"list"`
+	Rule    string `json:"rule"`
+	Matches int64  `json:"matches"`
+}
+
+// FilterRules represents a list of filter rules.
+type FilterRules []*FilterRule
@@ -6,8 +6,12 @@ package model // import "miniflux.app/model"
 
 // Job represents a payload sent to the processing queue.
 type Job struct {
-	UserID int64
-	FeedID int64
+	UserID  int64
+	FeedID  int64
+	FeedURL string
+
+	// Content is the feed document pushed by a WebSub hub, the feed is not fetched when it's set.
+	Content string
 }
 
 // JobList represents a list of jobs.
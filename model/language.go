@@ -0,0 +1,29 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package model // import "miniflux.app/model"
+
+import "strings"
+
+// NormalizeLanguage returns the lowercase primary language subtag of a language tag like "en-US" or "pt_BR".
+//
+// An empty string is returned when the tag doesn't start with an ISO 639 code.
+func NormalizeLanguage(tag string) string {
+	code := strings.ToLower(strings.TrimSpace(tag))
+	if index := strings.IndexAny(code, "-_"); index != -1 {
+		code = code[:index]
+	}
+
+	if len(code) < 2 || len(code) > 3 || code == "und" {
+		return ""
+	}
+
+	for _, r := range code {
+		if r < 'a' || r > 'z' {
+			return ""
+		}
+	}
+
+	return code
+}
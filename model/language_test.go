@@ -0,0 +1,30 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package model // import "miniflux.app/model"
+
+import "testing"
+
+func TestNormalizeLanguage(t *testing.T) {
+	scenarios := map[string]string{
+		"en":        "en",
+		"en-US":     "en",
+		" pt_BR ":   "pt",
+		"FR":        "fr",
+		"zh-Hant":   "zh",
+		"fil":       "fil",
+		"und":       "",
+		"":          "",
+		"english":   "",
+		"e":         "",
+		"12-34":     "",
+		"x-klingon": "",
+	}
+
+	for input, expected := range scenarios {
+		if result := NormalizeLanguage(input); result != expected {
+			t.Errorf(`Unexpected result for %q, got %q instead of %q`, input, result, expected)
+		}
+	}
+}
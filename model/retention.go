@@ -0,0 +1,17 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package model // import "miniflux.app/model"
+
+// Retention settings of feeds and categories.
+//
+// The settings of a feed take precedence over the ones of its category, which take precedence
+// over the global configuration. Starred and shared entries are never archived.
+const (
+	// RetentionInherit uses the setting of the category, or the global configuration.
+	RetentionInherit = 0
+
+	// RetentionNever disables the cleanup: the entries are never archived, or their number is unlimited.
+	RetentionNever = -1
+)
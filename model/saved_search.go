@@ -0,0 +1,34 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package model // import "miniflux.app/model"
+
+import "time"
+
+// SavedSearch represents a search query saved as a virtual feed.
+type SavedSearch struct {
+	ID        int64     `json:"id"`
+	UserID    int64     `json:"user_id"`
+	Title     string    `json:"title"`
+	Query     string    `json:"query"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// UnreadCount is the number of unread entries matching the query, it is only set when the searches are listed.
+	UnreadCount int `json:"unread_count"`
+}
+
+// SavedSearches represents a list of saved searches.
+type SavedSearches []*SavedSearch
+
+// SavedSearchRequest represents the request to create or update a saved search.
+type SavedSearchRequest struct {
+	Title string `json:"title"`
+	Query string `json:"query"`
+}
+
+// Patch updates the saved search fields.
+func (s *SavedSearchRequest) Patch(savedSearch *SavedSearch) {
+	savedSearch.Title = s.Title
+	savedSearch.Query = s.Query
+}
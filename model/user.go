@@ -35,6 +35,10 @@ type User struct {
 	CJKReadingSpeed        int        `json:"cjk_reading_speed"`
 	DefaultHomePage        string     `json:"default_home_page"`
 	CategoriesSortingOrder string     `json:"categories_sorting_order"`
+	BlocklistRules         string     `json:"blocklist_rules"`
+	KeeplistRules          string     `json:"keeplist_rules"`
+	DuplicateEntries       string     `json:"duplicate_entries"`
+	DuplicateTitleMatching bool       `json:"duplicate_title_matching"`
 }
 
 // UserCreationRequest represents the request to create a user.
@@ -69,6 +73,10 @@ type UserModificationRequest struct {
 	CJKReadingSpeed        *int    `json:"cjk_reading_speed"`
 	DefaultHomePage        *string `json:"default_home_page"`
 	CategoriesSortingOrder *string `json:"categories_sorting_order"`
+	BlocklistRules         *string `json:"blocklist_rules"`
+	KeeplistRules          *string `json:"keeplist_rules"`
+	DuplicateEntries       *string `json:"duplicate_entries"`
+	DuplicateTitleMatching *bool   `json:"duplicate_title_matching"`
 }
 
 // Patch updates the User object with the modification request.
@@ -156,6 +164,22 @@ func (u *UserModificationRequest) Patch(user *User) {
 	if u.CategoriesSortingOrder != nil {
 		user.CategoriesSortingOrder = *u.CategoriesSortingOrder
 	}
+
+	if u.BlocklistRules != nil {
+		user.BlocklistRules = *u.BlocklistRules
+	}
+
+	if u.KeeplistRules != nil {
+		user.KeeplistRules = *u.KeeplistRules
+	}
+
+	if u.DuplicateEntries != nil {
+		user.DuplicateEntries = *u.DuplicateEntries
+	}
+
+	if u.DuplicateTitleMatching != nil {
+		user.DuplicateTitleMatching = *u.DuplicateTitleMatching
+	}
 }
 
 // UseTimezone converts last login date to the given timezone.
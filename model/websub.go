@@ -0,0 +1,51 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package model // import "miniflux.app/model"
+
+import (
+	"time"
+
+	"miniflux.app/crypto"
+)
+
+// WebSub subscription states.
+const (
+	WebSubStatePending = "pending"
+	WebSubStateActive  = "active"
+	WebSubStateDenied  = "denied"
+)
+
+// WebSubSubscription represents the subscription of a feed to a WebSub hub.
+type WebSubSubscription struct {
+	FeedID         int64
+	UserID         int64
+	HubURL         string
+	TopicURL       string
+	Secret         string
+	CallbackToken  string
+	State          string
+	LeaseExpiresAt *time.Time
+}
+
+// NewWebSubSubscription initializes a pending subscription with a new secret and callback token.
+func NewWebSubSubscription(userID, feedID int64, hubURL, topicURL string) *WebSubSubscription {
+	return &WebSubSubscription{
+		FeedID:        feedID,
+		UserID:        userID,
+		HubURL:        hubURL,
+		TopicURL:      topicURL,
+		Secret:        crypto.GenerateRandomString(32),
+		CallbackToken: crypto.GenerateRandomString(32),
+		State:         WebSubStatePending,
+	}
+}
+
+// IsActive returns true if the hub confirmed the subscription and the lease is still valid.
+func (w *WebSubSubscription) IsActive() bool {
+	return w.State == WebSubStateActive && w.LeaseExpiresAt != nil && w.LeaseExpiresAt.After(time.Now())
+}
+
+// WebSubSubscriptions represents a list of WebSub subscriptions.
+type WebSubSubscriptions []*WebSubSubscription
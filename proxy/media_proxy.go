@@ -18,14 +18,17 @@ import (
 type urlProxyRewriter func(router *mux.Router, url string) string
 
 // ProxyRewriter replaces media URLs with internal proxy URLs.
-func ProxyRewriter(router *mux.Router, data string) string {
-	return genericProxyRewriter(router, ProxifyURL, data)
+func ProxyRewriter(router *mux.Router, data, proxyName string) string {
+	proxifyFunction := func(router *mux.Router, url string) string {
+		return ProxifyURL(router, url, proxyName)
+	}
+	return genericProxyRewriter(router, proxifyFunction, data)
 }
 
 // AbsoluteProxyRewriter do the same as ProxyRewriter except it uses absolute URLs.
-func AbsoluteProxyRewriter(router *mux.Router, host, data string) string {
+func AbsoluteProxyRewriter(router *mux.Router, host, data, proxyName string) string {
 	proxifyFunction := func(router *mux.Router, url string) string {
-		return AbsoluteProxifyURL(router, host, url)
+		return AbsoluteProxifyURL(router, host, url, proxyName)
 	}
 	return genericProxyRewriter(router, proxifyFunction, data)
 }
@@ -5,6 +5,7 @@
 package proxy // import "miniflux.app/proxy"
 
 import (
+	"encoding/base64"
 	"net/http"
 	"os"
 	"testing"
@@ -30,7 +31,7 @@ func TestProxyFilterWithHttpDefault(t *testing.T) {
 	r.HandleFunc("/proxy/{encodedDigest}/{encodedURL}", func(w http.ResponseWriter, r *http.Request) {}).Name("proxy")
 
 	input := `<p><img src="http://website/folder/image.png" alt="Test"/></p>`
-	output := ProxyRewriter(r, input)
+	output := ProxyRewriter(r, input, "")
 	expected := `<p><img src="/proxy/okK5PsdNY8F082UMQEAbLPeUFfbe2WnNfInNmR9T4WA=/aHR0cDovL3dlYnNpdGUvZm9sZGVyL2ltYWdlLnBuZw==" alt="Test"/></p>`
 
 	if expected != output {
@@ -54,7 +55,7 @@ func TestProxyFilterWithHttpsDefault(t *testing.T) {
 	r.HandleFunc("/proxy/{encodedDigest}/{encodedURL}", func(w http.ResponseWriter, r *http.Request) {}).Name("proxy")
 
 	input := `<p><img src="https://website/folder/image.png" alt="Test"/></p>`
-	output := ProxyRewriter(r, input)
+	output := ProxyRewriter(r, input, "")
 	expected := `<p><img src="https://website/folder/image.png" alt="Test"/></p>`
 
 	if expected != output {
@@ -77,7 +78,7 @@ func TestProxyFilterWithHttpNever(t *testing.T) {
 	r.HandleFunc("/proxy/{encodedDigest}/{encodedURL}", func(w http.ResponseWriter, r *http.Request) {}).Name("proxy")
 
 	input := `<p><img src="http://website/folder/image.png" alt="Test"/></p>`
-	output := ProxyRewriter(r, input)
+	output := ProxyRewriter(r, input, "")
 	expected := input
 
 	if expected != output {
@@ -100,7 +101,7 @@ func TestProxyFilterWithHttpsNever(t *testing.T) {
 	r.HandleFunc("/proxy/{encodedDigest}/{encodedURL}", func(w http.ResponseWriter, r *http.Request) {}).Name("proxy")
 
 	input := `<p><img src="https://website/folder/image.png" alt="Test"/></p>`
-	output := ProxyRewriter(r, input)
+	output := ProxyRewriter(r, input, "")
 	expected := input
 
 	if expected != output {
@@ -125,7 +126,7 @@ func TestProxyFilterWithHttpAlways(t *testing.T) {
 	r.HandleFunc("/proxy/{encodedDigest}/{encodedURL}", func(w http.ResponseWriter, r *http.Request) {}).Name("proxy")
 
 	input := `<p><img src="http://website/folder/image.png" alt="Test"/></p>`
-	output := ProxyRewriter(r, input)
+	output := ProxyRewriter(r, input, "")
 	expected := `<p><img src="/proxy/okK5PsdNY8F082UMQEAbLPeUFfbe2WnNfInNmR9T4WA=/aHR0cDovL3dlYnNpdGUvZm9sZGVyL2ltYWdlLnBuZw==" alt="Test"/></p>`
 
 	if expected != output {
@@ -150,7 +151,7 @@ func TestProxyFilterWithHttpsAlways(t *testing.T) {
 	r.HandleFunc("/proxy/{encodedDigest}/{encodedURL}", func(w http.ResponseWriter, r *http.Request) {}).Name("proxy")
 
 	input := `<p><img src="https://website/folder/image.png" alt="Test"/></p>`
-	output := ProxyRewriter(r, input)
+	output := ProxyRewriter(r, input, "")
 	expected := `<p><img src="/proxy/LdPNR1GBDigeeNp2ArUQRyZsVqT_PWLfHGjYFrrWWIY=/aHR0cHM6Ly93ZWJzaXRlL2ZvbGRlci9pbWFnZS5wbmc=" alt="Test"/></p>`
 
 	if expected != output {
@@ -175,7 +176,7 @@ func TestProxyFilterWithHttpsAlwaysAndCustomProxyServer(t *testing.T) {
 	r.HandleFunc("/proxy/{encodedDigest}/{encodedURL}", func(w http.ResponseWriter, r *http.Request) {}).Name("proxy")
 
 	input := `<p><img src="https://website/folder/image.png" alt="Test"/></p>`
-	output := ProxyRewriter(r, input)
+	output := ProxyRewriter(r, input, "")
 	expected := `<p><img src="https://proxy-example/proxy/aHR0cHM6Ly93ZWJzaXRlL2ZvbGRlci9pbWFnZS5wbmc=" alt="Test"/></p>`
 
 	if expected != output {
@@ -199,7 +200,7 @@ func TestProxyFilterWithHttpInvalid(t *testing.T) {
 	r.HandleFunc("/proxy/{encodedDigest}/{encodedURL}", func(w http.ResponseWriter, r *http.Request) {}).Name("proxy")
 
 	input := `<p><img src="http://website/folder/image.png" alt="Test"/></p>`
-	output := ProxyRewriter(r, input)
+	output := ProxyRewriter(r, input, "")
 	expected := `<p><img src="/proxy/okK5PsdNY8F082UMQEAbLPeUFfbe2WnNfInNmR9T4WA=/aHR0cDovL3dlYnNpdGUvZm9sZGVyL2ltYWdlLnBuZw==" alt="Test"/></p>`
 
 	if expected != output {
@@ -223,7 +224,7 @@ func TestProxyFilterWithHttpsInvalid(t *testing.T) {
 	r.HandleFunc("/proxy/{encodedDigest}/{encodedURL}", func(w http.ResponseWriter, r *http.Request) {}).Name("proxy")
 
 	input := `<p><img src="https://website/folder/image.png" alt="Test"/></p>`
-	output := ProxyRewriter(r, input)
+	output := ProxyRewriter(r, input, "")
 	expected := `<p><img src="https://website/folder/image.png" alt="Test"/></p>`
 
 	if expected != output {
@@ -249,7 +250,7 @@ func TestProxyFilterWithSrcset(t *testing.T) {
 
 	input := `<p><img src="http://website/folder/image.png" srcset="http://website/folder/image2.png 656w, http://website/folder/image3.png 360w" alt="test"></p>`
 	expected := `<p><img src="/proxy/okK5PsdNY8F082UMQEAbLPeUFfbe2WnNfInNmR9T4WA=/aHR0cDovL3dlYnNpdGUvZm9sZGVyL2ltYWdlLnBuZw==" srcset="/proxy/aY5Hb4urDnUCly2vTJ7ExQeeaVS-52O7kjUr2v9VrAs=/aHR0cDovL3dlYnNpdGUvZm9sZGVyL2ltYWdlMi5wbmc= 656w, /proxy/QgAmrJWiAud_nNAsz3F8OTxaIofwAiO36EDzH_YfMzo=/aHR0cDovL3dlYnNpdGUvZm9sZGVyL2ltYWdlMy5wbmc= 360w" alt="test"/></p>`
-	output := ProxyRewriter(r, input)
+	output := ProxyRewriter(r, input, "")
 
 	if expected != output {
 		t.Errorf(`Not expected output: got %s`, output)
@@ -274,7 +275,7 @@ func TestProxyFilterWithEmptySrcset(t *testing.T) {
 
 	input := `<p><img src="http://website/folder/image.png" srcset="" alt="test"></p>`
 	expected := `<p><img src="/proxy/okK5PsdNY8F082UMQEAbLPeUFfbe2WnNfInNmR9T4WA=/aHR0cDovL3dlYnNpdGUvZm9sZGVyL2ltYWdlLnBuZw==" srcset="" alt="test"/></p>`
-	output := ProxyRewriter(r, input)
+	output := ProxyRewriter(r, input, "")
 
 	if expected != output {
 		t.Errorf(`Not expected output: got %s`, output)
@@ -299,7 +300,7 @@ func TestProxyFilterWithPictureSource(t *testing.T) {
 
 	input := `<picture><source srcset="http://website/folder/image2.png 656w,   http://website/folder/image3.png 360w, https://website/some,image.png 2x"></picture>`
 	expected := `<picture><source srcset="/proxy/aY5Hb4urDnUCly2vTJ7ExQeeaVS-52O7kjUr2v9VrAs=/aHR0cDovL3dlYnNpdGUvZm9sZGVyL2ltYWdlMi5wbmc= 656w, /proxy/QgAmrJWiAud_nNAsz3F8OTxaIofwAiO36EDzH_YfMzo=/aHR0cDovL3dlYnNpdGUvZm9sZGVyL2ltYWdlMy5wbmc= 360w, /proxy/ZIw0hv8WhSTls5aSqhnFaCXlUrKIqTnBRaY0-NaLnds=/aHR0cHM6Ly93ZWJzaXRlL3NvbWUsaW1hZ2UucG5n 2x"/></picture>`
-	output := ProxyRewriter(r, input)
+	output := ProxyRewriter(r, input, "")
 
 	if expected != output {
 		t.Errorf(`Not expected output: got %s`, output)
@@ -324,7 +325,7 @@ func TestProxyFilterOnlyNonHTTPWithPictureSource(t *testing.T) {
 
 	input := `<picture><source srcset="http://website/folder/image2.png 656w, https://website/some,image.png 2x"></picture>`
 	expected := `<picture><source srcset="/proxy/aY5Hb4urDnUCly2vTJ7ExQeeaVS-52O7kjUr2v9VrAs=/aHR0cDovL3dlYnNpdGUvZm9sZGVyL2ltYWdlMi5wbmc= 656w, https://website/some,image.png 2x"/></picture>`
-	output := ProxyRewriter(r, input)
+	output := ProxyRewriter(r, input, "")
 
 	if expected != output {
 		t.Errorf(`Not expected output: got %s`, output)
@@ -348,7 +349,7 @@ func TestProxyWithImageDataURL(t *testing.T) {
 
 	input := `<img src="data:image/gif;base64,test">`
 	expected := `<img src="data:image/gif;base64,test"/>`
-	output := ProxyRewriter(r, input)
+	output := ProxyRewriter(r, input, "")
 
 	if expected != output {
 		t.Errorf(`Not expected output: got %s`, output)
@@ -372,9 +373,38 @@ func TestProxyWithImageSourceDataURL(t *testing.T) {
 
 	input := `<picture><source srcset="data:image/gif;base64,test"/></picture>`
 	expected := `<picture><source srcset="data:image/gif;base64,test"/></picture>`
-	output := ProxyRewriter(r, input)
+	output := ProxyRewriter(r, input, "")
 
 	if expected != output {
 		t.Errorf(`Not expected output: got %s`, output)
 	}
 }
+
+func TestProxyFilterWithNamedProxy(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("PROXY_OPTION", "all")
+	os.Setenv("PROXY_MEDIA_TYPES", "image")
+	os.Setenv("PROXY_PRIVATE_KEY", "test")
+
+	var err error
+	parser := config.NewParser()
+	config.Opts, err = parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	r := mux.NewRouter()
+	r.HandleFunc("/proxy/{encodedDigest}/{encodedURL}", func(w http.ResponseWriter, r *http.Request) {}).Name("proxy")
+
+	input := `<p><img src="http://website/folder/image.png" alt="Test"/></p>`
+	output := ProxyRewriter(r, input, "tor")
+	expected := `<p><img src="/proxy/` + base64.URLEncoding.EncodeToString(Signature("http://website/folder/image.png", "tor")) + `/aHR0cDovL3dlYnNpdGUvZm9sZGVyL2ltYWdlLnBuZw==?proxy=tor" alt="Test"/></p>`
+
+	if expected != output {
+		t.Errorf(`Not expected output: got "%s" instead of "%s"`, output, expected)
+	}
+
+	if string(Signature("http://website/folder/image.png", "tor")) == string(Signature("http://website/folder/image.png", "")) {
+		t.Error(`The proxy name should be part of the signature`)
+	}
+}
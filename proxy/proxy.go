@@ -18,16 +18,25 @@ import (
 	"miniflux.app/config"
 )
 
+// Signature returns the digest used to verify a proxified URL and the name of the outbound proxy to use.
+func Signature(link, proxyName string) []byte {
+	mac := hmac.New(sha256.New, config.Opts.ProxyPrivateKey())
+	mac.Write([]byte(link))
+	if proxyName != "" {
+		mac.Write([]byte("\x00" + proxyName))
+	}
+	return mac.Sum(nil)
+}
+
 // ProxifyURL generates a relative URL for a proxified resource.
-func ProxifyURL(router *mux.Router, link string) string {
+//
+// The proxy name selects the outbound proxy used to fetch the resource, it's ignored with an external media proxy.
+func ProxifyURL(router *mux.Router, link, proxyName string) string {
 	if link != "" {
 		proxyImageUrl := config.Opts.ProxyUrl()
 
 		if proxyImageUrl == "" {
-			mac := hmac.New(sha256.New, config.Opts.ProxyPrivateKey())
-			mac.Write([]byte(link))
-			digest := mac.Sum(nil)
-			return route.Path(router, "proxy", "encodedDigest", base64.URLEncoding.EncodeToString(digest), "encodedURL", base64.URLEncoding.EncodeToString([]byte(link)))
+			return proxifiedPath(router, link, proxyName)
 		}
 
 		proxyUrl, err := url.Parse(proxyImageUrl)
@@ -42,15 +51,12 @@ func ProxifyURL(router *mux.Router, link string) string {
 }
 
 // AbsoluteProxifyURL generates an absolute URL for a proxified resource.
-func AbsoluteProxifyURL(router *mux.Router, host, link string) string {
+func AbsoluteProxifyURL(router *mux.Router, host, link, proxyName string) string {
 	if link != "" {
 		proxyImageUrl := config.Opts.ProxyUrl()
 
 		if proxyImageUrl == "" {
-			mac := hmac.New(sha256.New, config.Opts.ProxyPrivateKey())
-			mac.Write([]byte(link))
-			digest := mac.Sum(nil)
-			path := route.Path(router, "proxy", "encodedDigest", base64.URLEncoding.EncodeToString(digest), "encodedURL", base64.URLEncoding.EncodeToString([]byte(link)))
+			path := proxifiedPath(router, link, proxyName)
 			if config.Opts.HTTPS {
 				return "https://" + host + path
 			} else {
@@ -68,3 +74,12 @@ func AbsoluteProxifyURL(router *mux.Router, host, link string) string {
 	}
 	return ""
 }
+
+func proxifiedPath(router *mux.Router, link, proxyName string) string {
+	digest := Signature(link, proxyName)
+	path := route.Path(router, "proxy", "encodedDigest", base64.URLEncoding.EncodeToString(digest), "encodedURL", base64.URLEncoding.EncodeToString([]byte(link)))
+	if proxyName != "" {
+		path += "?proxy=" + url.QueryEscape(proxyName)
+	}
+	return path
+}
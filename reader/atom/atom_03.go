@@ -27,7 +27,7 @@ type atom03Feed struct {
 	Entries []atom03Entry `xml:"entry"`
 }
 
-func (a *atom03Feed) Transform(baseURL string) *model.Feed {
+func (a *atom03Feed) Transform(baseURL, entryIdentity string) *model.Feed {
 	var err error
 
 	feed := new(model.Feed)
@@ -38,6 +38,17 @@ func (a *atom03Feed) Transform(baseURL string) *model.Feed {
 		feed.FeedURL = feedURL
 	}
 
+	if feedURL != "" {
+		feed.SelfURL = feed.FeedURL
+	}
+
+	if hubURL := a.Links.firstLinkWithRelation("hub"); hubURL != "" {
+		feed.HubURL, err = url.AbsoluteURL(baseURL, hubURL)
+		if err != nil {
+			feed.HubURL = hubURL
+		}
+	}
+
 	siteURL := a.Links.originalLink()
 	feed.SiteURL, err = url.AbsoluteURL(baseURL, siteURL)
 	if err != nil {
@@ -68,6 +79,10 @@ func (a *atom03Feed) Transform(baseURL string) *model.Feed {
 			item.Title = item.URL
 		}
 
+		if hash := model.EntryIdentityHash(entryIdentity, item); hash != "" {
+			item.Hash = hash
+		}
+
 		feed.Entries = append(feed.Entries, item)
 	}
 
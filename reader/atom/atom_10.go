@@ -25,6 +25,7 @@ import (
 // https://validator.w3.org/feed/docs/atom.html
 type atom10Feed struct {
 	XMLName xml.Name      `xml:"http://www.w3.org/2005/Atom feed"`
+	Lang    string        `xml:"http://www.w3.org/XML/1998/namespace lang,attr"`
 	ID      string        `xml:"id"`
 	Title   atom10Text    `xml:"title"`
 	Authors atomAuthors   `xml:"author"`
@@ -33,7 +34,7 @@ type atom10Feed struct {
 	Entries []atom10Entry `xml:"entry"`
 }
 
-func (a *atom10Feed) Transform(baseURL string) *model.Feed {
+func (a *atom10Feed) Transform(baseURL, entryIdentity string) *model.Feed {
 	var err error
 
 	feed := new(model.Feed)
@@ -44,6 +45,17 @@ func (a *atom10Feed) Transform(baseURL string) *model.Feed {
 		feed.FeedURL = feedURL
 	}
 
+	if feedURL != "" {
+		feed.SelfURL = feed.FeedURL
+	}
+
+	if hubURL := a.Links.firstLinkWithRelation("hub"); hubURL != "" {
+		feed.HubURL, err = url.AbsoluteURL(baseURL, hubURL)
+		if err != nil {
+			feed.HubURL = hubURL
+		}
+	}
+
 	siteURL := a.Links.originalLink()
 	feed.SiteURL, err = url.AbsoluteURL(baseURL, siteURL)
 	if err != nil {
@@ -57,6 +69,7 @@ func (a *atom10Feed) Transform(baseURL string) *model.Feed {
 
 	feed.IconURL = strings.TrimSpace(a.Icon)
 
+	language := model.NormalizeLanguage(a.Lang)
 	for _, entry := range a.Entries {
 		item := entry.Transform()
 		entryURL, err := url.AbsoluteURL(feed.SiteURL, item.URL)
@@ -68,6 +81,10 @@ func (a *atom10Feed) Transform(baseURL string) *model.Feed {
 			item.Author = a.Authors.String()
 		}
 
+		if item.Language == "" {
+			item.Language = language
+		}
+
 		if item.Title == "" {
 			item.Title = sanitizer.TruncateHTML(item.Content, 100)
 		}
@@ -76,6 +93,10 @@ func (a *atom10Feed) Transform(baseURL string) *model.Feed {
 			item.Title = item.URL
 		}
 
+		if hash := model.EntryIdentityHash(entryIdentity, item); hash != "" {
+			item.Hash = hash
+		}
+
 		feed.Entries = append(feed.Entries, item)
 	}
 
@@ -84,6 +105,7 @@ func (a *atom10Feed) Transform(baseURL string) *model.Feed {
 
 type atom10Entry struct {
 	ID         string           `xml:"id"`
+	Lang       string           `xml:"http://www.w3.org/XML/1998/namespace lang,attr"`
 	Title      atom10Text       `xml:"title"`
 	Published  string           `xml:"published"`
 	Updated    string           `xml:"updated"`
@@ -106,6 +128,7 @@ func (a *atom10Entry) Transform() *model.Entry {
 	entry.Enclosures = a.entryEnclosures()
 	entry.CommentsURL = a.entryCommentsURL()
 	entry.Tags = a.entryCategories()
+	entry.Language = model.NormalizeLanguage(a.Lang)
 	return entry
 }
 
@@ -8,6 +8,9 @@ import (
 	"bytes"
 	"testing"
 	"time"
+
+	"miniflux.app/crypto"
+	"miniflux.app/model"
 )
 
 func TestParseAtomSample(t *testing.T) {
@@ -217,6 +220,51 @@ func TestParseFeedURL(t *testing.T) {
 	}
 }
 
+func TestParseFeedWithHubLink(t *testing.T) {
+	data := `<?xml version="1.0" encoding="utf-8"?>
+	<feed xmlns="http://www.w3.org/2005/Atom">
+	  <title>Example Feed</title>
+	  <link rel="hub" href="https://hub.example.org/"/>
+	  <link rel="self" type="application/atom+xml" href="/feed"/>
+	  <updated>2003-12-13T18:30:02Z</updated>
+	</feed>`
+
+	feed, err := Parse("https://example.org/", bytes.NewBufferString(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.HubURL != "https://hub.example.org/" {
+		t.Errorf("Incorrect hub URL, got: %s", feed.HubURL)
+	}
+
+	if feed.SelfURL != "https://example.org/feed" {
+		t.Errorf("Incorrect self URL, got: %s", feed.SelfURL)
+	}
+}
+
+func TestParseFeedWithoutHubLink(t *testing.T) {
+	data := `<?xml version="1.0" encoding="utf-8"?>
+	<feed xmlns="http://www.w3.org/2005/Atom">
+	  <title>Example Feed</title>
+	  <link rel="alternate" type="text/html" href="https://example.org/"/>
+	  <updated>2003-12-13T18:30:02Z</updated>
+	</feed>`
+
+	feed, err := Parse("https://example.org/", bytes.NewBufferString(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.HubURL != "" {
+		t.Errorf("Incorrect hub URL, got: %s", feed.HubURL)
+	}
+
+	if feed.SelfURL != "" {
+		t.Errorf("Incorrect self URL, got: %s", feed.SelfURL)
+	}
+}
+
 func TestParseFeedWithRelativeURL(t *testing.T) {
 	data := `<?xml version="1.0" encoding="utf-8"?>
 	<feed xmlns="http://www.w3.org/2005/Atom">
@@ -1649,3 +1697,59 @@ func TestParseFeedWithCategories(t *testing.T) {
 		t.Errorf("Incorrect entry category, got %q instead of %q", result, expected)
 	}
 }
+
+func TestParseEntryWithURLIdentity(t *testing.T) {
+	data := `<?xml version="1.0" encoding="utf-8"?>
+	<feed xmlns="http://www.w3.org/2005/Atom">
+		<title>Example Feed</title>
+		<link href="http://example.org/"/>
+		<entry>
+			<title>Test</title>
+			<link href="/2003/12/13/atom03"/>
+			<id>urn:uuid:1225c695-cfb8-4ebb-aaaa-80da344efa6a</id>
+			<updated>2003-12-13T18:30:02Z</updated>
+		</entry>
+	</feed>`
+
+	feed, err := ParseWithEntryIdentity("http://example.org/feed.xml", bytes.NewBufferString(data), model.EntryIdentityURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.Entries[0].Hash != crypto.Hash("http://example.org/2003/12/13/atom03") {
+		t.Errorf("Incorrect entry hash, got: %s", feed.Entries[0].Hash)
+	}
+}
+
+func TestParseEntryLanguage(t *testing.T) {
+	data := `<?xml version="1.0" encoding="utf-8"?>
+	<feed xmlns="http://www.w3.org/2005/Atom" xml:lang="en-US">
+		<title>Example Feed</title>
+		<link href="http://example.org/"/>
+		<entry>
+			<title>Test</title>
+			<link href="http://example.org/a"/>
+			<id>urn:uuid:1225c695-cfb8-4ebb-aaaa-80da344efa6a</id>
+			<updated>2003-12-13T18:30:02Z</updated>
+		</entry>
+		<entry xml:lang="fr">
+			<title>Essai</title>
+			<link href="http://example.org/b"/>
+			<id>urn:uuid:1225c695-cfb8-4ebb-aaaa-80da344efa6b</id>
+			<updated>2003-12-13T18:30:02Z</updated>
+		</entry>
+	</feed>`
+
+	feed, err := Parse("http://example.org/feed.xml", bytes.NewBufferString(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.Entries[0].Language != "en" {
+		t.Errorf("Incorrect entry language, got: %q", feed.Entries[0].Language)
+	}
+
+	if feed.Entries[1].Language != "fr" {
+		t.Errorf("Incorrect entry language, got: %q", feed.Entries[1].Language)
+	}
+}
@@ -15,11 +15,16 @@ import (
 )
 
 type atomFeed interface {
-	Transform(baseURL string) *model.Feed
+	Transform(baseURL, entryIdentity string) *model.Feed
 }
 
 // Parse returns a normalized feed struct from a Atom feed.
 func Parse(baseURL string, r io.Reader) (*model.Feed, *errors.LocalizedError) {
+	return ParseWithEntryIdentity(baseURL, r, model.EntryIdentityGUID)
+}
+
+// ParseWithEntryIdentity returns a normalized feed struct from a Atom feed, the entries are identified with the given strategy.
+func ParseWithEntryIdentity(baseURL string, r io.Reader, entryIdentity string) (*model.Feed, *errors.LocalizedError) {
 	var buf bytes.Buffer
 	tee := io.TeeReader(r, &buf)
 
@@ -36,7 +41,7 @@ func Parse(baseURL string, r io.Reader) (*model.Feed, *errors.LocalizedError) {
 		return nil, errors.NewLocalizedError("Unable to parse Atom feed: %q", err)
 	}
 
-	return rawFeed.Transform(baseURL), nil
+	return rawFeed.Transform(baseURL, entryIdentity), nil
 }
 
 func getAtomFeedVersion(data io.Reader) string {
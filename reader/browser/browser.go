@@ -19,6 +19,8 @@ var (
 )
 
 // Exec executes a HTTP request and handles errors.
+//
+// The response is also returned for HTTP errors, so the caller can inspect the headers sent by the server.
 func Exec(request *client.Client) (*client.Response, *errors.LocalizedError) {
 	response, err := request.Get()
 	if err != nil {
@@ -29,15 +31,15 @@ func Exec(request *client.Client) (*client.Response, *errors.LocalizedError) {
 	}
 
 	if response.IsNotFound() {
-		return nil, errors.NewLocalizedError(errResourceNotFound)
+		return response, errors.NewLocalizedError(errResourceNotFound)
 	}
 
 	if response.IsNotAuthorized() {
-		return nil, errors.NewLocalizedError(errNotAuthorized)
+		return response, errors.NewLocalizedError(errNotAuthorized)
 	}
 
 	if response.HasServerFailure() {
-		return nil, errors.NewLocalizedError(errServerFailure, response.StatusCode)
+		return response, errors.NewLocalizedError(errServerFailure, response.StatusCode)
 	}
 
 	if response.StatusCode != 304 {
@@ -0,0 +1,24 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+/*
+Package filter implements the rules used to block or keep feed entries.
+
+Each line of the rules is a condition, blank lines and lines starting with # are ignored:
+
+	#!rules
+	title contains "sponsored" and not author equals "Staff"
+	(tag equals video or enclosure_type starts_with video/) and date after 30d
+	not language equals en
+
+The blocklist and keeplist rules written before the rule language are a single regular expression
+matched against the entry title. They keep working unchanged: the rule language is used only when
+the first line is #!rules or when the rules are not a valid regular expression.
+
+The fields are title, url, author, content, tag, enclosure_type, language and date.
+The language is the ISO 639 code declared by the feed or detected from the content, like en or fr.
+The text operators are contains, equals, starts_with, ends_with and matches, they are case-insensitive.
+The date operators are before and after, with a date like 2023-01-31 or a relative duration like 12h or 30d.
+*/
+package filter // import "miniflux.app/reader/filter"
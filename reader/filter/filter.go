@@ -0,0 +1,196 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package filter // import "miniflux.app/reader/filter"
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"miniflux.app/model"
+)
+
+// SyntaxHeader is the first line of the blocklist and keeplist rules written with the rule language.
+const SyntaxHeader = "#!rules"
+
+// SyntaxError represents an invalid rule.
+type SyntaxError struct {
+	Line    int
+	Message string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Message)
+}
+
+// Rules represents the list of rules, an entry matches when any rule matches.
+type Rules []*Rule
+
+// Rule represents a single line of the rules.
+type Rule struct {
+	Text       string
+	expression expression
+}
+
+// ParseListRules compiles the blocklist or keeplist rules.
+//
+// The rules written before the rule language are a single regular expression matched against the title,
+// they are kept as is unless the first line is the SyntaxHeader or the text is not a valid regular expression.
+func ParseListRules(text string) (Rules, error) {
+	if text == "" {
+		return nil, nil
+	}
+
+	firstLine, _, _ := strings.Cut(text, "\n")
+	if strings.TrimSpace(firstLine) != SyntaxHeader {
+		if regex, err := regexp.Compile(text); err == nil {
+			return Rules{{Text: text, expression: &titleRegexExpression{regex: regex}}}, nil
+		}
+	}
+
+	return Parse(text)
+}
+
+// Parse compiles the rules written with the rule language, one condition per line.
+//
+// Blank lines and lines starting with # are ignored.
+func Parse(text string) (Rules, error) {
+	var rules Rules
+	for i, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		expr, err := parseCondition(line)
+		if err != nil {
+			return nil, &SyntaxError{Line: i + 1, Message: err.Error()}
+		}
+
+		rules = append(rules, &Rule{Text: line, expression: expr})
+	}
+	return rules, nil
+}
+
+// Match returns the first rule that matches the entry.
+func (r Rules) Match(entry *model.Entry) (*Rule, bool) {
+	for _, rule := range r {
+		if rule.expression.match(entry) {
+			return rule, true
+		}
+	}
+	return nil, false
+}
+
+type expression interface {
+	match(entry *model.Entry) bool
+}
+
+type andExpression struct {
+	left, right expression
+}
+
+func (e *andExpression) match(entry *model.Entry) bool {
+	return e.left.match(entry) && e.right.match(entry)
+}
+
+type orExpression struct {
+	left, right expression
+}
+
+func (e *orExpression) match(entry *model.Entry) bool {
+	return e.left.match(entry) || e.right.match(entry)
+}
+
+type notExpression struct {
+	expr expression
+}
+
+func (e *notExpression) match(entry *model.Entry) bool {
+	return !e.expr.match(entry)
+}
+
+// titleRegexExpression keeps the behavior of the legacy rules written as a single regular expression.
+type titleRegexExpression struct {
+	regex *regexp.Regexp
+}
+
+func (e *titleRegexExpression) match(entry *model.Entry) bool {
+	return e.regex.MatchString(entry.Title)
+}
+
+type textCondition struct {
+	field    string
+	operator string
+	value    string
+	regex    *regexp.Regexp
+}
+
+func (c *textCondition) match(entry *model.Entry) bool {
+	for _, value := range fieldValues(entry, c.field) {
+		if c.matchValue(value) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *textCondition) matchValue(value string) bool {
+	switch c.operator {
+	case "matches":
+		return c.regex.MatchString(value)
+	case "equals":
+		return strings.EqualFold(value, c.value)
+	case "starts_with":
+		return strings.HasPrefix(strings.ToLower(value), c.value)
+	case "ends_with":
+		return strings.HasSuffix(strings.ToLower(value), c.value)
+	default:
+		return strings.Contains(strings.ToLower(value), c.value)
+	}
+}
+
+func fieldValues(entry *model.Entry, field string) []string {
+	switch field {
+	case "title":
+		return []string{entry.Title}
+	case "url":
+		return []string{entry.URL}
+	case "author":
+		return []string{entry.Author}
+	case "content":
+		return []string{entry.Content}
+	case "tag":
+		return entry.Tags
+	case "language":
+		return []string{entry.Language}
+	case "enclosure_type":
+		values := make([]string, 0, len(entry.Enclosures))
+		for _, enclosure := range entry.Enclosures {
+			values = append(values, enclosure.MimeType)
+		}
+		return values
+	}
+	return nil
+}
+
+type dateCondition struct {
+	operator string
+	date     time.Time
+	age      time.Duration
+}
+
+func (c *dateCondition) match(entry *model.Entry) bool {
+	date := c.date
+	if c.age > 0 {
+		date = time.Now().Add(-c.age)
+	}
+
+	if c.operator == "before" {
+		return entry.Date.Before(date)
+	}
+	return entry.Date.After(date)
+}
@@ -0,0 +1,179 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package filter // import "miniflux.app/reader/filter"
+
+import (
+	"testing"
+	"time"
+
+	"miniflux.app/model"
+)
+
+func TestMatchRules(t *testing.T) {
+	entry := &model.Entry{
+		Title:    "Sponsored: The New Phone",
+		URL:      "https://example.org/ads/phone",
+		Author:   "Marketing Team",
+		Content:  "<p>Buy it now</p>",
+		Tags:     []string{"Ads", "Phones"},
+		Date:     time.Now().Add(-48 * time.Hour),
+		Language: "en",
+		Enclosures: model.EnclosureList{
+			&model.Enclosure{MimeType: "video/mp4"},
+		},
+	}
+
+	var scenarios = []struct {
+		rules    string
+		expected bool
+	}{
+		{`title contains sponsored`, true},
+		{`TITLE CONTAINS "the new"`, true},
+		{`title equals "sponsored: the new phone"`, true},
+		{`title equals sponsored`, false},
+		{`url starts_with https://example.org/ads/`, true},
+		{`url ends_with .html`, false},
+		{`author equals "marketing team"`, true},
+		{`content contains "buy it"`, true},
+		{`tag equals ads`, true},
+		{`tag equals news`, false},
+		{`enclosure_type starts_with video/`, true},
+		{`enclosure_type equals audio/mpeg`, false},
+		{`language equals en`, true},
+		{`not language equals EN`, false},
+		{`title matches "phone$"`, true},
+		{`date before 1d`, true},
+		{`date after 1d`, false},
+		{`date after 2000-01-01`, true},
+		{`date before 2000-01-01T00:00:00Z`, false},
+		{`title contains phone and author contains team`, true},
+		{`title contains phone and author contains staff`, false},
+		{`title contains tablet or tag equals phones`, true},
+		{`not title contains phone`, false},
+		{`not (title contains tablet or author contains staff)`, true},
+		{`title contains tablet and (tag equals ads or tag equals phones)`, false},
+		{`title contains tablet or title contains phone and not tag equals ads`, false},
+		{"# Comment\n\ntitle contains tablet\nauthor contains marketing", true},
+	}
+
+	for _, tc := range scenarios {
+		rules, err := Parse(tc.rules)
+		if err != nil {
+			t.Fatalf(`Unable to parse %q: %v`, tc.rules, err)
+		}
+
+		if _, result := rules.Match(entry); result != tc.expected {
+			t.Errorf(`Unexpected result for %q, got %v instead of %v`, tc.rules, result, tc.expected)
+		}
+	}
+}
+
+func TestParseInvalidRules(t *testing.T) {
+	var scenarios = []struct {
+		rules string
+		line  int
+	}{
+		{`[a-z`, 1},
+		{`title contains phone and`, 1},
+		{`title contains`, 1},
+		{`title contains "phone`, 1},
+		{`(title contains phone`, 1},
+		{`title contains phone author contains team`, 1},
+		{`title matches "[a-z"`, 1},
+		{`date before yesterday`, 1},
+		{`date contains 2023`, 1},
+		{"title contains phone\n\nnot title contains", 3},
+	}
+
+	for _, tc := range scenarios {
+		_, err := Parse(tc.rules)
+		if err == nil {
+			t.Errorf(`The rules %q should be invalid`, tc.rules)
+			continue
+		}
+
+		if syntaxErr, ok := err.(*SyntaxError); !ok || syntaxErr.Line != tc.line {
+			t.Errorf(`Unexpected error for %q: %v`, tc.rules, err)
+		}
+	}
+}
+
+func TestParseListRules(t *testing.T) {
+	entry := &model.Entry{Title: "C++ news", Author: "Staff"}
+
+	var scenarios = []struct {
+		rules    string
+		expected bool
+	}{
+		{"#!rules\ntitle contains news", true},
+		{"  #!rules\r\n# Comment\r\n  author equals staff\r\n", true},
+		{"#!rules\ntitle contains sport", false},
+		{`title contains "c++"`, true},
+		{`title contains "c++" and author equals bot`, false},
+		{"", false},
+	}
+
+	for _, tc := range scenarios {
+		rules, err := ParseListRules(tc.rules)
+		if err != nil {
+			t.Fatalf(`Unable to parse %q: %v`, tc.rules, err)
+		}
+
+		if _, result := rules.Match(entry); result != tc.expected {
+			t.Errorf(`Unexpected result for %q, got %v instead of %v`, tc.rules, result, tc.expected)
+		}
+	}
+
+	if _, err := ParseListRules("#!rules\n(?i)news"); err == nil {
+		t.Error(`Regular expressions should be rejected after the syntax header`)
+	}
+
+	if _, err := ParseListRules(`title contains "c++`); err == nil {
+		t.Error(`Rules that are neither a regular expression nor conditions should be rejected`)
+	}
+}
+
+func TestParseLegacyRules(t *testing.T) {
+	entry := &model.Entry{Title: "Content Warning: the Tag of the URL is out of date #ad"}
+
+	var scenarios = []struct {
+		rules    string
+		expected bool
+	}{
+		{`(?i)content warning`, true},
+		{`^Content`, true},
+		{`^content`, false},
+		{`#ad`, true},
+		{`# Comment`, false},
+		{`(?i) tag`, true},
+		{`(?i) content`, false},
+		{`title contains date`, false},
+		{`url contains date`, false},
+		{"Tag\nURL", false},
+		{`Tag`, true},
+		{`Content Warning`, true},
+		{`URL`, true},
+		{`date`, true},
+		{`(?i)^content warning:`, true},
+		{`not date`, false},
+		{`Title`, false},
+		{`Content Warning: spoilers`, false},
+	}
+
+	for _, tc := range scenarios {
+		rules, err := ParseListRules(tc.rules)
+		if err != nil {
+			t.Fatalf(`The legacy rule %q should be valid: %v`, tc.rules, err)
+		}
+
+		if len(rules) != 1 || rules[0].Text != tc.rules {
+			t.Fatalf(`The legacy rule %q should be kept as a single rule`, tc.rules)
+		}
+
+		if _, result := rules.Match(entry); result != tc.expected {
+			t.Errorf(`Unexpected result for %q, got %v instead of %v`, tc.rules, result, tc.expected)
+		}
+	}
+}
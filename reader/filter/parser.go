@@ -0,0 +1,246 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package filter // import "miniflux.app/reader/filter"
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+var (
+	textFields = map[string]bool{
+		"title":          true,
+		"url":            true,
+		"author":         true,
+		"content":        true,
+		"tag":            true,
+		"enclosure_type": true,
+		"language":       true,
+	}
+
+	textOperators = map[string]bool{
+		"contains":    true,
+		"equals":      true,
+		"starts_with": true,
+		"ends_with":   true,
+		"matches":     true,
+	}
+)
+
+type token struct {
+	value  string
+	quoted bool
+}
+
+func (t token) is(keyword string) bool {
+	return !t.quoted && strings.EqualFold(t.value, keyword)
+}
+
+func parseCondition(line string) (expression, error) {
+	tokens, err := tokenize(line)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if !p.done() {
+		return nil, fmt.Errorf("unexpected %q", p.peek().value)
+	}
+	return expr, nil
+}
+
+func tokenize(line string) ([]token, error) {
+	var tokens []token
+	runes := []rune(line)
+
+	for i := 0; i < len(runes); {
+		switch r := runes[i]; {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(' || r == ')':
+			tokens = append(tokens, token{value: string(r)})
+			i++
+		case r == '"':
+			var value strings.Builder
+			i++
+			for ; i < len(runes) && runes[i] != '"'; i++ {
+				if runes[i] == '\\' && i+1 < len(runes) {
+					i++
+				}
+				value.WriteRune(runes[i])
+			}
+			if i == len(runes) {
+				return nil, errors.New("missing closing quote")
+			}
+			tokens = append(tokens, token{value: value.String(), quoted: true})
+			i++
+		default:
+			start := i
+			for i < len(runes) && !unicode.IsSpace(runes[i]) && runes[i] != '(' && runes[i] != ')' && runes[i] != '"' {
+				i++
+			}
+			tokens = append(tokens, token{value: string(runes[start:i])})
+		}
+	}
+
+	return tokens, nil
+}
+
+type parser struct {
+	tokens   []token
+	position int
+}
+
+func (p *parser) done() bool {
+	return p.position >= len(p.tokens)
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.position]
+}
+
+func (p *parser) next() (token, error) {
+	if p.done() {
+		return token{}, errors.New("unexpected end of rule")
+	}
+	t := p.tokens[p.position]
+	p.position++
+	return t, nil
+}
+
+func (p *parser) parseOr() (expression, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for !p.done() && p.peek().is("or") {
+		p.position++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &orExpression{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (expression, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+
+	for !p.done() && p.peek().is("and") {
+		p.position++
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = &andExpression{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseNot() (expression, error) {
+	t, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case t.is("not"):
+		expr, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &notExpression{expr: expr}, nil
+	case t.is("("):
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing, err := p.next(); err != nil || !closing.is(")") {
+			return nil, errors.New("missing closing parenthesis")
+		}
+		return expr, nil
+	default:
+		return p.parseComparison(t)
+	}
+}
+
+func (p *parser) parseComparison(field token) (expression, error) {
+	name := strings.ToLower(field.value)
+	if field.quoted || (!textFields[name] && name != "date") {
+		return nil, fmt.Errorf("unknown field %q", field.value)
+	}
+
+	operator, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	operatorName := strings.ToLower(operator.value)
+
+	value, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+
+	if name == "date" {
+		if operator.quoted || (operatorName != "before" && operatorName != "after") {
+			return nil, fmt.Errorf("unknown date operator %q", operator.value)
+		}
+		return parseDateCondition(operatorName, value.value)
+	}
+
+	if operator.quoted || !textOperators[operatorName] {
+		return nil, fmt.Errorf("unknown operator %q", operator.value)
+	}
+
+	condition := &textCondition{field: name, operator: operatorName, value: strings.ToLower(value.value)}
+	if operatorName == "matches" {
+		condition.regex, err = regexp.Compile("(?i)" + value.value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid regular expression %q", value.value)
+		}
+	}
+	return condition, nil
+}
+
+func parseDateCondition(operator, value string) (expression, error) {
+	condition := &dateCondition{operator: operator}
+
+	if length := len(value); length > 1 {
+		if amount, err := strconv.Atoi(value[:length-1]); err == nil && amount > 0 {
+			switch value[length-1] {
+			case 'h':
+				condition.age = time.Duration(amount) * time.Hour
+				return condition, nil
+			case 'd':
+				condition.age = time.Duration(amount) * 24 * time.Hour
+				return condition, nil
+			}
+		}
+	}
+
+	for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+		if date, err := time.Parse(layout, value); err == nil {
+			condition.date = date
+			return condition, nil
+		}
+	}
+
+	return nil, fmt.Errorf("invalid date %q", value)
+}
@@ -20,6 +20,7 @@ import (
 	"miniflux.app/reader/processor"
 	"miniflux.app/storage"
 	"miniflux.app/timer"
+	"miniflux.app/websub"
 )
 
 var (
@@ -37,7 +38,12 @@ func CreateFeed(store *storage.Storage, userID int64, feedCreationRequest *model
 		return nil, storeErr
 	}
 
-	if !store.CategoryIDExists(userID, feedCreationRequest.CategoryID) {
+	category, storeErr := store.Category(userID, feedCreationRequest.CategoryID)
+	if storeErr != nil {
+		return nil, storeErr
+	}
+
+	if category == nil {
 		return nil, errors.NewLocalizedError(errCategoryNotFound)
 	}
 
@@ -45,6 +51,17 @@ func CreateFeed(store *storage.Storage, userID int64, feedCreationRequest *model
 	request.WithCredentials(feedCreationRequest.Username, feedCreationRequest.Password)
 	request.WithUserAgent(feedCreationRequest.UserAgent)
 	request.WithCookie(feedCreationRequest.Cookie)
+	request.WithHeaders(feedCreationRequest.Headers)
+	request.WithTLSSettings(client.TLSSettings{
+		ClientCertificate: feedCreationRequest.TLSClientCertificate,
+		ClientKey:         feedCreationRequest.TLSClientKey,
+		CABundle:          feedCreationRequest.TLSCABundle,
+	})
+	if feedCreationRequest.ProxyName != "" {
+		request.WithNamedProxy(feedCreationRequest.ProxyName)
+	} else {
+		request.WithNamedProxy(category.ProxyName)
+	}
 	request.AllowSelfSignedCertificates = feedCreationRequest.AllowSelfSignedCertificates
 
 	if feedCreationRequest.FetchViaProxy {
@@ -56,18 +73,39 @@ func CreateFeed(store *storage.Storage, userID int64, feedCreationRequest *model
 		return nil, requestErr
 	}
 
-	if store.FeedURLExists(userID, response.EffectiveURL) {
-		return nil, errors.NewLocalizedError(errDuplicate, response.EffectiveURL)
+	// The URL is only replaced when the feed has been moved permanently.
+	feedURL := feedCreationRequest.FeedURL
+	if permanentURL := response.PermanentURL(); permanentURL != "" {
+		feedURL = permanentURL
+	}
+
+	if store.FeedURLExists(userID, feedURL) {
+		return nil, errors.NewLocalizedError(errDuplicate, feedURL)
 	}
 
-	subscription, parseErr := parser.ParseFeed(response.EffectiveURL, response.BodyAsString())
+	entryIdentity := feedCreationRequest.EntryIdentity
+	if entryIdentity == "" {
+		entryIdentity = model.EntryIdentityGUID
+	}
+
+	subscription, parseErr := parser.ParseFeedWithEntryIdentity(response.EffectiveURL, response.BodyAsString(), entryIdentity)
 	if parseErr != nil {
 		return nil, parseErr
 	}
 
+	subscription.FeedURL = feedURL
 	subscription.UserID = userID
 	subscription.UserAgent = feedCreationRequest.UserAgent
 	subscription.Cookie = feedCreationRequest.Cookie
+	subscription.Headers = feedCreationRequest.Headers
+	subscription.TLSClientCertificate = feedCreationRequest.TLSClientCertificate
+	subscription.TLSClientKey = feedCreationRequest.TLSClientKey
+	subscription.TLSCABundle = feedCreationRequest.TLSCABundle
+	subscription.ProxyName = feedCreationRequest.ProxyName
+	subscription.EntryIdentity = entryIdentity
+	subscription.RetentionReadDays = feedCreationRequest.RetentionReadDays
+	subscription.RetentionUnreadDays = feedCreationRequest.RetentionUnreadDays
+	subscription.RetentionMaxEntries = feedCreationRequest.RetentionMaxEntries
 	subscription.Username = feedCreationRequest.Username
 	subscription.Password = feedCreationRequest.Password
 	subscription.Crawler = feedCreationRequest.Crawler
@@ -80,33 +118,51 @@ func CreateFeed(store *storage.Storage, userID int64, feedCreationRequest *model
 	subscription.BlocklistRules = feedCreationRequest.BlocklistRules
 	subscription.KeeplistRules = feedCreationRequest.KeeplistRules
 	subscription.UrlRewriteRules = feedCreationRequest.UrlRewriteRules
-	subscription.WithCategoryID(feedCreationRequest.CategoryID)
+	subscription.Category = category
 	subscription.WithClientResponse(response)
 	subscription.CheckedNow()
 
-	processor.ProcessFeedEntries(store, subscription, user)
+	newEntries, processErr := processor.ProcessFeedEntries(store, subscription, user)
+	if processErr != nil {
+		return nil, processErr
+	}
 
 	if storeErr := store.CreateFeed(subscription); storeErr != nil {
 		return nil, storeErr
 	}
 
+	newEntries.SendToIntegrations(store)
+
 	logger.Debug("[CreateFeed] Feed saved with ID: %d", subscription.ID)
 
+	websub.Subscribe(store, userID, subscription.ID, subscription.HubURL, webSubTopic(subscription, subscription))
+
 	checkFeedIcon(
 		store,
 		subscription.ID,
 		subscription.SiteURL,
 		subscription.IconURL,
 		feedCreationRequest.UserAgent,
+		feedCreationRequest.Headers,
+		subscription.TLSSettings(),
+		subscription.EffectiveProxyName(),
 		feedCreationRequest.FetchViaProxy,
 		feedCreationRequest.AllowSelfSignedCertificates,
 	)
 	return subscription, nil
 }
 
-// RefreshFeed refreshes a feed.
-func RefreshFeed(store *storage.Storage, userID, feedID int64) error {
+// RefreshFeed refreshes a feed and records the attempt in the refresh history of the feed.
+func RefreshFeed(store *storage.Storage, userID, feedID int64) (refreshErr error) {
 	defer timer.ExecutionTime(time.Now(), fmt.Sprintf("[RefreshFeed] feedID=%d", feedID))
+
+	// The claim taken by the scheduler of this node is released whatever the outcome of the refresh.
+	defer func() {
+		if err := store.ReleaseFeedClaim(feedID, config.Opts.NodeName()); err != nil {
+			logger.Error("[RefreshFeed] %v", err)
+		}
+	}()
+
 	user, storeErr := store.UserByID(userID)
 	if storeErr != nil {
 		return storeErr
@@ -132,13 +188,26 @@ func RefreshFeed(store *storage.Storage, userID, feedID int64) error {
 		}
 	}
 
+	refresh := model.NewFeedRefresh(userID, feedID)
+	defer func() {
+		// The error paths below always record the error message on the feed before returning.
+		errorMessage := ""
+		if refreshErr != nil {
+			errorMessage = originalFeed.ParsingErrorMsg
+		}
+
+		saveFeedRefresh(store, refresh, errorMessage)
+	}()
+
 	originalFeed.CheckedNow()
-	originalFeed.ScheduleNextCheck(weeklyEntryCount)
 
 	request := client.NewClientWithConfig(originalFeed.FeedURL, config.Opts)
 	request.WithCredentials(originalFeed.Username, originalFeed.Password)
 	request.WithUserAgent(originalFeed.UserAgent)
 	request.WithCookie(originalFeed.Cookie)
+	request.WithHeaders(originalFeed.Headers)
+	request.WithTLSSettings(originalFeed.TLSSettings())
+	request.WithNamedProxy(originalFeed.EffectiveProxyName())
 	request.AllowSelfSignedCertificates = originalFeed.AllowSelfSignedCertificates
 
 	if !originalFeed.IgnoreHTTPCache {
@@ -150,39 +219,36 @@ func RefreshFeed(store *storage.Storage, userID, feedID int64) error {
 	}
 
 	response, requestErr := browser.Exec(request)
-	if requestErr != nil {
-		originalFeed.WithError(requestErr.Localize(printer))
-		store.UpdateFeedError(originalFeed)
-		return requestErr
+	if response != nil {
+		refresh.WithClientResponse(response)
 	}
 
-	if store.AnotherFeedURLExists(userID, originalFeed.ID, response.EffectiveURL) {
-		storeErr := errors.NewLocalizedError(errDuplicate, response.EffectiveURL)
-		originalFeed.WithError(storeErr.Error())
-		store.UpdateFeedError(originalFeed)
-		return storeErr
+	if requestErr != nil {
+		storeFeedError(store, originalFeed, requestErr.Localize(printer), weeklyEntryCount, response)
+		return requestErr
 	}
 
 	if originalFeed.IgnoreHTTPCache || response.IsModified(originalFeed.EtagHeader, originalFeed.LastModifiedHeader) {
 		logger.Debug("[RefreshFeed] Feed #%d has been modified", feedID)
 
-		updatedFeed, parseErr := parser.ParseFeed(response.EffectiveURL, response.BodyAsString())
+		// The body can be read only once, the document is parsed again to rekey the entries.
+		body := response.BodyAsString()
+		updatedFeed, parseErr := parser.ParseFeedWithEntryIdentity(response.EffectiveURL, body, originalFeed.EntryIdentity)
 		if parseErr != nil {
-			originalFeed.WithError(parseErr.Localize(printer))
-			store.UpdateFeedError(originalFeed)
+			storeFeedError(store, originalFeed, parseErr.Localize(printer), weeklyEntryCount, response)
 			return parseErr
 		}
 
-		originalFeed.Entries = updatedFeed.Entries
-		processor.ProcessFeedEntries(store, originalFeed, user)
+		// The refresh hints declared in the feed are only available when the document is returned.
+		originalFeed.WithRefreshHints(updatedFeed)
 
-		// We don't update existing entries when the crawler is enabled (we crawl only inexisting entries).
-		if storeErr := store.RefreshFeedEntries(originalFeed.UserID, originalFeed.ID, originalFeed.Entries, !originalFeed.Crawler); storeErr != nil {
-			originalFeed.WithError(storeErr.Error())
-			store.UpdateFeedError(originalFeed)
+		if storeErr := storeFeedEntries(store, user, originalFeed, response.EffectiveURL, body, updatedFeed, refresh, false); storeErr != nil {
+			storeFeedError(store, originalFeed, storeErr.Error(), weeklyEntryCount, response)
 			return storeErr
 		}
 
+		websub.Subscribe(store, userID, originalFeed.ID, updatedFeed.HubURL, webSubTopic(originalFeed, updatedFeed))
+
 		// We update caching headers only if the feed has been modified,
 		// because some websites don't return the same headers when replying with a 304.
 		originalFeed.WithClientResponse(response)
@@ -192,27 +258,263 @@ func RefreshFeed(store *storage.Storage, userID, feedID int64) error {
 			originalFeed.SiteURL,
 			updatedFeed.IconURL,
 			originalFeed.UserAgent,
+			originalFeed.Headers,
+			originalFeed.TLSSettings(),
+			originalFeed.EffectiveProxyName(),
 			originalFeed.FetchViaProxy,
 			originalFeed.AllowSelfSignedCertificates,
 		)
 	} else {
 		logger.Debug("[RefreshFeed] Feed #%d not modified", feedID)
+		refresh.NotModified = true
 	}
 
+	followPermanentRedirect(store, originalFeed, response)
+
+	// A successful refresh resets the error backoff.
 	originalFeed.ResetErrorCounter()
+	originalFeed.ScheduleNextCheck(weeklyEntryCount, response)
 
 	if storeErr := store.UpdateFeed(originalFeed); storeErr != nil {
-		originalFeed.WithError(storeErr.Error())
-		store.UpdateFeedError(originalFeed)
+		storeFeedError(store, originalFeed, storeErr.Error(), weeklyEntryCount, response)
 		return storeErr
 	}
 
 	return nil
 }
 
-func checkFeedIcon(store *storage.Storage, feedID int64, websiteURL, iconURL, userAgent string, fetchViaProxy, allowSelfSignedCertificates bool) {
+// RefreshFeedWithContent stores the entries of a feed document pushed by a WebSub hub and records it in the refresh history of the feed.
+//
+// The hubs can send only the new entries, the entries missing from the content are kept.
+func RefreshFeedWithContent(store *storage.Storage, userID, feedID int64, content string) (refreshErr error) {
+	defer timer.ExecutionTime(time.Now(), fmt.Sprintf("[RefreshFeedWithContent] feedID=%d", feedID))
+	user, storeErr := store.UserByID(userID)
+	if storeErr != nil {
+		return storeErr
+	}
+
+	feed, storeErr := store.FeedByID(userID, feedID)
+	if storeErr != nil {
+		return storeErr
+	}
+
+	if feed == nil {
+		return errors.NewLocalizedError(errNotFound, feedID)
+	}
+
+	if feed.Disabled {
+		return nil
+	}
+
+	printer := locale.NewPrinter(user.Language)
+
+	refresh := model.NewFeedRefresh(userID, feedID)
+	defer func() {
+		errorMessage := ""
+		if localizedErr, ok := refreshErr.(*errors.LocalizedError); ok {
+			errorMessage = localizedErr.Localize(printer)
+		} else if refreshErr != nil {
+			errorMessage = refreshErr.Error()
+		}
+
+		saveFeedRefresh(store, refresh, errorMessage)
+	}()
+
+	pushedFeed, parseErr := parser.ParseFeedWithEntryIdentity(feed.FeedURL, content, feed.EntryIdentity)
+	if parseErr != nil {
+		return parseErr
+	}
+
+	logger.Debug("[RefreshFeedWithContent] Received %d entries for feed #%d", len(pushedFeed.Entries), feed.ID)
+
+	if storeErr := storeFeedEntries(store, user, feed, feed.FeedURL, content, pushedFeed, refresh, true); storeErr != nil {
+		return storeErr
+	}
+
+	logger.Debug("[RefreshFeedWithContent] Feed #%d: %d new entries and %d updated entries", feed.ID, refresh.NewEntries, refresh.UpdatedEntries)
+	return nil
+}
+
+// PreviewFeed fetches, parses and processes a feed with the given rules, without saving anything.
+func PreviewFeed(store *storage.Storage, userID, feedID int64, feedPreviewRequest *model.FeedPreviewRequest) (*model.FeedPreview, error) {
+	defer timer.ExecutionTime(time.Now(), fmt.Sprintf("[PreviewFeed] feedID=%d", feedID))
+
+	user, storeErr := store.UserByID(userID)
+	if storeErr != nil {
+		return nil, storeErr
+	}
+
+	feed, storeErr := store.FeedByID(userID, feedID)
+	if storeErr != nil {
+		return nil, storeErr
+	}
+
+	if feed == nil {
+		return nil, errors.NewLocalizedError(errNotFound, feedID)
+	}
+
+	feedPreviewRequest.Patch(feed)
+
+	// The cache headers are not sent, the preview must always show the entries.
+	request := client.NewClientWithConfig(feed.FeedURL, config.Opts)
+	request.WithCredentials(feed.Username, feed.Password)
+	request.WithUserAgent(feed.UserAgent)
+	request.WithCookie(feed.Cookie)
+	request.WithHeaders(feed.Headers)
+	request.WithTLSSettings(feed.TLSSettings())
+	request.WithNamedProxy(feed.EffectiveProxyName())
+	request.AllowSelfSignedCertificates = feed.AllowSelfSignedCertificates
+
+	if feed.FetchViaProxy {
+		request.WithProxy()
+	}
+
+	response, requestErr := browser.Exec(request)
+	if requestErr != nil {
+		return nil, requestErr
+	}
+
+	document, parseErr := parser.ParseFeedWithEntryIdentity(response.EffectiveURL, response.BodyAsString(), feed.EntryIdentity)
+	if parseErr != nil {
+		return nil, parseErr
+	}
+
+	feed.Entries = document.Entries
+
+	previews, processErr := processor.PreviewFeedEntries(store, feed, user)
+	if processErr != nil {
+		return nil, processErr
+	}
+
+	return &model.FeedPreview{
+		FeedID:       feed.ID,
+		EffectiveURL: response.EffectiveURL,
+		Entries:      previews,
+	}, nil
+}
+
+// storeFeedError records the error on the feed and delays its next check with the error backoff.
+func storeFeedError(store *storage.Storage, feed *model.Feed, message string, weeklyEntryCount int, response *client.Response) {
+	feed.WithError(message)
+	feed.ScheduleNextCheck(weeklyEntryCount, response)
+	if err := store.UpdateFeedError(feed); err != nil {
+		logger.Error("[RefreshFeed] %v", err)
+	}
+}
+
+// saveFeedRefresh records a refresh attempt in the history of the feed.
+func saveFeedRefresh(store *storage.Storage, refresh *model.FeedRefresh, errorMessage string) {
+	refresh.Finish(errorMessage)
+	if err := store.CreateFeedRefresh(refresh); err != nil {
+		logger.Error("[RefreshFeed] %v", err)
+	}
+}
+
+// storeFeedEntries re-keys the existing entries after a change of the entry identity strategy,
+// then processes and stores the entries of the document.
+//
+// A partial document, pushed by a WebSub hub, keeps the entries missing from the document and
+// re-keys only the entries it contains: the pending change is kept until the next complete refresh.
+func storeFeedEntries(store *storage.Storage, user *model.User, feed *model.Feed, baseURL, body string, document *model.Feed, refresh *model.FeedRefresh, partial bool) error {
+	if feed.PreviousEntryIdentity != "" {
+		if err := rekeyFeedEntries(store, feed, baseURL, body, document); err != nil {
+			return err
+		}
+
+		if !partial {
+			feed.PreviousEntryIdentity = ""
+		}
+	}
+
+	feed.Entries = document.Entries
+	newEntries, err := processor.ProcessFeedEntries(store, feed, user)
+	if err != nil {
+		return err
+	}
+
+	// We don't update existing entries when the crawler is enabled (we crawl only inexisting entries).
+	if partial {
+		refresh.NewEntries, refresh.UpdatedEntries, err = store.AddFeedEntries(feed.UserID, feed.ID, feed.Entries, !feed.Crawler)
+	} else {
+		refresh.NewEntries, refresh.UpdatedEntries, err = store.RefreshFeedEntries(feed.UserID, feed.ID, feed.Entries, !feed.Crawler)
+	}
+	if err != nil {
+		return err
+	}
+
+	newEntries.SendToIntegrations(store)
+	return nil
+}
+
+// rekeyFeedEntries replaces the hash of the existing entries after a change of the entry identity strategy.
+//
+// The document is parsed a second time with the previous strategy, both parsings return the entries in the same order.
+// Entries missing from the document keep their hash, they are not expected to be published again.
+//
+// An error is returned when the entries cannot be matched, the entries must not be stored with their
+// new hash before the existing ones are re-keyed: the pending change is retried on the next refresh.
+func rekeyFeedEntries(store *storage.Storage, feed *model.Feed, baseURL, body string, document *model.Feed) error {
+	previousDocument, parseErr := parser.ParseFeedWithEntryIdentity(baseURL, body, feed.PreviousEntryIdentity)
+	if parseErr != nil {
+		return fmt.Errorf("unable to rekey the entries with the previous identity %q: %v", feed.PreviousEntryIdentity, parseErr)
+	}
+
+	if len(previousDocument.Entries) != len(document.Entries) {
+		return fmt.Errorf("unable to rekey the entries: %d entries with the identity %q but %d with the previous identity %q",
+			len(document.Entries), feed.EntryIdentity, len(previousDocument.Entries), feed.PreviousEntryIdentity)
+	}
+
+	hashes := make(map[string]string, len(document.Entries))
+	for i, entry := range document.Entries {
+		hashes[previousDocument.Entries[i].Hash] = entry.Hash
+	}
+
+	rekeyedEntries, err := store.RekeyFeedEntries(feed.UserID, feed.ID, hashes)
+	if err != nil {
+		return err
+	}
+
+	logger.Debug("[RefreshFeed] Feed #%d: %d entries rekeyed from %q to %q", feed.ID, rekeyedEntries, feed.PreviousEntryIdentity, feed.EntryIdentity)
+	return nil
+}
+
+// followPermanentRedirect moves the feed to its new URL when the server answers with a permanent redirect.
+//
+// Temporary redirects are ignored. When the new URL is already subscribed,
+// the feed is left untouched and the user is offered to merge both feeds.
+func followPermanentRedirect(store *storage.Storage, feed *model.Feed, response *client.Response) {
+	permanentURL := response.PermanentURL()
+	if permanentURL == "" || permanentURL == feed.FeedURL {
+		feed.MovedToURL = ""
+		return
+	}
+
+	if store.AnotherFeedURLExists(feed.UserID, feed.ID, permanentURL) {
+		logger.Info("[RefreshFeed] Feed #%d moved permanently to %q which is already subscribed", feed.ID, permanentURL)
+		feed.MovedToURL = permanentURL
+		return
+	}
+
+	logger.Info("[RefreshFeed] Feed #%d moved permanently from %q to %q", feed.ID, feed.FeedURL, permanentURL)
+	if err := store.CreateFeedURLChange(model.NewFeedURLChange(feed, permanentURL, model.FeedURLChangeReasonPermanentRedirect)); err != nil {
+		logger.Error("[RefreshFeed] %v", err)
+	}
+
+	feed.FeedURL = permanentURL
+	feed.MovedToURL = ""
+}
+
+// webSubTopic returns the URL to subscribe to: the self link declared in the document, or the feed URL.
+func webSubTopic(feed, document *model.Feed) string {
+	if document.SelfURL != "" {
+		return document.SelfURL
+	}
+	return feed.FeedURL
+}
+
+func checkFeedIcon(store *storage.Storage, feedID int64, websiteURL, iconURL, userAgent string, headers map[string]string, tlsSettings client.TLSSettings, proxyName string, fetchViaProxy, allowSelfSignedCertificates bool) {
 	if !store.HasIcon(feedID) {
-		icon, err := icon.FindIcon(websiteURL, iconURL, userAgent, fetchViaProxy, allowSelfSignedCertificates)
+		icon, err := icon.FindIcon(websiteURL, iconURL, userAgent, headers, tlsSettings, proxyName, fetchViaProxy, allowSelfSignedCertificates)
 		if err != nil {
 			logger.Debug(`[CheckFeedIcon] %v (feedID=%d websiteURL=%s)`, err, feedID, websiteURL)
 		} else if icon == nil {
@@ -23,13 +23,16 @@ import (
 )
 
 // FindIcon try to find the website's icon.
-func FindIcon(websiteURL, iconURL, userAgent string, fetchViaProxy, allowSelfSignedCertificates bool) (*model.Icon, error) {
+func FindIcon(websiteURL, iconURL, userAgent string, headers map[string]string, tlsSettings client.TLSSettings, proxyName string, fetchViaProxy, allowSelfSignedCertificates bool) (*model.Icon, error) {
 	if iconURL == "" {
 		rootURL := url.RootURL(websiteURL)
 		logger.Debug("[FindIcon] Trying to find an icon: rootURL=%q websiteURL=%q userAgent=%q", rootURL, websiteURL, userAgent)
 
 		clt := client.NewClientWithConfig(rootURL, config.Opts)
 		clt.WithUserAgent(userAgent)
+		clt.WithHeaders(headers)
+		clt.WithTLSSettings(tlsSettings)
+		clt.WithNamedProxy(proxyName)
 		clt.AllowSelfSignedCertificates = allowSelfSignedCertificates
 
 		if fetchViaProxy {
@@ -56,7 +59,7 @@ func FindIcon(websiteURL, iconURL, userAgent string, fetchViaProxy, allowSelfSig
 	}
 
 	logger.Debug("[FindIcon] Fetching icon => %s", iconURL)
-	icon, err := downloadIcon(iconURL, userAgent, fetchViaProxy, allowSelfSignedCertificates)
+	icon, err := downloadIcon(iconURL, userAgent, headers, tlsSettings, proxyName, fetchViaProxy, allowSelfSignedCertificates)
 	if err != nil {
 		return nil, err
 	}
@@ -99,9 +102,12 @@ func parseDocument(websiteURL string, data io.Reader) (string, error) {
 	return iconURL, nil
 }
 
-func downloadIcon(iconURL, userAgent string, fetchViaProxy, allowSelfSignedCertificates bool) (*model.Icon, error) {
+func downloadIcon(iconURL, userAgent string, headers map[string]string, tlsSettings client.TLSSettings, proxyName string, fetchViaProxy, allowSelfSignedCertificates bool) (*model.Icon, error) {
 	clt := client.NewClientWithConfig(iconURL, config.Opts)
 	clt.WithUserAgent(userAgent)
+	clt.WithHeaders(headers)
+	clt.WithTLSSettings(tlsSettings)
+	clt.WithNamedProxy(proxyName)
 	clt.AllowSelfSignedCertificates = allowSelfSignedCertificates
 	if fetchViaProxy {
 		clt.WithProxy()
@@ -23,6 +23,7 @@ type jsonFeed struct {
 	IconURL    string       `json:"icon"`
 	FaviconURL string       `json:"favicon"`
 	FeedURL    string       `json:"feed_url"`
+	Language   string       `json:"language"`
 	Authors    []jsonAuthor `json:"authors"`
 	Author     jsonAuthor   `json:"author"`
 	Items      []jsonItem   `json:"items"`
@@ -63,7 +64,7 @@ func (j *jsonFeed) GetAuthor() string {
 	return getAuthor(j.Author)
 }
 
-func (j *jsonFeed) Transform(baseURL string) *model.Feed {
+func (j *jsonFeed) Transform(baseURL, entryIdentity string) *model.Feed {
 	var err error
 
 	feed := new(model.Feed)
@@ -89,6 +90,7 @@ func (j *jsonFeed) Transform(baseURL string) *model.Feed {
 		feed.Title = feed.SiteURL
 	}
 
+	language := model.NormalizeLanguage(j.Language)
 	for _, item := range j.Items {
 		entry := item.Transform()
 		entryURL, err := url.AbsoluteURL(feed.SiteURL, entry.URL)
@@ -100,6 +102,14 @@ func (j *jsonFeed) Transform(baseURL string) *model.Feed {
 			entry.Author = j.GetAuthor()
 		}
 
+		if entry.Language == "" {
+			entry.Language = language
+		}
+
+		if hash := model.EntryIdentityHash(entryIdentity, entry); hash != "" {
+			entry.Hash = hash
+		}
+
 		feed.Entries = append(feed.Entries, entry)
 	}
 
@@ -14,11 +14,16 @@ import (
 
 // Parse returns a normalized feed struct from a JSON feed.
 func Parse(baseURL string, data io.Reader) (*model.Feed, *errors.LocalizedError) {
+	return ParseWithEntryIdentity(baseURL, data, model.EntryIdentityGUID)
+}
+
+// ParseWithEntryIdentity returns a normalized feed struct from a JSON feed, the entries are identified with the given strategy.
+func ParseWithEntryIdentity(baseURL string, data io.Reader, entryIdentity string) (*model.Feed, *errors.LocalizedError) {
 	feed := new(jsonFeed)
 	decoder := json.NewDecoder(data)
 	if err := decoder.Decode(&feed); err != nil {
 		return nil, errors.NewLocalizedError("Unable to parse JSON Feed: %q", err)
 	}
 
-	return feed.Transform(baseURL), nil
+	return feed.Transform(baseURL, entryIdentity), nil
 }
@@ -9,6 +9,9 @@ import (
 	"strings"
 	"testing"
 	"time"
+
+	"miniflux.app/crypto"
+	"miniflux.app/model"
 )
 
 func TestParseJsonFeed(t *testing.T) {
@@ -653,3 +656,53 @@ func TestParseFavicon(t *testing.T) {
 		t.Errorf("Incorrect icon URL, got: %s", feed.IconURL)
 	}
 }
+
+func TestParseItemWithTitleDateIdentity(t *testing.T) {
+	data := `{
+		"version": "https://jsonfeed.org/version/1",
+		"title": "My Example Feed",
+		"home_page_url": "https://example.org/",
+		"items": [
+			{
+				"id": "1",
+				"title": "Item Title",
+				"date_published": "2023-03-04T23:30:00+02:00",
+				"content_text": "Hello, world!"
+			}
+		]
+	}`
+
+	feed, err := ParseWithEntryIdentity("https://example.org/feed.json", bytes.NewBufferString(data), model.EntryIdentityTitleDate)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.Entries[0].Hash != crypto.Hash("Item Title\n2023-03-04") {
+		t.Errorf("Incorrect entry hash, got: %s", feed.Entries[0].Hash)
+	}
+}
+
+func TestParseItemLanguage(t *testing.T) {
+	data := `{
+		"version": "https://jsonfeed.org/version/1.1",
+		"title": "My Example Feed",
+		"home_page_url": "https://example.org/",
+		"language": "es-ES",
+		"items": [
+			{
+				"id": "1",
+				"url": "https://example.org/item",
+				"content_text": "Hola"
+			}
+		]
+	}`
+
+	feed, err := Parse("https://example.org/feed.json", bytes.NewBufferString(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.Entries[0].Language != "es" {
+		t.Errorf("Incorrect entry language, got: %q", feed.Entries[0].Language)
+	}
+}
@@ -33,6 +33,7 @@ func (h *Handler) Export(userID int64) (string, error) {
 			FeedURL:      feed.FeedURL,
 			SiteURL:      feed.SiteURL,
 			CategoryName: feed.Category.Title,
+			Headers:      feed.Headers,
 		})
 	}
 
@@ -79,6 +80,7 @@ func (h *Handler) Import(userID int64, data io.Reader) error {
 				FeedURL:  subscription.FeedURL,
 				SiteURL:  subscription.SiteURL,
 				Category: category,
+				Headers:  subscription.Headers,
 			}
 
 			h.store.CreateFeed(feed)
@@ -5,6 +5,7 @@
 package opml // import "miniflux.app/reader/opml"
 
 import (
+	"encoding/json"
 	"encoding/xml"
 	"strings"
 )
@@ -32,6 +33,7 @@ type opmlOutline struct {
 	Text     string                `xml:"text,attr"`
 	FeedURL  string                `xml:"xmlUrl,attr,omitempty"`
 	SiteURL  string                `xml:"htmlUrl,attr,omitempty"`
+	Headers  string                `xml:"headers,attr,omitempty"` // Custom HTTP headers encoded in JSON, specific to Miniflux.
 	Outlines opmlOutlineCollection `xml:"outline,omitempty"`
 }
 
@@ -67,6 +69,33 @@ func (o *opmlOutline) GetSiteURL() string {
 	return o.FeedURL
 }
 
+// GetHeaders returns the custom HTTP headers of the feed, the invalid values are ignored.
+func (o *opmlOutline) GetHeaders() map[string]string {
+	if o.Headers == "" {
+		return nil
+	}
+
+	var headers map[string]string
+	if err := json.Unmarshal([]byte(o.Headers), &headers); err != nil {
+		return nil
+	}
+
+	return headers
+}
+
+func encodeHeaders(headers map[string]string) string {
+	if len(headers) == 0 {
+		return ""
+	}
+
+	data, err := json.Marshal(headers)
+	if err != nil {
+		return ""
+	}
+
+	return string(data)
+}
+
 type opmlOutlineCollection []opmlOutline
 
 func (o opmlOutlineCollection) HasChildren() bool {
@@ -36,6 +36,7 @@ func getSubscriptionsFromOutlines(outlines opmlOutlineCollection, category strin
 				FeedURL:      outline.FeedURL,
 				SiteURL:      outline.GetSiteURL(),
 				CategoryName: category,
+				Headers:      outline.GetHeaders(),
 			})
 		} else if outline.Outlines.HasChildren() {
 			subscriptions = append(subscriptions, getSubscriptionsFromOutlines(outline.Outlines, outline.Text)...)
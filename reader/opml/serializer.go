@@ -52,6 +52,7 @@ func convertSubscriptionsToOPML(subscriptions SubcriptionList) *opmlDocument {
 				Text:    subscription.Title,
 				FeedURL: subscription.FeedURL,
 				SiteURL: subscription.SiteURL,
+				Headers: encodeHeaders(subscription.Headers),
 			})
 		}
 
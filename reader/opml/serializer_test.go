@@ -62,3 +62,26 @@ func TestNormalizedCategoriesOrder(t *testing.T) {
 		}
 	}
 }
+
+func TestSerializeWithHeaders(t *testing.T) {
+	subscription := &Subcription{
+		Title:        "Feed 1",
+		FeedURL:      "http://example.org/feed/1",
+		SiteURL:      "http://example.org/1",
+		CategoryName: "Category 1",
+		Headers:      map[string]string{"Authorization": "Bearer token", "Accept": "application/atom+xml"},
+	}
+
+	feeds, err := Parse(bytes.NewBufferString(Serialize(SubcriptionList{subscription})))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(feeds) != 1 {
+		t.Fatalf("Wrong number of subscriptions: %d instead of %d", len(feeds), 1)
+	}
+
+	if !feeds[0].Equals(subscription) {
+		t.Errorf("The headers are not round-tripped, got %v instead of %v", feeds[0].Headers, subscription.Headers)
+	}
+}
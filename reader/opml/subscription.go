@@ -10,12 +10,28 @@ type Subcription struct {
 	SiteURL      string
 	FeedURL      string
 	CategoryName string
+	Headers      map[string]string
 }
 
 // Equals compare two subscriptions.
 func (s Subcription) Equals(subscription *Subcription) bool {
 	return s.Title == subscription.Title && s.SiteURL == subscription.SiteURL &&
-		s.FeedURL == subscription.FeedURL && s.CategoryName == subscription.CategoryName
+		s.FeedURL == subscription.FeedURL && s.CategoryName == subscription.CategoryName &&
+		equalHeaders(s.Headers, subscription.Headers)
+}
+
+func equalHeaders(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for name, value := range a {
+		if otherValue, found := b[name]; !found || otherValue != value {
+			return false
+		}
+	}
+
+	return true
 }
 
 // SubcriptionList is a list of subscriptions.
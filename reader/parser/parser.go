@@ -17,15 +17,21 @@ import (
 
 // ParseFeed analyzes the input data and returns a normalized feed object.
 func ParseFeed(baseURL, data string) (*model.Feed, *errors.LocalizedError) {
+	return ParseFeedWithEntryIdentity(baseURL, data, model.EntryIdentityGUID)
+}
+
+// ParseFeedWithEntryIdentity analyzes the input data and returns a normalized feed object,
+// the entries are identified with the given strategy.
+func ParseFeedWithEntryIdentity(baseURL, data, entryIdentity string) (*model.Feed, *errors.LocalizedError) {
 	switch DetectFeedFormat(data) {
 	case FormatAtom:
-		return atom.Parse(baseURL, strings.NewReader(data))
+		return atom.ParseWithEntryIdentity(baseURL, strings.NewReader(data), entryIdentity)
 	case FormatRSS:
-		return rss.Parse(baseURL, strings.NewReader(data))
+		return rss.ParseWithEntryIdentity(baseURL, strings.NewReader(data), entryIdentity)
 	case FormatJSON:
-		return json.Parse(baseURL, strings.NewReader(data))
+		return json.ParseWithEntryIdentity(baseURL, strings.NewReader(data), entryIdentity)
 	case FormatRDF:
-		return rdf.Parse(baseURL, strings.NewReader(data))
+		return rdf.ParseWithEntryIdentity(baseURL, strings.NewReader(data), entryIdentity)
 	default:
 		return nil, errors.NewLocalizedError("Unsupported feed format")
 	}
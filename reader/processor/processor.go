@@ -12,6 +12,7 @@ import (
 	"strconv"
 	"strings"
 	"time"
+	"unicode"
 	"unicode/utf8"
 
 	"miniflux.app/integration"
@@ -22,6 +23,7 @@ import (
 	"miniflux.app/metric"
 	"miniflux.app/model"
 	"miniflux.app/reader/browser"
+	"miniflux.app/reader/filter"
 	"miniflux.app/reader/rewrite"
 	"miniflux.app/reader/sanitizer"
 	"miniflux.app/reader/scraper"
@@ -38,11 +40,84 @@ var (
 )
 
 // ProcessFeedEntries downloads original web page for entries and apply filters.
-func ProcessFeedEntries(store *storage.Storage, feed *model.Feed, user *model.User) {
+//
+// The new entries are sent to the integrations with SendToIntegrations once they are stored.
+// An error is returned when the blocklist or keeplist rules are invalid, the entries must not be stored unfiltered.
+func ProcessFeedEntries(store *storage.Storage, feed *model.Feed, user *model.User) (*NewEntries, error) {
+	_, newEntries, err := processFeedEntries(store, feed, user, false)
+	return newEntries, err
+}
+
+// PreviewFeedEntries processes the entries like ProcessFeedEntries but without
+// side effects and returns what happened to each entry, including the blocked ones.
+//
+// The integrations are not triggered and all entries are crawled, not only the new ones.
+func PreviewFeedEntries(store *storage.Storage, feed *model.Feed, user *model.User) (model.EntryPreviews, error) {
+	previews, _, err := processFeedEntries(store, feed, user, true)
+	return previews, err
+}
+
+// NewEntries holds the new entries of a refresh and the integrations chosen for them by the automation rules.
+type NewEntries struct {
+	userID           int64
+	entries          model.Entries
+	integrationNames [][]string
+}
+
+// SendToIntegrations pushes the new entries to the integrations of the user.
+//
+// It must be called after the entries are stored, the integrations need their ID.
+func (n *NewEntries) SendToIntegrations(store *storage.Storage) {
+	if n == nil || len(n.entries) == 0 {
+		return
+	}
+
+	intg, err := store.Integration(n.userID)
+	if err != nil {
+		logger.Error("[Processor] Get integrations for user %d failed: %v; no integrations will run this time.", n.userID, err)
+		return
+	}
+
+	if intg == nil {
+		return
+	}
+
+	go func() {
+		var storedEntries model.Entries
+		for i, entry := range n.entries {
+			// The entries without ID have not been stored, they already existed or were purged.
+			if entry.ID == 0 {
+				continue
+			}
+
+			integration.PushEntry(entry, intg)
+			for _, name := range n.integrationNames[i] {
+				integration.SendEntryTo(name, entry, intg)
+			}
+			storedEntries = append(storedEntries, entry)
+		}
+
+		if len(storedEntries) > 0 {
+			integration.PushEntries(storedEntries, intg)
+		}
+	}()
+}
+
+func processFeedEntries(store *storage.Storage, feed *model.Feed, user *model.User, preview bool) (previews model.EntryPreviews, newEntries *NewEntries, err error) {
+	filterRules, err := loadFilterRules(feed, user)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	var filteredEntries model.Entries
+	newEntries = &NewEntries{userID: feed.UserID}
 
-	// array used for bulk push
-	entriesToPush := model.Entries{}
+	var automationRules []*automationRule
+	if !preview {
+		automationRules = loadAutomationRules(store, user)
+	}
+
+	duplicates := &duplicateDetector{store: store, feed: feed, user: user}
 
 	// Process older entries first
 	for i := len(feed.Entries) - 1; i >= 0; i-- {
@@ -50,13 +125,51 @@ func ProcessFeedEntries(store *storage.Storage, feed *model.Feed, user *model.Us
 
 		logger.Debug("[Processor] Processing entry %q from feed %q", entry.URL, feed.FeedURL)
 
-		if isBlockedEntry(feed, entry) || !isAllowedEntry(feed, entry) {
+		entryIsNew := !store.EntryExists(feed.ID, entry.Hash)
+		if entryIsNew && store.EntryTombstoneExists(feed.ID, entry.Hash) {
+			// The entry has been purged, it must not be imported again.
+			if !preview {
+				continue
+			}
+			entryIsNew = false
+		}
+
+		entryPreview := &model.EntryPreview{Hash: entry.Hash, URL: entry.URL, New: entryIsNew}
+		if preview {
+			// The previews are listed in the order of the feed.
+			previews = append(model.EntryPreviews{entryPreview}, previews...)
+		}
+
+		if entry.Language == "" {
+			entry.Language = detectLanguage(entry.Title + "\n" + entry.Content)
+		}
+
+		blockRule, userBlockRule := filterRules.isBlockedEntry(entry)
+		userKeepRule, feedKeepRule, allowed := filterRules.isAllowedEntry(entry)
+		if blockRule != nil || !allowed {
+			entryPreview.Blocked = true
+			entryPreview.Title = entry.Title
+			if blockRule != nil {
+				entryPreview.BlocklistRule = blockRule.Text
+				if userBlockRule && entryIsNew && !preview {
+					countFilterRuleMatch(store, feed, model.FilterRuleBlocklist, blockRule, entry)
+				}
+			}
 			continue
 		}
 
+		if feedKeepRule != nil {
+			entryPreview.KeeplistRule = feedKeepRule.Text
+		} else if userKeepRule != nil {
+			entryPreview.KeeplistRule = userKeepRule.Text
+		}
+
+		if userKeepRule != nil && entryIsNew && !preview {
+			countFilterRuleMatch(store, feed, model.FilterRuleKeeplist, userKeepRule, entry)
+		}
+
 		url := getUrlFromEntry(feed, entry)
-		entryIsNew := !store.EntryURLExists(feed.ID, entry.URL)
-		if feed.Crawler && entryIsNew {
+		if feed.Crawler && (entryIsNew || preview) {
 			logger.Debug("[Processor] Crawling entry %q from feed %q", url, feed.FeedURL)
 
 			startTime := time.Now()
@@ -65,6 +178,9 @@ func ProcessFeedEntries(store *storage.Storage, feed *model.Feed, user *model.Us
 				feed.ScraperRules,
 				feed.UserAgent,
 				feed.Cookie,
+				feed.Headers,
+				feed.TLSSettings(),
+				feed.EffectiveProxyName(),
 				feed.AllowSelfSignedCertificates,
 				feed.FetchViaProxy,
 			)
@@ -79,67 +195,264 @@ func ProcessFeedEntries(store *storage.Storage, feed *model.Feed, user *model.Us
 
 			if scraperErr != nil {
 				logger.Error(`[Processor] Unable to crawl this entry: %q => %v`, entry.URL, scraperErr)
+				entryPreview.CrawlerError = scraperErr.Error()
 			} else if content != "" {
 				// We replace the entry content only if the scraper doesn't return any error.
 				entry.Content = content
+				entryPreview.Crawled = true
 			}
 		}
 
-		rewrite.Rewriter(url, entry, feed.RewriteRules)
+		entryPreview.RewriteRules = rewrite.Rewriter(url, entry, feed.RewriteRules)
 
 		// The sanitizer should always run at the end of the process to make sure unsafe HTML is filtered.
 		entry.Content = sanitizer.Sanitize(url, entry.Content)
 
-		if entryIsNew {
-			intg, err := store.Integration(feed.UserID)
-			if err != nil {
-				logger.Error("[Processor] Get integrations for user %d failed: %v; the refresh process will go on, but no integrations will run this time.", feed.UserID, err)
-			} else if intg != nil {
-				localEntry := entry
-				go func() {
-					integration.PushEntry(localEntry, intg)
-				}()
-				entriesToPush = append(entriesToPush, localEntry)
+		entryPreview.Title = entry.Title
+		entryPreview.Content = entry.Content
+
+		if entryIsNew && !preview {
+			if user.DuplicateEntries != model.DuplicateEntriesKeep {
+				if entry.DuplicateGroupID = duplicates.groupID(entry); entry.DuplicateGroupID != 0 && user.DuplicateEntries == model.DuplicateEntriesMarkAsRead {
+					logger.Debug("[Processor] Marking entry %q from feed %q as read, it was already published in another feed", entry.URL, feed.FeedURL)
+					entry.Status = model.EntryStatusRead
+				}
 			}
+
+			newEntries.entries = append(newEntries.entries, entry)
+			newEntries.integrationNames = append(newEntries.integrationNames, applyAutomationRules(automationRules, entry))
 		}
 
 		updateEntryReadingTime(store, feed, entry, entryIsNew, user)
 		filteredEntries = append(filteredEntries, entry)
 	}
 
-	intg, err := store.Integration(feed.UserID)
+	feed.Entries = filteredEntries
+
+	if preview {
+		return previews, nil, nil
+	}
+
+	return nil, newEntries, nil
+}
+
+// filterRules holds the blocklist and keeplist rules of the user and of the feed, parsed once per refresh.
+type filterRules struct {
+	feed          *model.Feed
+	userBlocklist filter.Rules
+	feedBlocklist filter.Rules
+	userKeeplist  filter.Rules
+	feedKeeplist  filter.Rules
+}
+
+// loadFilterRules parses the filter rules, the rules are validated when they are saved.
+func loadFilterRules(feed *model.Feed, user *model.User) (*filterRules, error) {
+	rules := &filterRules{feed: feed}
+	lists := []struct {
+		name  string
+		text  string
+		rules *filter.Rules
+	}{
+		{"user blocklist", user.BlocklistRules, &rules.userBlocklist},
+		{"blocklist", feed.BlocklistRules, &rules.feedBlocklist},
+		{"user keeplist", user.KeeplistRules, &rules.userKeeplist},
+		{"keeplist", feed.KeeplistRules, &rules.feedKeeplist},
+	}
+
+	for _, list := range lists {
+		parsedRules, err := filter.ParseListRules(list.text)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s rules: %v", list.name, err)
+		}
+		*list.rules = parsedRules
+	}
+
+	return rules, nil
+}
+
+// isBlockedEntry returns the user or feed blocklist rule that matches the entry, if any.
+func (r *filterRules) isBlockedEntry(entry *model.Entry) (rule *filter.Rule, userRule bool) {
+	if rule, matched := r.userBlocklist.Match(entry); matched {
+		logger.Debug("[Processor] Blocking entry %q from feed %q based on the user rule %q", entry.Title, r.feed.FeedURL, rule.Text)
+		return rule, true
+	}
+
+	if rule, matched := r.feedBlocklist.Match(entry); matched {
+		logger.Debug("[Processor] Blocking entry %q from feed %q based on rule %q", entry.Title, r.feed.FeedURL, rule.Text)
+		return rule, false
+	}
+
+	return nil, false
+}
+
+// isAllowedEntry returns the user and feed keeplist rules that match the entry and whether the entry is allowed.
+//
+// The entry must match both keeplists, an empty keeplist allows every entry.
+func (r *filterRules) isAllowedEntry(entry *model.Entry) (userRule, feedRule *filter.Rule, allowed bool) {
+	if len(r.userKeeplist) > 0 {
+		rule, matched := r.userKeeplist.Match(entry)
+		if !matched {
+			return nil, nil, false
+		}
+		logger.Debug("[Processor] Allow entry %q from feed %q based on the user rule %q", entry.Title, r.feed.FeedURL, rule.Text)
+		userRule = rule
+	}
+
+	if len(r.feedKeeplist) > 0 {
+		rule, matched := r.feedKeeplist.Match(entry)
+		if !matched {
+			return nil, nil, false
+		}
+		logger.Debug("[Processor] Allow entry %q from feed %q based on rule %q", entry.Title, r.feed.FeedURL, rule.Text)
+		feedRule = rule
+	}
+
+	return userRule, feedRule, true
+}
+
+func countFilterRuleMatch(store *storage.Storage, feed *model.Feed, list string, rule *filter.Rule, entry *model.Entry) {
+	if err := store.IncrementFilterRuleMatches(feed.UserID, feed.ID, list, rule.Text, entry.Hash); err != nil {
+		logger.Error("[Processor] %v", err)
+	}
+}
+
+type automationRule struct {
+	*model.AutomationRule
+	rules filter.Rules
+}
+
+// loadAutomationRules returns the enabled automation rules of the user, the invalid rules are skipped.
+func loadAutomationRules(store *storage.Storage, user *model.User) []*automationRule {
+	automationRules, err := store.AutomationRules(user.ID)
 	if err != nil {
-		logger.Error("[Processor] Get integrations for user %d failed: %v; the refresh process will go on, but no integrations will run this time.", feed.UserID, err)
-	} else if intg != nil && len(entriesToPush) > 0 {
-		go func() {
-			integration.PushEntries(entriesToPush, intg)
-		}()
+		logger.Error("[Processor] %v", err)
+		return nil
 	}
 
-	feed.Entries = filteredEntries
+	var compiledRules []*automationRule
+	for _, automationRule := range automationRules {
+		if automationRule.Disabled {
+			continue
+		}
+
+		compiled, err := compileAutomationRule(automationRule)
+		if err != nil {
+			logger.Error("[Processor] Invalid automation rule %q for user #%d: %v", automationRule.Name, user.ID, err)
+			continue
+		}
+		compiledRules = append(compiledRules, compiled)
+	}
+	return compiledRules
+}
+
+func compileAutomationRule(rule *model.AutomationRule) (*automationRule, error) {
+	rules, err := filter.Parse(rule.Rules)
+	if err != nil {
+		return nil, err
+	}
+	return &automationRule{AutomationRule: rule, rules: rules}, nil
 }
 
-func isBlockedEntry(feed *model.Feed, entry *model.Entry) bool {
-	if feed.BlocklistRules != "" {
-		match, _ := regexp.MatchString(feed.BlocklistRules, entry.Title)
-		if match {
-			logger.Debug("[Processor] Blocking entry %q from feed %q based on rule %q", entry.Title, feed.FeedURL, feed.BlocklistRules)
-			return true
+// applyAutomationRules applies the actions of the matching rules to a new entry before it is stored.
+//
+// The integrations cannot be called before the entry is stored, their names are returned instead.
+func applyAutomationRules(automationRules []*automationRule, entry *model.Entry) (integrationNames []string) {
+	for _, automationRule := range automationRules {
+		if _, matched := automationRule.rules.Match(entry); !matched {
+			continue
+		}
+
+		logger.Debug("[Processor] Applying automation rule %q to entry %q", automationRule.Name, entry.URL)
+
+		switch automationRule.Action {
+		case model.AutomationActionMarkAsRead:
+			entry.Status = model.EntryStatusRead
+		case model.AutomationActionStar:
+			entry.Starred = true
+		case model.AutomationActionReadLater:
+			entry.ReadLater = true
+		case model.AutomationActionAddTags:
+			entry.Tags = append(entry.Tags, automationRule.Tags()...)
+		case model.AutomationActionSendToIntegration:
+			integrationNames = append(integrationNames, automationRule.Value)
 		}
 	}
-	return false
+	return integrationNames
+}
+
+// duplicateTitleWindow is the period during which the titles of the entries are compared.
+const duplicateTitleWindow = 72 * time.Hour
+
+// duplicateDetector finds the copies of an entry already published in another feed of the user.
+type duplicateDetector struct {
+	store         *storage.Storage
+	feed          *model.Feed
+	user          *model.User
+	recentEntries model.Entries
+	loaded        bool
 }
 
-func isAllowedEntry(feed *model.Feed, entry *model.Entry) bool {
-	if feed.KeeplistRules != "" {
-		match, _ := regexp.MatchString(feed.KeeplistRules, entry.Title)
-		if match {
-			logger.Debug("[Processor] Allow entry %q from feed %q based on rule %q", entry.Title, feed.FeedURL, feed.KeeplistRules)
-			return true
+// groupID returns the duplicate group of the entry, or 0 if the entry was not published in another feed.
+func (d *duplicateDetector) groupID(entry *model.Entry) int64 {
+	if groupID := d.store.DuplicateEntryGroup(d.user.ID, d.feed.ID, entry.URL); groupID != 0 {
+		return groupID
+	}
+
+	if !d.user.DuplicateTitleMatching {
+		return 0
+	}
+
+	// The recent entries are loaded once per refresh, and only when a new entry needs them.
+	if !d.loaded {
+		recentEntries, err := d.store.RecentEntriesFromOtherFeeds(d.user.ID, d.feed.ID, time.Now().Add(-duplicateTitleWindow))
+		if err != nil {
+			logger.Error("[Processor] %v", err)
+		}
+		d.recentEntries, d.loaded = recentEntries, true
+	}
+
+	for _, candidate := range d.recentEntries {
+		if similarTitles(entry.Title, candidate.Title) {
+			if candidate.DuplicateGroupID != 0 {
+				return candidate.DuplicateGroupID
+			}
+			return candidate.ID
 		}
+	}
+	return 0
+}
+
+// similarTitles returns true if the titles have the same words, or if most of their words are shared.
+func similarTitles(a, b string) bool {
+	wordsA, wordsB := titleWords(a), titleWords(b)
+	if len(wordsA) == 0 || len(wordsB) == 0 {
 		return false
 	}
-	return true
+
+	common := 0
+	for word := range wordsA {
+		if wordsB[word] {
+			common++
+		}
+	}
+
+	if common == len(wordsA) && common == len(wordsB) {
+		return true
+	}
+
+	// Short titles are too ambiguous to be compared partially.
+	union := len(wordsA) + len(wordsB) - common
+	return union >= 5 && float64(common)/float64(union) >= 0.8
+}
+
+func titleWords(title string) map[string]bool {
+	words := make(map[string]bool)
+	for _, word := range strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	}) {
+		words[word] = true
+	}
+	return words
 }
 
 // ProcessEntryWebPage downloads the entry web page and apply rewrite rules.
@@ -152,6 +465,9 @@ func ProcessEntryWebPage(feed *model.Feed, entry *model.Entry, user *model.User)
 		entry.Feed.ScraperRules,
 		entry.Feed.UserAgent,
 		entry.Feed.Cookie,
+		entry.Feed.Headers,
+		entry.Feed.TLSSettings(),
+		feed.EffectiveProxyName(),
 		feed.AllowSelfSignedCertificates,
 		feed.FetchViaProxy,
 	)
@@ -170,7 +486,10 @@ func ProcessEntryWebPage(feed *model.Feed, entry *model.Entry, user *model.User)
 
 	if content != "" {
 		entry.Content = content
-		entry.ReadingTime = calculateReadingTime(content, user)
+		if entry.Language == "" {
+			entry.Language = detectLanguage(entry.Title + "\n" + content)
+		}
+		entry.ReadingTime = calculateReadingTime(content, entry.Language, user)
 	}
 
 	rewrite.Rewriter(url, entry, entry.Feed.RewriteRules)
@@ -210,7 +529,7 @@ func updateEntryReadingTime(store *storage.Storage, feed *model.Feed, entry *mod
 
 	// Handle YT error case and non-YT entries.
 	if entry.ReadingTime == 0 {
-		entry.ReadingTime = calculateReadingTime(entry.Content, user)
+		entry.ReadingTime = calculateReadingTime(entry.Content, entry.Language, user)
 	}
 }
 
@@ -285,12 +604,16 @@ func parseISO8601(from string) (time.Duration, error) {
 	return d, nil
 }
 
-func calculateReadingTime(content string, user *model.User) int {
+// detectLanguage returns the language code of an HTML text, or an empty string if it is undetermined.
+func detectLanguage(text string) string {
+	return model.NormalizeLanguage(getlang.FromString(sanitizer.StripTags(text)).LanguageCode())
+}
+
+func calculateReadingTime(content, language string, user *model.User) int {
 	sanitizedContent := sanitizer.StripTags(content)
-	languageInfo := getlang.FromString(sanitizedContent)
 
 	var timeToReadInt int
-	if languageInfo.LanguageCode() == "ko" || languageInfo.LanguageCode() == "zh" || languageInfo.LanguageCode() == "jp" {
+	if language == "ko" || language == "zh" || language == "ja" {
 		timeToReadInt = int(math.Ceil(float64(utf8.RuneCountInString(sanitizedContent)) / float64(user.CJKReadingSpeed)))
 	} else {
 		nbOfWords := len(strings.Fields(sanitizedContent))
@@ -5,6 +5,7 @@
 package processor // import "miniflux.app/reader/processor"
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -19,12 +20,14 @@ func TestBlockingEntries(t *testing.T) {
 	}{
 		{&model.Feed{ID: 1, BlocklistRules: "(?i)example"}, &model.Entry{Title: "Some Example"}, true},
 		{&model.Feed{ID: 1, BlocklistRules: "(?i)example"}, &model.Entry{Title: "Something different"}, false},
+		{&model.Feed{ID: 1, BlocklistRules: "#!rules\nauthor equals bot"}, &model.Entry{Title: "Automated post", Author: "Bot"}, true},
+		{&model.Feed{ID: 1, BlocklistRules: "#!rules\ntag equals ads\ntitle contains sponsored"}, &model.Entry{Title: "Sponsored"}, true},
 		{&model.Feed{ID: 1}, &model.Entry{Title: "No rule defined"}, false},
 	}
 
 	for _, tc := range scenarios {
-		result := isBlockedEntry(tc.feed, tc.entry)
-		if tc.expected != result {
+		rule, _ := mustLoadFilterRules(t, tc.feed, &model.User{}).isBlockedEntry(tc.entry)
+		if result := rule != nil; tc.expected != result {
 			t.Errorf(`Unexpected result, got %v for entry %q`, result, tc.entry.Title)
 		}
 	}
@@ -38,17 +41,119 @@ func TestAllowEntries(t *testing.T) {
 	}{
 		{&model.Feed{ID: 1, KeeplistRules: "(?i)example"}, &model.Entry{Title: "Some Example"}, true},
 		{&model.Feed{ID: 1, KeeplistRules: "(?i)example"}, &model.Entry{Title: "Something different"}, false},
+		{&model.Feed{ID: 1, KeeplistRules: "#!rules\ntag equals golang and not title contains job"}, &model.Entry{Title: "Go 1.20", Tags: []string{"golang"}}, true},
+		{&model.Feed{ID: 1, KeeplistRules: "#!rules\ntag equals golang and not title contains job"}, &model.Entry{Title: "Job offer", Tags: []string{"golang"}}, false},
 		{&model.Feed{ID: 1}, &model.Entry{Title: "No rule defined"}, true},
 	}
 
 	for _, tc := range scenarios {
-		result := isAllowedEntry(tc.feed, tc.entry)
+		_, _, result := mustLoadFilterRules(t, tc.feed, &model.User{}).isAllowedEntry(tc.entry)
 		if tc.expected != result {
 			t.Errorf(`Unexpected result, got %v for entry %q`, result, tc.entry.Title)
 		}
 	}
 }
 
+func TestInvalidFilterRules(t *testing.T) {
+	if _, err := loadFilterRules(&model.Feed{KeeplistRules: "#!rules\ntitle contains \"golang"}, &model.User{}); err == nil {
+		t.Error(`Invalid rules should not be ignored`)
+	}
+
+	if _, err := loadFilterRules(&model.Feed{}, &model.User{BlocklistRules: "[a-z"}); err == nil {
+		t.Error(`Invalid user rules should not be ignored`)
+	}
+}
+
+func TestUserFilterRules(t *testing.T) {
+	user := &model.User{BlocklistRules: "#!rules\ntitle contains football", KeeplistRules: "#!rules\ntag equals sports"}
+	entry := &model.Entry{Title: "Football results", Tags: []string{"sports"}}
+
+	if rule, userRule := mustLoadFilterRules(t, &model.Feed{}, user).isBlockedEntry(entry); rule == nil || !userRule {
+		t.Error(`The entry should be blocked by the user rule`)
+	}
+
+	if _, userRule := mustLoadFilterRules(t, &model.Feed{BlocklistRules: "results"}, &model.User{}).isBlockedEntry(entry); userRule {
+		t.Error(`The entry should be blocked by the feed rule`)
+	}
+
+	if userRule, feedRule, allowed := mustLoadFilterRules(t, &model.Feed{}, user).isAllowedEntry(entry); !allowed || userRule == nil || feedRule != nil {
+		t.Error(`The entry should be allowed by the user rule`)
+	}
+
+	if userRule, feedRule, allowed := mustLoadFilterRules(t, &model.Feed{KeeplistRules: "(?i)football"}, user).isAllowedEntry(entry); !allowed || userRule == nil || feedRule == nil {
+		t.Error(`The entry should be allowed by both keeplists`)
+	}
+
+	if _, _, allowed := mustLoadFilterRules(t, &model.Feed{KeeplistRules: "#!rules\ntag equals news"}, user).isAllowedEntry(entry); allowed {
+		t.Error(`The entry should match the feed keeplist as well`)
+	}
+
+	if _, _, allowed := mustLoadFilterRules(t, &model.Feed{KeeplistRules: "(?i)football"}, &model.User{KeeplistRules: "(?i)news"}).isAllowedEntry(entry); allowed {
+		t.Error(`The entry should match the user keeplist as well`)
+	}
+}
+
+func mustLoadFilterRules(t *testing.T, feed *model.Feed, user *model.User) *filterRules {
+	rules, err := loadFilterRules(feed, user)
+	if err != nil {
+		t.Fatalf(`Unable to parse the filter rules: %v`, err)
+	}
+	return rules
+}
+
+func TestApplyAutomationRules(t *testing.T) {
+	var automationRules []*automationRule
+	for _, rule := range []*model.AutomationRule{
+		{Name: "Read", Rules: "title contains release", Action: model.AutomationActionMarkAsRead},
+		{Name: "Star", Rules: "author equals alice", Action: model.AutomationActionStar},
+		{Name: "Tags", Rules: "title contains release", Action: model.AutomationActionAddTags, Value: "go, releases"},
+		{Name: "Later", Rules: "title contains football", Action: model.AutomationActionReadLater},
+		{Name: "Pinboard", Rules: "url contains golang", Action: model.AutomationActionSendToIntegration, Value: "pinboard"},
+	} {
+		compiled, err := compileAutomationRule(rule)
+		if err != nil {
+			t.Fatalf(`Unable to compile the rule %q: %v`, rule.Name, err)
+		}
+		automationRules = append(automationRules, compiled)
+	}
+
+	entry := &model.Entry{Title: "Go release", URL: "https://golang.org/", Author: "Alice", Tags: []string{"news"}}
+	integrationNames := applyAutomationRules(automationRules, entry)
+
+	if entry.Status != model.EntryStatusRead || !entry.Starred || entry.ReadLater {
+		t.Errorf(`Unexpected entry state: status=%q starred=%v read_later=%v`, entry.Status, entry.Starred, entry.ReadLater)
+	}
+
+	if strings.Join(entry.Tags, ",") != "news,go,releases" {
+		t.Errorf(`Unexpected tags: %v`, entry.Tags)
+	}
+
+	if len(integrationNames) != 1 || integrationNames[0] != "pinboard" {
+		t.Errorf(`Unexpected integrations: %v`, integrationNames)
+	}
+}
+
+func TestSimilarTitles(t *testing.T) {
+	scenarios := []struct {
+		a, b     string
+		expected bool
+	}{
+		{"Go 1.20 is released", "Go 1.20 is released", true},
+		{"Go 1.20 is released!", "go 1.20 is Released", true},
+		{"Show HN: A new feed reader written in Go", "A new feed reader written in Go", false},
+		{"The new feed reader written in Go is fast", "A new feed reader written in Go is fast", true},
+		{"Go 1.20", "Go 1.21", false},
+		{"Release notes", "Release", false},
+		{"", "", false},
+	}
+
+	for _, tc := range scenarios {
+		if result := similarTitles(tc.a, tc.b); result != tc.expected {
+			t.Errorf(`Unexpected result for %q and %q, got %v instead of %v`, tc.a, tc.b, result, tc.expected)
+		}
+	}
+}
+
 func TestParseISO8601(t *testing.T) {
 	var scenarios = []struct {
 		duration string
@@ -77,3 +182,33 @@ func TestParseISO8601(t *testing.T) {
 		}
 	}
 }
+
+func TestDetectLanguage(t *testing.T) {
+	var scenarios = []struct {
+		text     string
+		expected string
+	}{
+		{"<p>The quick brown fox jumps over the lazy dog, then it runs back into the forest.</p>", "en"},
+		{"<p>Le renard brun rapide saute par-dessus le chien paresseux, puis il retourne dans la forêt.</p>", "fr"},
+		{"<p>これは日本語の文章です。ひらがなとカタカナを使います。</p>", "ja"},
+		{"", ""},
+	}
+
+	for _, tc := range scenarios {
+		if result := detectLanguage(tc.text); result != tc.expected {
+			t.Errorf(`Unexpected language for %q, got %q instead of %q`, tc.text, result, tc.expected)
+		}
+	}
+}
+
+func TestCalculateReadingTime(t *testing.T) {
+	user := &model.User{DefaultReadingSpeed: 2, CJKReadingSpeed: 4}
+
+	if result := calculateReadingTime("<p>one two three four</p>", "en", user); result != 2 {
+		t.Errorf(`Unexpected reading time, got %d`, result)
+	}
+
+	if result := calculateReadingTime("<p>日本語の文章</p>", "ja", user); result != 2 {
+		t.Errorf(`Unexpected reading time for a CJK language, got %d`, result)
+	}
+}
@@ -6,7 +6,8 @@ package rdf // import "miniflux.app/reader/rdf"
 
 // DublinCoreFeedElement represents Dublin Core feed XML elements.
 type DublinCoreFeedElement struct {
-	DublinCoreCreator string `xml:"http://purl.org/dc/elements/1.1/ channel>creator"`
+	DublinCoreCreator  string `xml:"http://purl.org/dc/elements/1.1/ channel>creator"`
+	DublinCoreLanguage string `xml:"http://purl.org/dc/elements/1.1/ channel>language"`
 }
 
 // DublinCoreEntryElement represents Dublin Core entry XML elements.
@@ -9,6 +9,9 @@ import (
 	"strings"
 	"testing"
 	"time"
+
+	"miniflux.app/crypto"
+	"miniflux.app/model"
 )
 
 func TestParseRDFSample(t *testing.T) {
@@ -264,6 +267,33 @@ func TestParseItemWithOnlyFeedAuthor(t *testing.T) {
 	}
 }
 
+func TestParseFeedWithSyndicationUpdatePeriod(t *testing.T) {
+	data := `<?xml version="1.0" encoding="utf-8"?>
+
+	<rdf:RDF
+	  xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+	  xmlns:sy="http://purl.org/rss/1.0/modules/syndication/"
+	  xmlns="http://purl.org/rss/1.0/"
+	>
+
+	  <channel rdf:about="http://meerkat.oreillynet.com/?_fl=rss1.0">
+		<title>Meerkat</title>
+		<link>http://meerkat.oreillynet.com</link>
+		<sy:updatePeriod>hourly</sy:updatePeriod>
+		<sy:updateFrequency>2</sy:updateFrequency>
+	  </channel>
+	</rdf:RDF>`
+
+	feed, err := Parse("http://meerkat.oreillynet.com", bytes.NewBufferString(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.TTL != 30 {
+		t.Errorf("Incorrect TTL, got: %d", feed.TTL)
+	}
+}
+
 func TestParseItemRelativeURL(t *testing.T) {
 	data := `<?xml version="1.0" encoding="utf-8"?>
 	<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#" xmlns="http://purl.org/rss/1.0/">
@@ -577,3 +607,52 @@ func TestParseRDFWithEncodedHTMLDescription(t *testing.T) {
 		t.Errorf(`Unexpected entry URL, got %v instead of %v`, result, expected)
 	}
 }
+
+func TestParseItemWithURLIdentity(t *testing.T) {
+	data := `<?xml version="1.0" encoding="utf-8"?>
+		<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#" xmlns="http://purl.org/rss/1.0/">
+			<channel>
+				<title>Example</title>
+				<link>http://example.org</link>
+			</channel>
+			<item>
+				<title>Title</title>
+				<link>http://example.org/item</link>
+				<description>Test</description>
+			</item>
+		</rdf:RDF>`
+
+	feed, err := ParseWithEntryIdentity("http://example.org", bytes.NewBufferString(data), model.EntryIdentityURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.Entries[0].Hash != crypto.Hash("http://example.org/item") {
+		t.Errorf("Incorrect entry hash, got: %s", feed.Entries[0].Hash)
+	}
+}
+
+func TestParseItemLanguage(t *testing.T) {
+	data := `<?xml version="1.0" encoding="utf-8"?>
+		<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#" xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns="http://purl.org/rss/1.0/">
+			<channel>
+				<title>Example</title>
+				<link>http://example.org</link>
+				<dc:language>de-DE</dc:language>
+			</channel>
+			<item>
+				<title>Title</title>
+				<link>http://example.org/item</link>
+				<description>Test</description>
+			</item>
+		</rdf:RDF>`
+
+	feed, err := Parse("http://example.org", bytes.NewBufferString(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.Entries[0].Language != "de" {
+		t.Errorf("Incorrect entry language, got: %q", feed.Entries[0].Language)
+	}
+}
@@ -15,6 +15,7 @@ import (
 	"miniflux.app/model"
 	"miniflux.app/reader/date"
 	"miniflux.app/reader/sanitizer"
+	"miniflux.app/reader/syndication"
 	"miniflux.app/url"
 )
 
@@ -24,9 +25,10 @@ type rdfFeed struct {
 	Link    string    `xml:"channel>link"`
 	Items   []rdfItem `xml:"item"`
 	DublinCoreFeedElement
+	syndication.Element
 }
 
-func (r *rdfFeed) Transform(baseURL string) *model.Feed {
+func (r *rdfFeed) Transform(baseURL, entryIdentity string) *model.Feed {
 	var err error
 	feed := new(model.Feed)
 	feed.Title = sanitizer.StripTags(r.Title)
@@ -36,12 +38,17 @@ func (r *rdfFeed) Transform(baseURL string) *model.Feed {
 		feed.SiteURL = r.Link
 	}
 
+	feed.TTL = r.UpdateIntervalInMinutes()
+
+	language := model.NormalizeLanguage(r.DublinCoreLanguage)
 	for _, item := range r.Items {
 		entry := item.Transform()
 		if entry.Author == "" && r.DublinCoreCreator != "" {
 			entry.Author = strings.TrimSpace(r.DublinCoreCreator)
 		}
 
+		entry.Language = language
+
 		if entry.URL == "" {
 			entry.URL = feed.SiteURL
 		} else {
@@ -51,6 +58,10 @@ func (r *rdfFeed) Transform(baseURL string) *model.Feed {
 			}
 		}
 
+		if hash := model.EntryIdentityHash(entryIdentity, entry); hash != "" {
+			entry.Hash = hash
+		}
+
 		feed.Entries = append(feed.Entries, entry)
 	}
 
@@ -19,8 +19,22 @@ type rule struct {
 	args []string
 }
 
+func (r rule) String() string {
+	if len(r.args) == 0 {
+		return r.name
+	}
+
+	quotedArgs := make([]string, len(r.args))
+	for i, arg := range r.args {
+		quotedArgs[i] = strconv.Quote(arg)
+	}
+	return r.name + "(" + strings.Join(quotedArgs, "|") + ")"
+}
+
 // Rewriter modify item contents with a set of rewriting rules.
-func Rewriter(entryURL string, entry *model.Entry, customRewriteRules string) {
+//
+// It returns the rules that changed the title or the content of the entry.
+func Rewriter(entryURL string, entry *model.Entry, customRewriteRules string) (appliedRules []string) {
 	rulesList := getPredefinedRewriteRules(entryURL)
 	if customRewriteRules != "" {
 		rulesList = customRewriteRules
@@ -32,8 +46,15 @@ func Rewriter(entryURL string, entry *model.Entry, customRewriteRules string) {
 	logger.Debug(`[Rewrite] Applying rules %v for %q`, rules, entryURL)
 
 	for _, rule := range rules {
+		title, content := entry.Title, entry.Content
 		applyRule(entryURL, entry, rule)
+
+		if entry.Title != title || entry.Content != content {
+			appliedRules = append(appliedRules, rule.String())
+		}
 	}
+
+	return appliedRules
 }
 
 func parseRules(rulesText string) (rules []rule) {
@@ -531,3 +531,17 @@ func TestRemoveClickbait(t *testing.T) {
 		t.Errorf(`Not expected output: got "%+v" instead of "%+v"`, testEntry, controlEntry)
 	}
 }
+
+func TestRewriterReturnsAppliedRules(t *testing.T) {
+	testEntry := &model.Entry{
+		Title:   `A title`,
+		Content: `<p>Some text.</p><p class="ad">Ad</p>`,
+	}
+
+	appliedRules := Rewriter("https://example.org/article", testEntry, `remove(".ad"),replace("missing"|"text"),nl2br`)
+	expected := []string{`remove(".ad")`}
+
+	if !reflect.DeepEqual(appliedRules, expected) {
+		t.Errorf(`Unexpected applied rules, got %v instead of %v`, appliedRules, expected)
+	}
+}
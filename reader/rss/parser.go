@@ -14,6 +14,11 @@ import (
 
 // Parse returns a normalized feed struct from a RSS feed.
 func Parse(baseURL string, data io.Reader) (*model.Feed, *errors.LocalizedError) {
+	return ParseWithEntryIdentity(baseURL, data, model.EntryIdentityGUID)
+}
+
+// ParseWithEntryIdentity returns a normalized feed struct from a RSS feed, the entries are identified with the given strategy.
+func ParseWithEntryIdentity(baseURL string, data io.Reader, entryIdentity string) (*model.Feed, *errors.LocalizedError) {
 	feed := new(rssFeed)
 	decoder := xml.NewDecoder(data)
 	err := decoder.Decode(feed)
@@ -21,5 +26,5 @@ func Parse(baseURL string, data io.Reader) (*model.Feed, *errors.LocalizedError)
 		return nil, errors.NewLocalizedError("Unable to parse RSS feed: %q", err)
 	}
 
-	return feed.Transform(baseURL), nil
+	return feed.Transform(baseURL, entryIdentity), nil
 }
@@ -8,6 +8,9 @@ import (
 	"bytes"
 	"testing"
 	"time"
+
+	"miniflux.app/crypto"
+	"miniflux.app/model"
 )
 
 func TestParseRss2Sample(t *testing.T) {
@@ -128,6 +131,70 @@ func TestParseFeedWithoutTitle(t *testing.T) {
 	}
 }
 
+func TestParseFeedWithTTL(t *testing.T) {
+	data := `<?xml version="1.0" encoding="utf-8"?>
+		<rss version="2.0" xmlns:sy="http://purl.org/rss/1.0/modules/syndication/">
+		<channel>
+			<link>https://example.org/</link>
+			<ttl>90</ttl>
+			<sy:updatePeriod>hourly</sy:updatePeriod>
+		</channel>
+		</rss>`
+
+	feed, err := Parse("https://example.org/", bytes.NewBufferString(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.TTL != 90 {
+		t.Errorf("Incorrect TTL, got: %d", feed.TTL)
+	}
+}
+
+func TestParseFeedWithSyndicationUpdatePeriod(t *testing.T) {
+	data := `<?xml version="1.0" encoding="utf-8"?>
+		<rss version="2.0" xmlns:sy="http://purl.org/rss/1.0/modules/syndication/">
+		<channel>
+			<link>https://example.org/</link>
+			<sy:updatePeriod>daily</sy:updatePeriod>
+			<sy:updateFrequency>4</sy:updateFrequency>
+		</channel>
+		</rss>`
+
+	feed, err := Parse("https://example.org/", bytes.NewBufferString(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.TTL != 360 {
+		t.Errorf("Incorrect TTL, got: %d", feed.TTL)
+	}
+}
+
+func TestParseFeedWithSkipHoursAndSkipDays(t *testing.T) {
+	data := `<?xml version="1.0" encoding="utf-8"?>
+		<rss version="2.0">
+		<channel>
+			<link>https://example.org/</link>
+			<skipHours><hour>0</hour><hour>23</hour><hour>24</hour><hour>invalid</hour></skipHours>
+			<skipDays><day>Saturday</day><day>sunday</day><day>invalid</day></skipDays>
+		</channel>
+		</rss>`
+
+	feed, err := Parse("https://example.org/", bytes.NewBufferString(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(feed.SkipHours) != 2 || feed.SkipHours[0] != 0 || feed.SkipHours[1] != 23 {
+		t.Errorf("Incorrect skip hours, got: %v", feed.SkipHours)
+	}
+
+	if len(feed.SkipDays) != 2 || feed.SkipDays[0] != "Saturday" || feed.SkipDays[1] != "Sunday" {
+		t.Errorf("Incorrect skip days, got: %v", feed.SkipDays)
+	}
+}
+
 func TestParseEntryWithoutTitleAndDescription(t *testing.T) {
 	data := `<?xml version="1.0" encoding="utf-8"?>
 		<rss version="2.0">
@@ -340,6 +407,35 @@ func TestParseFeedURLWithAtomLink(t *testing.T) {
 	}
 }
 
+func TestParseFeedWithHubLink(t *testing.T) {
+	data := `<?xml version="1.0" encoding="utf-8"?>
+		<rss xmlns:atom="http://www.w3.org/2005/Atom" version="2.0">
+		<channel>
+			<title>Example</title>
+			<link>https://example.org/</link>
+			<atom:link href="https://hub.example.org/" rel="hub"></atom:link>
+			<atom:link href="https://example.org/rss" type="application/rss+xml" rel="self"></atom:link>
+		</channel>
+		</rss>`
+
+	feed, err := Parse("https://example.org/", bytes.NewBufferString(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.FeedURL != "https://example.org/rss" {
+		t.Errorf("Incorrect feed URL, got: %s", feed.FeedURL)
+	}
+
+	if feed.HubURL != "https://hub.example.org/" {
+		t.Errorf("Incorrect hub URL, got: %s", feed.HubURL)
+	}
+
+	if feed.SelfURL != "https://example.org/rss" {
+		t.Errorf("Incorrect self URL, got: %s", feed.SelfURL)
+	}
+}
+
 func TestParseFeedWithWebmaster(t *testing.T) {
 	data := `<?xml version="1.0" encoding="utf-8"?>
 		<rss version="2.0">
@@ -1501,3 +1597,66 @@ func TestParseEntryWithCategoryAndCDATA(t *testing.T) {
 		t.Errorf("Incorrect entry category, got %q instead of %q", result, expected)
 	}
 }
+
+func TestParseEntriesWithURLTitleIdentity(t *testing.T) {
+	data := `<?xml version="1.0" encoding="utf-8"?>
+		<rss version="2.0">
+		<channel>
+			<link>https://example.org/</link>
+			<item>
+				<guid isPermaLink="false">1234</guid>
+				<title>Entry Title</title>
+				<link>/entry</link>
+			</item>
+			<item>
+				<guid isPermaLink="false">5678</guid>
+				<link>/other-entry</link>
+			</item>
+		</channel>
+		</rss>`
+
+	feed, err := ParseWithEntryIdentity("https://example.org/", bytes.NewBufferString(data), model.EntryIdentityURLTitle)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.Entries[0].Hash != crypto.Hash("https://example.org/entry\nEntry Title") {
+		t.Errorf("Incorrect entry hash, got: %s", feed.Entries[0].Hash)
+	}
+
+	if feed.Entries[1].Hash != crypto.Hash("https://example.org/other-entry\nhttps://example.org/other-entry") {
+		t.Errorf("The link should be used as title, got: %s", feed.Entries[1].Hash)
+	}
+}
+
+func TestParseEntryLanguage(t *testing.T) {
+	data := `<?xml version="1.0" encoding="utf-8"?>
+		<rss version="2.0" xmlns:dc="http://purl.org/dc/elements/1.1/">
+		<channel>
+			<link>https://example.org/</link>
+			<language>en-us</language>
+			<item>
+				<title>Entry Title</title>
+				<link>https://example.org/a</link>
+			</item>
+			<item>
+				<title>Titre</title>
+				<link>https://example.org/b</link>
+				<dc:language>fr</dc:language>
+			</item>
+		</channel>
+		</rss>`
+
+	feed, err := Parse("https://example.org/", bytes.NewBufferString(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.Entries[0].Language != "en" {
+		t.Errorf("Incorrect entry language, got: %q", feed.Entries[0].Language)
+	}
+
+	if feed.Entries[1].Language != "fr" {
+		t.Errorf("Incorrect entry language, got: %q", feed.Entries[1].Language)
+	}
+}
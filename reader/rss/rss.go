@@ -18,6 +18,7 @@ import (
 	"miniflux.app/reader/date"
 	"miniflux.app/reader/media"
 	"miniflux.app/reader/sanitizer"
+	"miniflux.app/reader/syndication"
 	"miniflux.app/url"
 )
 
@@ -33,11 +34,15 @@ type rssFeed struct {
 	PubDate        string    `xml:"channel>pubDate"`
 	ManagingEditor string    `xml:"channel>managingEditor"`
 	Webmaster      string    `xml:"channel>webMaster"`
+	TTL            string    `xml:"channel>ttl"`
+	SkipHours      []string  `xml:"channel>skipHours>hour"`
+	SkipDays       []string  `xml:"channel>skipDays>day"`
 	Items          []rssItem `xml:"channel>item"`
 	PodcastFeedElement
+	syndication.Element
 }
 
-func (r *rssFeed) Transform(baseURL string) *model.Feed {
+func (r *rssFeed) Transform(baseURL, entryIdentity string) *model.Feed {
 	var err error
 
 	feed := new(model.Feed)
@@ -54,19 +59,41 @@ func (r *rssFeed) Transform(baseURL string) *model.Feed {
 		feed.FeedURL = feedURL
 	}
 
+	if selfURL := r.atomLinkWithRelation("self"); selfURL != "" {
+		feed.SelfURL, err = url.AbsoluteURL(baseURL, selfURL)
+		if err != nil {
+			feed.SelfURL = selfURL
+		}
+	}
+
+	if hubURL := r.atomLinkWithRelation("hub"); hubURL != "" {
+		feed.HubURL, err = url.AbsoluteURL(baseURL, hubURL)
+		if err != nil {
+			feed.HubURL = hubURL
+		}
+	}
+
 	feed.Title = html.UnescapeString(strings.TrimSpace(r.Title))
 	if feed.Title == "" {
 		feed.Title = feed.SiteURL
 	}
 
 	feed.IconURL = strings.TrimSpace(r.ImageURL)
+	feed.TTL = r.ttl()
+	feed.SkipHours = r.skipHours()
+	feed.SkipDays = r.skipDays()
 
+	language := model.NormalizeLanguage(r.Language)
 	for _, item := range r.Items {
 		entry := item.Transform()
 		if entry.Author == "" {
 			entry.Author = r.feedAuthor()
 		}
 
+		if entry.Language == "" {
+			entry.Language = language
+		}
+
 		if entry.URL == "" {
 			entry.URL = feed.SiteURL
 		} else {
@@ -84,6 +111,10 @@ func (r *rssFeed) Transform(baseURL string) *model.Feed {
 			entry.Title = entry.URL
 		}
 
+		if hash := model.EntryIdentityHash(entryIdentity, entry); hash != "" {
+			entry.Hash = hash
+		}
+
 		feed.Entries = append(feed.Entries, entry)
 	}
 
@@ -102,7 +133,8 @@ func (r *rssFeed) siteURL() string {
 
 func (r *rssFeed) feedURL() string {
 	for _, element := range r.Links {
-		if element.XMLName.Space == "http://www.w3.org/2005/Atom" {
+		// The hub link is not the location of the feed.
+		if element.XMLName.Space == "http://www.w3.org/2005/Atom" && strings.ToLower(element.Rel) != "hub" {
 			return strings.TrimSpace(element.Href)
 		}
 	}
@@ -110,6 +142,48 @@ func (r *rssFeed) feedURL() string {
 	return ""
 }
 
+func (r *rssFeed) atomLinkWithRelation(relation string) string {
+	for _, element := range r.Links {
+		if element.XMLName.Space == "http://www.w3.org/2005/Atom" && strings.ToLower(element.Rel) == relation {
+			return strings.TrimSpace(element.Href)
+		}
+	}
+
+	return ""
+}
+
+func (r *rssFeed) ttl() int {
+	if ttl, err := strconv.Atoi(strings.TrimSpace(r.TTL)); err == nil && ttl > 0 {
+		return ttl
+	}
+
+	return r.UpdateIntervalInMinutes()
+}
+
+func (r *rssFeed) skipHours() []int64 {
+	var hours []int64
+	for _, value := range r.SkipHours {
+		hour, err := strconv.Atoi(strings.TrimSpace(value))
+		if err == nil && hour >= 0 && hour <= 23 {
+			hours = append(hours, int64(hour))
+		}
+	}
+	return hours
+}
+
+func (r *rssFeed) skipDays() []string {
+	var days []string
+	for _, value := range r.SkipDays {
+		value = strings.ToLower(strings.TrimSpace(value))
+		for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+			if value == strings.ToLower(weekday.String()) {
+				days = append(days, weekday.String())
+			}
+		}
+	}
+	return days
+}
+
 func (r rssFeed) feedAuthor() string {
 	author := r.PodcastAuthor()
 	switch {
@@ -200,6 +274,7 @@ func (r *rssItem) Transform() *model.Entry {
 	entry.Title = r.entryTitle()
 	entry.Enclosures = r.entryEnclosures()
 	entry.Tags = r.entryCategories()
+	entry.Language = model.NormalizeLanguage(r.DublinCoreLanguage)
 
 	return entry
 }
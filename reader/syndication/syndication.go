@@ -0,0 +1,44 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package syndication // import "miniflux.app/reader/syndication"
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Element represents the syndication XML elements of a RSS or RDF channel.
+// Specs: https://web.resource.org/rss/1.0/modules/syndication/
+type Element struct {
+	UpdatePeriod    string `xml:"http://purl.org/rss/1.0/modules/syndication/ channel>updatePeriod"`
+	UpdateFrequency string `xml:"http://purl.org/rss/1.0/modules/syndication/ channel>updateFrequency"`
+}
+
+// UpdateIntervalInMinutes returns the refresh interval advertised by the publisher.
+func (e *Element) UpdateIntervalInMinutes() int {
+	var periodInMinutes int
+
+	switch strings.ToLower(strings.TrimSpace(e.UpdatePeriod)) {
+	case "hourly":
+		periodInMinutes = 60
+	case "daily":
+		periodInMinutes = 24 * 60
+	case "weekly":
+		periodInMinutes = 7 * 24 * 60
+	case "monthly":
+		periodInMinutes = 30 * 24 * 60
+	case "yearly":
+		periodInMinutes = 365 * 24 * 60
+	default:
+		return 0
+	}
+
+	frequency := 1
+	if value, err := strconv.Atoi(strings.TrimSpace(e.UpdateFrequency)); err == nil && value > 0 {
+		frequency = value
+	}
+
+	return periodInMinutes / frequency
+}
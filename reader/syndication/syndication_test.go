@@ -0,0 +1,30 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package syndication // import "miniflux.app/reader/syndication"
+
+import "testing"
+
+func TestUpdateIntervalInMinutes(t *testing.T) {
+	scenarios := []struct {
+		period    string
+		frequency string
+		expected  int
+	}{
+		{"", "", 0},
+		{"invalid", "2", 0},
+		{"hourly", "", 60},
+		{"hourly", "2", 30},
+		{" Daily ", "1", 1440},
+		{"daily", "invalid", 1440},
+		{"weekly", "7", 1440},
+	}
+
+	for _, scenario := range scenarios {
+		element := &Element{UpdatePeriod: scenario.period, UpdateFrequency: scenario.frequency}
+		if result := element.UpdateIntervalInMinutes(); result != scenario.expected {
+			t.Errorf(`Unexpected interval for %q/%q, got %d instead of %d`, scenario.period, scenario.frequency, result, scenario.expected)
+		}
+	}
+}
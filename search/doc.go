@@ -0,0 +1,23 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+/*
+Package search parses the queries used to search entries.
+
+A query is a list of words, quoted phrases and filters:
+
+	feed:lwn author:"Jonathan Corbet" -kernel after:2024-01 is:starred "memory safety"
+
+The words and the phrases are searched in the title and the content of the entries,
+a leading minus excludes the entries containing a word, a phrase or matching a filter.
+
+The filters feed, category and author match the entries where the field contains the value,
+tag and language the entries having this value, they are case-insensitive.
+The other filters are is:unread, is:read, is:starred, is:read_later and the dates after and before.
+The dates are like 2024, 2024-01 or 2024-01-31, after includes the entries published
+from the first day of the period and before excludes them.
+
+Everything that is not a valid filter is searched as a word.
+*/
+package search // import "miniflux.app/search"
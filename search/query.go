@@ -0,0 +1,210 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package search // import "miniflux.app/search"
+
+import (
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Filter fields.
+const (
+	FieldFeed     = "feed"
+	FieldCategory = "category"
+	FieldAuthor   = "author"
+	FieldTag      = "tag"
+	FieldLanguage = "language"
+	FieldIs       = "is"
+)
+
+// Values of the is filter.
+const (
+	IsUnread    = "unread"
+	IsRead      = "read"
+	IsStarred   = "starred"
+	IsReadLater = "read_later"
+)
+
+var (
+	fields = map[string]string{
+		"feed":     FieldFeed,
+		"category": FieldCategory,
+		"author":   FieldAuthor,
+		"tag":      FieldTag,
+		"language": FieldLanguage,
+		"lang":     FieldLanguage,
+		"is":       FieldIs,
+	}
+
+	isValues = map[string]bool{
+		IsUnread:    true,
+		IsRead:      true,
+		IsStarred:   true,
+		IsReadLater: true,
+	}
+
+	dateLayouts = []string{"2006-01-02", "2006-01", "2006"}
+)
+
+// Filter is a condition on a field of the entries.
+type Filter struct {
+	Field   string
+	Value   string
+	Exclude bool
+}
+
+// Query is a parsed search query.
+type Query struct {
+	// Terms are the words searched in the title and the content.
+	Terms []string
+
+	// Phrases are the quoted texts searched in the title and the content.
+	Phrases []string
+
+	// Excluded are the words and the phrases that must not be in the title or the content.
+	Excluded []string
+
+	Filters []Filter
+
+	// After and Before are the first day of the periods, zero if they are not set.
+	After  time.Time
+	Before time.Time
+}
+
+// HasFullText returns true if the query has words or phrases to find.
+func (q *Query) HasFullText() bool {
+	return len(q.Terms) > 0 || len(q.Phrases) > 0
+}
+
+// IsEmpty returns true if the query doesn't have any condition.
+func (q *Query) IsEmpty() bool {
+	return !q.HasFullText() && len(q.Excluded) == 0 && len(q.Filters) == 0 && q.After.IsZero() && q.Before.IsZero()
+}
+
+type token struct {
+	value   string
+	quoted  bool
+	exclude bool
+}
+
+// Parse returns the query of a search text.
+func Parse(text string) *Query {
+	query := &Query{}
+	for _, t := range tokenize(text) {
+		if !t.quoted && query.addFilter(t) {
+			continue
+		}
+
+		switch {
+		case t.exclude:
+			query.Excluded = append(query.Excluded, t.value)
+		case t.quoted:
+			query.Phrases = append(query.Phrases, t.value)
+		default:
+			query.Terms = append(query.Terms, t.value)
+		}
+	}
+	return query
+}
+
+// addFilter adds a field:value token to the query and returns false if it isn't a valid filter.
+func (q *Query) addFilter(t token) bool {
+	index := strings.Index(t.value, ":")
+	if index <= 0 {
+		return false
+	}
+
+	name, value := strings.ToLower(t.value[:index]), t.value[index+1:]
+	if value == "" {
+		return false
+	}
+
+	switch name {
+	case "after", "before":
+		if t.exclude {
+			return false
+		}
+
+		date, ok := parseDate(value)
+		if !ok {
+			return false
+		}
+
+		if name == "after" {
+			q.After = date
+		} else {
+			q.Before = date
+		}
+		return true
+	}
+
+	field, found := fields[name]
+	if !found {
+		return false
+	}
+
+	if field == FieldIs {
+		value = strings.ToLower(value)
+		if !isValues[value] {
+			return false
+		}
+	}
+
+	q.Filters = append(q.Filters, Filter{Field: field, Value: value, Exclude: t.exclude})
+	return true
+}
+
+func parseDate(value string) (time.Time, bool) {
+	for _, layout := range dateLayouts {
+		if date, err := time.Parse(layout, value); err == nil {
+			return date, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// tokenize splits the text into words, quoted phrases and field:value pairs where the value may be quoted.
+func tokenize(text string) []token {
+	var tokens []token
+	runes := []rune(text)
+	for i := 0; i < len(runes); {
+		if unicode.IsSpace(runes[i]) {
+			i++
+			continue
+		}
+
+		var t token
+		if runes[i] == '-' && i+1 < len(runes) && !unicode.IsSpace(runes[i+1]) {
+			t.exclude = true
+			i++
+		}
+
+		var b strings.Builder
+		for i < len(runes) && !unicode.IsSpace(runes[i]) {
+			if runes[i] != '"' {
+				b.WriteRune(runes[i])
+				i++
+				continue
+			}
+
+			// A quote starts a phrase, or the value of a field when it follows a colon.
+			t.quoted = b.Len() == 0
+			i++
+			for i < len(runes) && runes[i] != '"' {
+				b.WriteRune(runes[i])
+				i++
+			}
+			i++
+			break
+		}
+
+		t.value = strings.TrimSpace(b.String())
+		if t.value != "" {
+			tokens = append(tokens, t)
+		}
+	}
+	return tokens
+}
@@ -0,0 +1,125 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package search // import "miniflux.app/search"
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseQuery(t *testing.T) {
+	query := Parse(`feed:lwn author:"Jonathan Corbet" -kernel after:2024-01 is:starred "memory safety"`)
+
+	expected := &Query{
+		Phrases:  []string{"memory safety"},
+		Excluded: []string{"kernel"},
+		Filters: []Filter{
+			{Field: FieldFeed, Value: "lwn"},
+			{Field: FieldAuthor, Value: "Jonathan Corbet"},
+			{Field: FieldIs, Value: IsStarred},
+		},
+		After: time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	if !reflect.DeepEqual(query, expected) {
+		t.Errorf(`Unexpected query, got %#v`, query)
+	}
+}
+
+func TestParsePlainText(t *testing.T) {
+	query := Parse("  hello   world ")
+
+	if !reflect.DeepEqual(query.Terms, []string{"hello", "world"}) {
+		t.Errorf(`Unexpected terms, got %#v`, query.Terms)
+	}
+
+	if !query.HasFullText() || query.IsEmpty() {
+		t.Error(`The query should have full-text terms`)
+	}
+}
+
+func TestParseExclusions(t *testing.T) {
+	query := Parse(`-"breaking news" -is:read -tag:ads - foo`)
+
+	if !reflect.DeepEqual(query.Excluded, []string{"breaking news"}) {
+		t.Errorf(`Unexpected excluded terms, got %#v`, query.Excluded)
+	}
+
+	expected := []Filter{
+		{Field: FieldIs, Value: IsRead, Exclude: true},
+		{Field: FieldTag, Value: "ads", Exclude: true},
+	}
+	if !reflect.DeepEqual(query.Filters, expected) {
+		t.Errorf(`Unexpected filters, got %#v`, query.Filters)
+	}
+
+	if !reflect.DeepEqual(query.Terms, []string{"-", "foo"}) {
+		t.Errorf(`Unexpected terms, got %#v`, query.Terms)
+	}
+
+	if !query.HasFullText() {
+		t.Error(`The query should have full-text terms`)
+	}
+}
+
+func TestParseDates(t *testing.T) {
+	scenarios := map[string]time.Time{
+		"after:2024":       time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+		"after:2024-03":    time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC),
+		"AFTER:2024-03-15": time.Date(2024, time.March, 15, 0, 0, 0, 0, time.UTC),
+	}
+
+	for text, expected := range scenarios {
+		if query := Parse(text); !query.After.Equal(expected) {
+			t.Errorf(`Unexpected date for %q, got %v`, text, query.After)
+		}
+	}
+
+	query := Parse("before:2023-12-31")
+	if !query.Before.Equal(time.Date(2023, time.December, 31, 0, 0, 0, 0, time.UTC)) || !query.After.IsZero() {
+		t.Errorf(`Unexpected dates, got %v and %v`, query.After, query.Before)
+	}
+}
+
+func TestParseInvalidFiltersAsTerms(t *testing.T) {
+	query := Parse(`after:yesterday is:shared unknown:value feed: -after:2024 https://example.org`)
+
+	expected := []string{"after:yesterday", "is:shared", "unknown:value", "feed:", "https://example.org"}
+	if !reflect.DeepEqual(query.Terms, expected) {
+		t.Errorf(`Unexpected terms, got %#v`, query.Terms)
+	}
+
+	if !reflect.DeepEqual(query.Excluded, []string{"after:2024"}) {
+		t.Errorf(`Unexpected excluded terms, got %#v`, query.Excluded)
+	}
+
+	if len(query.Filters) != 0 || !query.After.IsZero() {
+		t.Errorf(`The query should not have any filter, got %#v`, query.Filters)
+	}
+}
+
+func TestParseFilterAliases(t *testing.T) {
+	query := Parse(`lang:fr IS:Read_Later Category:Tech`)
+
+	expected := []Filter{
+		{Field: FieldLanguage, Value: "fr"},
+		{Field: FieldIs, Value: IsReadLater},
+		{Field: FieldCategory, Value: "Tech"},
+	}
+	if !reflect.DeepEqual(query.Filters, expected) {
+		t.Errorf(`Unexpected filters, got %#v`, query.Filters)
+	}
+
+	if query.HasFullText() || query.IsEmpty() {
+		t.Error(`The query should only have filters`)
+	}
+}
+
+func TestParseEmptyQuery(t *testing.T) {
+	if query := Parse(`  "" `); !query.IsEmpty() {
+		t.Errorf(`The query should be empty, got %#v`, query)
+	}
+}
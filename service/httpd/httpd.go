@@ -19,9 +19,11 @@ import (
 	"miniflux.app/googlereader"
 	"miniflux.app/http/request"
 	"miniflux.app/logger"
+	"miniflux.app/model"
 	"miniflux.app/storage"
 	"miniflux.app/ui"
 	"miniflux.app/version"
+	"miniflux.app/websub"
 	"miniflux.app/worker"
 
 	"github.com/gorilla/mux"
@@ -185,6 +187,13 @@ func setupHandler(store *storage.Storage, pool *worker.Pool) *mux.Router {
 	fever.Serve(router, store)
 	googlereader.Serve(router, store)
 	api.Serve(router, store, pool)
+
+	if config.Opts.HasWebSub() {
+		websub.Serve(router, store, func(job model.Job) {
+			pool.Push(model.JobList{job}, worker.PriorityInteractive)
+		})
+	}
+
 	ui.Serve(router, store, pool)
 
 	router.HandleFunc("/healthcheck", func(w http.ResponseWriter, r *http.Request) {
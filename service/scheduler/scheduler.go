@@ -12,6 +12,7 @@ import (
 	"miniflux.app/metric"
 	"miniflux.app/model"
 	"miniflux.app/storage"
+	"miniflux.app/websub"
 	"miniflux.app/worker"
 )
 
@@ -33,47 +34,126 @@ func Serve(store *storage.Storage, pool *worker.Pool) {
 		config.Opts.CleanupArchiveUnreadDays(),
 		config.Opts.CleanupArchiveBatchSize(),
 		config.Opts.CleanupRemoveSessionsDays(),
+		config.Opts.CleanupRefreshHistoryDays(),
+		config.Opts.CleanupRefreshHistoryPerFeed(),
+		config.Opts.CleanupPurgeRemovedDays(),
 	)
+
+	if config.Opts.HasWebSub() {
+		go webSubScheduler(store, config.Opts.PollingFrequency())
+	}
 }
 
 func feedScheduler(store *storage.Storage, pool *worker.Pool, frequency, batchSize int) {
+	// The feeds claimed by an instance that didn't refresh them during the next
+	// polling interval are released for the other instances.
+	claimTimeout := time.Duration(frequency) * time.Minute
+
 	for range time.Tick(time.Duration(frequency) * time.Minute) {
-		jobs, err := store.NewBatch(batchSize)
+		jobs, err := store.NewBatch(batchSize, config.Opts.NodeName(), claimTimeout)
 		if err != nil {
 			logger.Error("[Scheduler:Feed] %v", err)
 		} else {
 			logger.Debug("[Scheduler:Feed] Pushing %d jobs", len(jobs))
-			pool.Push(jobs)
+			pool.Push(jobs, worker.PriorityBackground)
 		}
 	}
 }
 
-func cleanupScheduler(store *storage.Storage, frequency, archiveReadDays, archiveUnreadDays, archiveBatchSize, sessionsDays int) {
-	for range time.Tick(time.Duration(frequency) * time.Hour) {
+func webSubScheduler(store *storage.Storage, frequency int) {
+	interval := time.Duration(frequency) * time.Minute
+	for range time.Tick(interval) {
+		if isLeader(store, "websub", interval) {
+			websub.RenewSubscriptions(store)
+		}
+	}
+}
+
+func cleanupScheduler(store *storage.Storage, frequency, archiveReadDays, archiveUnreadDays, archiveBatchSize, sessionsDays, refreshHistoryDays, refreshHistoryPerFeed, purgeRemovedDays int) {
+	interval := time.Duration(frequency) * time.Hour
+	for range time.Tick(interval) {
+		if !isLeader(store, "cleanup", interval) {
+			continue
+		}
+
 		nbSessions := store.CleanOldSessions(sessionsDays)
 		nbUserSessions := store.CleanOldUserSessions(sessionsDays)
 		logger.Info("[Scheduler:Cleanup] Cleaned %d sessions and %d user sessions", nbSessions, nbUserSessions)
 
-		startTime := time.Now()
-		if rowsAffected, err := store.ArchiveEntries(model.EntryStatusRead, archiveReadDays, archiveBatchSize); err != nil {
-			logger.Error("[Scheduler:ArchiveReadEntries] %v", err)
+		if nbRefreshes, err := store.CleanOldFeedRefreshes(refreshHistoryDays, refreshHistoryPerFeed); err != nil {
+			logger.Error("[Scheduler:Cleanup] %v", err)
 		} else {
-			logger.Info("[Scheduler:ArchiveReadEntries] %d entries changed", rowsAffected)
-
-			if config.Opts.HasMetricsCollector() {
-				metric.ArchiveEntriesDuration.WithLabelValues(model.EntryStatusRead).Observe(time.Since(startTime).Seconds())
-			}
+			logger.Info("[Scheduler:Cleanup] Cleaned %d feed refreshes", nbRefreshes)
 		}
 
-		startTime = time.Now()
-		if rowsAffected, err := store.ArchiveEntries(model.EntryStatusUnread, archiveUnreadDays, archiveBatchSize); err != nil {
-			logger.Error("[Scheduler:ArchiveUnreadEntries] %v", err)
+		archiveEntries("ArchiveReadEntries", model.EntryStatusRead, archiveBatchSize, func() (int64, error) {
+			return store.ArchiveEntries(model.EntryStatusRead, archiveReadDays, archiveBatchSize)
+		})
+
+		archiveEntries("ArchiveUnreadEntries", model.EntryStatusUnread, archiveBatchSize, func() (int64, error) {
+			return store.ArchiveEntries(model.EntryStatusUnread, archiveUnreadDays, archiveBatchSize)
+		})
+
+		archiveEntries("ArchiveExcessEntries", "excess", archiveBatchSize, func() (int64, error) {
+			return store.ArchiveExcessEntries(archiveBatchSize)
+		})
+
+		if rowsAffected, err := store.PurgeRemovedEntries(purgeRemovedDays, archiveBatchSize); err != nil {
+			logger.Error("[Scheduler:PurgeRemovedEntries] %v", err)
 		} else {
-			logger.Info("[Scheduler:ArchiveUnreadEntries] %d entries changed", rowsAffected)
+			logger.Info("[Scheduler:PurgeRemovedEntries] %d entries purged", rowsAffected)
 
 			if config.Opts.HasMetricsCollector() {
-				metric.ArchiveEntriesDuration.WithLabelValues(model.EntryStatusUnread).Observe(time.Since(startTime).Seconds())
+				metric.PurgedEntries.Add(float64(rowsAffected))
 			}
 		}
 	}
 }
+
+// archiveEntries runs the archive function until a batch is not full.
+//
+// Each batch is a separate statement, the rows are not locked for the whole cleanup.
+func archiveEntries(task, metricLabel string, batchSize int, archive func() (int64, error)) {
+	if batchSize <= 0 {
+		return
+	}
+
+	startTime := time.Now()
+	var total int64
+	for {
+		rowsAffected, err := archive()
+		if err != nil {
+			logger.Error("[Scheduler:%s] %v", task, err)
+			return
+		}
+
+		total += rowsAffected
+		if rowsAffected < int64(batchSize) {
+			break
+		}
+	}
+
+	logger.Info("[Scheduler:%s] %d entries changed", task, total)
+
+	if config.Opts.HasMetricsCollector() {
+		metric.ArchiveEntriesDuration.WithLabelValues(metricLabel).Observe(time.Since(startTime).Seconds())
+	}
+}
+
+// isLeader returns true when this instance is the only one allowed to run the named task.
+//
+// The lease lasts two intervals, so the leader keeps it as long as it runs and
+// another instance takes over when the leader is gone.
+func isLeader(store *storage.Storage, task string, interval time.Duration) bool {
+	leader, err := store.AcquireSchedulerLease(task, config.Opts.NodeName(), 2*interval)
+	if err != nil {
+		logger.Error("[Scheduler] %v", err)
+		return false
+	}
+
+	if !leader {
+		logger.Debug("[Scheduler] Another instance is running the %s task", task)
+	}
+
+	return leader
+}
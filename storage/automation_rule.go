@@ -0,0 +1,158 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package storage // import "miniflux.app/storage"
+
+import (
+	"database/sql"
+	"fmt"
+
+	"miniflux.app/model"
+)
+
+// AutomationRuleExists checks if an automation rule with the same name exists.
+func (s *Storage) AutomationRuleExists(userID, ruleID int64, name string) bool {
+	var result bool
+	query := `SELECT true FROM automation_rules WHERE user_id=$1 AND id != $2 AND lower(name)=lower($3) LIMIT 1`
+	s.db.QueryRow(query, userID, ruleID, name).Scan(&result)
+	return result
+}
+
+// AutomationRules returns all automation rules that belongs to the given user.
+func (s *Storage) AutomationRules(userID int64) (model.AutomationRules, error) {
+	query := `
+		SELECT
+			id, user_id, name, rules, action, value, disabled, created_at
+		FROM
+			automation_rules
+		WHERE
+			user_id=$1
+		ORDER BY name ASC
+	`
+	rows, err := s.db.Query(query, userID)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch automation rules: %v`, err)
+	}
+	defer rows.Close()
+
+	rules := make(model.AutomationRules, 0)
+	for rows.Next() {
+		var rule model.AutomationRule
+		if err := rows.Scan(
+			&rule.ID,
+			&rule.UserID,
+			&rule.Name,
+			&rule.Rules,
+			&rule.Action,
+			&rule.Value,
+			&rule.Disabled,
+			&rule.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch automation rule row: %v`, err)
+		}
+
+		rules = append(rules, &rule)
+	}
+
+	return rules, nil
+}
+
+// AutomationRule returns an automation rule by ID.
+func (s *Storage) AutomationRule(userID, ruleID int64) (*model.AutomationRule, error) {
+	query := `
+		SELECT
+			id, user_id, name, rules, action, value, disabled, created_at
+		FROM
+			automation_rules
+		WHERE
+			user_id=$1 AND id=$2
+	`
+	var rule model.AutomationRule
+	err := s.db.QueryRow(query, userID, ruleID).Scan(
+		&rule.ID,
+		&rule.UserID,
+		&rule.Name,
+		&rule.Rules,
+		&rule.Action,
+		&rule.Value,
+		&rule.Disabled,
+		&rule.CreatedAt,
+	)
+
+	switch {
+	case err == sql.ErrNoRows:
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf(`store: unable to fetch automation rule #%d: %v`, ruleID, err)
+	}
+
+	return &rule, nil
+}
+
+// CreateAutomationRule inserts a new automation rule.
+func (s *Storage) CreateAutomationRule(rule *model.AutomationRule) error {
+	query := `
+		INSERT INTO automation_rules
+			(user_id, name, rules, action, value, disabled)
+		VALUES
+			($1, $2, $3, $4, $5, $6)
+		RETURNING
+			id, created_at
+	`
+	err := s.db.QueryRow(
+		query,
+		rule.UserID,
+		rule.Name,
+		rule.Rules,
+		rule.Action,
+		rule.Value,
+		rule.Disabled,
+	).Scan(
+		&rule.ID,
+		&rule.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf(`store: unable to create automation rule %q: %v`, rule.Name, err)
+	}
+
+	return nil
+}
+
+// UpdateAutomationRule updates an existing automation rule.
+func (s *Storage) UpdateAutomationRule(rule *model.AutomationRule) error {
+	query := `
+		UPDATE
+			automation_rules
+		SET
+			name=$1, rules=$2, action=$3, value=$4, disabled=$5
+		WHERE
+			id=$6 AND user_id=$7
+	`
+	_, err := s.db.Exec(
+		query,
+		rule.Name,
+		rule.Rules,
+		rule.Action,
+		rule.Value,
+		rule.Disabled,
+		rule.ID,
+		rule.UserID,
+	)
+	if err != nil {
+		return fmt.Errorf(`store: unable to update automation rule #%d: %v`, rule.ID, err)
+	}
+
+	return nil
+}
+
+// RemoveAutomationRule deletes an automation rule.
+func (s *Storage) RemoveAutomationRule(userID, ruleID int64) error {
+	query := `DELETE FROM automation_rules WHERE id = $1 AND user_id = $2`
+	_, err := s.db.Exec(query, ruleID, userID)
+	if err != nil {
+		return fmt.Errorf(`store: unable to remove automation rule #%d: %v`, ruleID, err)
+	}
+
+	return nil
+}
@@ -41,8 +41,8 @@ func (s *Storage) CategoryIDExists(userID, categoryID int64) bool {
 func (s *Storage) Category(userID, categoryID int64) (*model.Category, error) {
 	var category model.Category
 
-	query := `SELECT id, user_id, title, hide_globally FROM categories WHERE user_id=$1 AND id=$2`
-	err := s.db.QueryRow(query, userID, categoryID).Scan(&category.ID, &category.UserID, &category.Title, &category.HideGlobally)
+	query := `SELECT id, user_id, title, hide_globally, proxy_name, retention_read_days, retention_unread_days, retention_max_entries FROM categories WHERE user_id=$1 AND id=$2`
+	err := s.db.QueryRow(query, userID, categoryID).Scan(&category.ID, &category.UserID, &category.Title, &category.HideGlobally, &category.ProxyName, &category.RetentionReadDays, &category.RetentionUnreadDays, &category.RetentionMaxEntries)
 
 	switch {
 	case err == sql.ErrNoRows:
@@ -56,10 +56,10 @@ func (s *Storage) Category(userID, categoryID int64) (*model.Category, error) {
 
 // FirstCategory returns the first category for the given user.
 func (s *Storage) FirstCategory(userID int64) (*model.Category, error) {
-	query := `SELECT id, user_id, title, hide_globally FROM categories WHERE user_id=$1 ORDER BY title ASC LIMIT 1`
+	query := `SELECT id, user_id, title, hide_globally, proxy_name, retention_read_days, retention_unread_days, retention_max_entries FROM categories WHERE user_id=$1 ORDER BY title ASC LIMIT 1`
 
 	var category model.Category
-	err := s.db.QueryRow(query, userID).Scan(&category.ID, &category.UserID, &category.Title, &category.HideGlobally)
+	err := s.db.QueryRow(query, userID).Scan(&category.ID, &category.UserID, &category.Title, &category.HideGlobally, &category.ProxyName, &category.RetentionReadDays, &category.RetentionUnreadDays, &category.RetentionMaxEntries)
 
 	switch {
 	case err == sql.ErrNoRows:
@@ -75,8 +75,8 @@ func (s *Storage) FirstCategory(userID int64) (*model.Category, error) {
 func (s *Storage) CategoryByTitle(userID int64, title string) (*model.Category, error) {
 	var category model.Category
 
-	query := `SELECT id, user_id, title, hide_globally FROM categories WHERE user_id=$1 AND title=$2`
-	err := s.db.QueryRow(query, userID, title).Scan(&category.ID, &category.UserID, &category.Title, &category.HideGlobally)
+	query := `SELECT id, user_id, title, hide_globally, proxy_name, retention_read_days, retention_unread_days, retention_max_entries FROM categories WHERE user_id=$1 AND title=$2`
+	err := s.db.QueryRow(query, userID, title).Scan(&category.ID, &category.UserID, &category.Title, &category.HideGlobally, &category.ProxyName, &category.RetentionReadDays, &category.RetentionUnreadDays, &category.RetentionMaxEntries)
 
 	switch {
 	case err == sql.ErrNoRows:
@@ -90,7 +90,7 @@ func (s *Storage) CategoryByTitle(userID int64, title string) (*model.Category,
 
 // Categories returns all categories that belongs to the given user.
 func (s *Storage) Categories(userID int64) (model.Categories, error) {
-	query := `SELECT id, user_id, title, hide_globally FROM categories WHERE user_id=$1 ORDER BY title ASC`
+	query := `SELECT id, user_id, title, hide_globally, proxy_name, retention_read_days, retention_unread_days, retention_max_entries FROM categories WHERE user_id=$1 ORDER BY title ASC`
 	rows, err := s.db.Query(query, userID)
 	if err != nil {
 		return nil, fmt.Errorf(`store: unable to fetch categories: %v`, err)
@@ -100,7 +100,7 @@ func (s *Storage) Categories(userID int64) (model.Categories, error) {
 	categories := make(model.Categories, 0)
 	for rows.Next() {
 		var category model.Category
-		if err := rows.Scan(&category.ID, &category.UserID, &category.Title, &category.HideGlobally); err != nil {
+		if err := rows.Scan(&category.ID, &category.UserID, &category.Title, &category.HideGlobally, &category.ProxyName, &category.RetentionReadDays, &category.RetentionUnreadDays, &category.RetentionMaxEntries); err != nil {
 			return nil, fmt.Errorf(`store: unable to fetch category row: %v`, err)
 		}
 
@@ -123,6 +123,10 @@ func (s *Storage) CategoriesWithFeedCount(userID int64) (model.Categories, error
 			c.user_id,
 			c.title,
 			c.hide_globally,
+			c.proxy_name,
+			c.retention_read_days,
+			c.retention_unread_days,
+			c.retention_max_entries,
 			(SELECT count(*) FROM feeds WHERE feeds.category_id=c.id) AS count,
 			(SELECT count(*)
 			   FROM feeds
@@ -155,7 +159,7 @@ func (s *Storage) CategoriesWithFeedCount(userID int64) (model.Categories, error
 	categories := make(model.Categories, 0)
 	for rows.Next() {
 		var category model.Category
-		if err := rows.Scan(&category.ID, &category.UserID, &category.Title, &category.HideGlobally, &category.FeedCount, &category.TotalUnread); err != nil {
+		if err := rows.Scan(&category.ID, &category.UserID, &category.Title, &category.HideGlobally, &category.ProxyName, &category.RetentionReadDays, &category.RetentionUnreadDays, &category.RetentionMaxEntries, &category.FeedCount, &category.TotalUnread); err != nil {
 			return nil, fmt.Errorf(`store: unable to fetch category row: %v`, err)
 		}
 
@@ -171,22 +175,34 @@ func (s *Storage) CreateCategory(userID int64, request *model.CategoryRequest) (
 
 	query := `
 		INSERT INTO categories
-			(user_id, title)
+			(user_id, title, proxy_name, retention_read_days, retention_unread_days, retention_max_entries)
 		VALUES
-			($1, $2)
+			($1, $2, $3, $4, $5, $6)
 		RETURNING
 			id,
 			user_id,
-			title
+			title,
+			proxy_name,
+			retention_read_days,
+			retention_unread_days,
+			retention_max_entries
 	`
 	err := s.db.QueryRow(
 		query,
 		userID,
 		request.Title,
+		request.ProxyName,
+		request.RetentionReadDays,
+		request.RetentionUnreadDays,
+		request.RetentionMaxEntries,
 	).Scan(
 		&category.ID,
 		&category.UserID,
 		&category.Title,
+		&category.ProxyName,
+		&category.RetentionReadDays,
+		&category.RetentionUnreadDays,
+		&category.RetentionMaxEntries,
 	)
 
 	if err != nil {
@@ -198,11 +214,27 @@ func (s *Storage) CreateCategory(userID int64, request *model.CategoryRequest) (
 
 // UpdateCategory updates an existing category.
 func (s *Storage) UpdateCategory(category *model.Category) error {
-	query := `UPDATE categories SET title=$1, hide_globally = $2 WHERE id=$3 AND user_id=$4`
+	query := `
+		UPDATE
+			categories
+		SET
+			title=$1,
+			hide_globally=$2,
+			proxy_name=$3,
+			retention_read_days=$4,
+			retention_unread_days=$5,
+			retention_max_entries=$6
+		WHERE
+			id=$7 AND user_id=$8
+	`
 	_, err := s.db.Exec(
 		query,
 		category.Title,
 		category.HideGlobally,
+		category.ProxyName,
+		category.RetentionReadDays,
+		category.RetentionUnreadDays,
+		category.RetentionMaxEntries,
 		category.ID,
 		category.UserID,
 	)
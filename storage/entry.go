@@ -10,9 +10,11 @@ import (
 	"fmt"
 	"time"
 
+	"miniflux.app/config"
 	"miniflux.app/crypto"
 	"miniflux.app/logger"
 	"miniflux.app/model"
+	"miniflux.app/url"
 
 	"github.com/lib/pq"
 )
@@ -76,11 +78,11 @@ func (s *Storage) UpdateEntryContent(entry *model.Entry) error {
 		UPDATE
 			entries
 		SET
-			content=$1, reading_time=$2
+			content=$1, reading_time=$2, language=$3
 		WHERE
-			id=$3 AND user_id=$4
+			id=$4 AND user_id=$5
 	`
-	_, err = tx.Exec(query, entry.Content, entry.ReadingTime, entry.ID, entry.UserID)
+	_, err = tx.Exec(query, entry.Content, entry.ReadingTime, entry.Language, entry.ID, entry.UserID)
 	if err != nil {
 		tx.Rollback()
 		return fmt.Errorf(`store: unable to update content of entry #%d: %v`, entry.ID, err)
@@ -90,7 +92,7 @@ func (s *Storage) UpdateEntryContent(entry *model.Entry) error {
 		UPDATE
 			entries
 		SET
-			document_vectors = setweight(to_tsvector(left(coalesce(title, ''), 500000)), 'A') || setweight(to_tsvector(left(coalesce(content, ''), 500000)), 'B')
+			document_vectors = ` + documentVectors("title", "content", "language") + `
 		WHERE
 			id=$1 AND user_id=$2
 	`
@@ -120,7 +122,13 @@ func (s *Storage) createEntry(tx *sql.Tx, entry *model.Entry) error {
 				reading_time,
 				changed_at,
 				document_vectors,
-				tags
+				tags,
+				status,
+				starred,
+				read_later,
+				normalized_url,
+				duplicate_group_id,
+				language
 			)
 		VALUES
 			(
@@ -135,12 +143,22 @@ func (s *Storage) createEntry(tx *sql.Tx, entry *model.Entry) error {
 				$9,
 				$10,
 				now(),
-				setweight(to_tsvector(left(coalesce($1, ''), 500000)), 'A') || setweight(to_tsvector(left(coalesce($6, ''), 500000)), 'B'),
-				$11
+				` + documentVectors("$1", "$6", "$17") + `,
+				$11,
+				$12,
+				$13,
+				$14,
+				$15,
+				NULLIF($16, 0),
+				$17
 			)
 		RETURNING
 			id, status
 	`
+	if entry.Status == "" {
+		entry.Status = model.EntryStatusUnread
+	}
+
 	err := tx.QueryRow(
 		query,
 		entry.Title,
@@ -154,12 +172,26 @@ func (s *Storage) createEntry(tx *sql.Tx, entry *model.Entry) error {
 		entry.FeedID,
 		entry.ReadingTime,
 		pq.Array(removeDuplicates(entry.Tags)),
+		entry.Status,
+		entry.Starred,
+		entry.ReadLater,
+		url.Normalize(entry.URL),
+		entry.DuplicateGroupID,
+		entry.Language,
 	).Scan(&entry.ID, &entry.Status)
 
 	if err != nil {
 		return fmt.Errorf(`store: unable to create entry %q (feed #%d): %v`, entry.URL, entry.FeedID, err)
 	}
 
+	if entry.DuplicateGroupID != 0 {
+		// The first copy becomes a member of the group as well.
+		_, err := tx.Exec(`UPDATE entries SET duplicate_group_id=$1 WHERE id=$1 AND duplicate_group_id IS NULL`, entry.DuplicateGroupID)
+		if err != nil {
+			return fmt.Errorf(`store: unable to update duplicate group #%d: %v`, entry.DuplicateGroupID, err)
+		}
+	}
+
 	for i := 0; i < len(entry.Enclosures); i++ {
 		entry.Enclosures[i].EntryID = entry.ID
 		entry.Enclosures[i].UserID = entry.UserID
@@ -172,13 +204,13 @@ func (s *Storage) createEntry(tx *sql.Tx, entry *model.Entry) error {
 	return nil
 }
 
-// updateEntry updates an entry when a feed is refreshed.
+// updateEntry updates an entry when a feed is refreshed and returns true if its content has changed.
 // Note: we do not update the published date because some feeds do not contains any date,
 // it default to time.Now() which could change the order of items on the history page.
-func (s *Storage) updateEntry(tx *sql.Tx, entry *model.Entry) error {
+func (s *Storage) updateEntry(tx *sql.Tx, entry *model.Entry) (bool, error) {
 	query := `
 		UPDATE
-			entries
+			entries e
 		SET
 			title=$1,
 			url=$2,
@@ -186,13 +218,24 @@ func (s *Storage) updateEntry(tx *sql.Tx, entry *model.Entry) error {
 			content=$4,
 			author=$5,
 			reading_time=$6,
-			document_vectors = setweight(to_tsvector(left(coalesce($1, ''), 500000)), 'A') || setweight(to_tsvector(left(coalesce($4, ''), 500000)), 'B'),
-			tags=$10
+			document_vectors = ` + documentVectors("$1", "$4", "$11") + `,
+			tags=$10,
+			language=$11
+		FROM
+			entries previous
 		WHERE
-			user_id=$7 AND feed_id=$8 AND hash=$9
+			previous.id=e.id AND e.user_id=$7 AND e.feed_id=$8 AND e.hash=$9
 		RETURNING
-			id
+			e.id,
+			(previous.title, previous.url, previous.comments_url, previous.content, previous.author, previous.tags) IS DISTINCT FROM
+			(e.title, e.url, e.comments_url, e.content, e.author, e.tags),
+			(previous.title, previous.content) IS DISTINCT FROM (e.title, e.content),
+			previous.title,
+			previous.url,
+			CASE WHEN (previous.title, previous.content) IS DISTINCT FROM (e.title, e.content) THEN previous.content ELSE '' END
 	`
+	var changed, revised bool
+	var revision model.EntryRevision
 	err := tx.QueryRow(
 		query,
 		entry.Title,
@@ -205,10 +248,19 @@ func (s *Storage) updateEntry(tx *sql.Tx, entry *model.Entry) error {
 		entry.FeedID,
 		entry.Hash,
 		pq.Array(removeDuplicates(entry.Tags)),
-	).Scan(&entry.ID)
+		entry.Language,
+	).Scan(&entry.ID, &changed, &revised, &revision.Title, &revision.URL, &revision.Content)
 
 	if err != nil {
-		return fmt.Errorf(`store: unable to update entry %q: %v`, entry.URL, err)
+		return false, fmt.Errorf(`store: unable to update entry %q: %v`, entry.URL, err)
+	}
+
+	if revised {
+		revision.UserID = entry.UserID
+		revision.EntryID = entry.ID
+		if err := s.createEntryRevision(tx, &revision, config.Opts.EntryRevisionsLimit()); err != nil {
+			return false, err
+		}
 	}
 
 	for _, enclosure := range entry.Enclosures {
@@ -216,7 +268,7 @@ func (s *Storage) updateEntry(tx *sql.Tx, entry *model.Entry) error {
 		enclosure.EntryID = entry.ID
 	}
 
-	return s.updateEnclosures(tx, entry.UserID, entry.ID, entry.Enclosures)
+	return changed, s.updateEnclosures(tx, entry.UserID, entry.ID, entry.Enclosures)
 }
 
 // entryExists checks if an entry already exists based on its hash when refreshing a feed.
@@ -263,63 +315,166 @@ func (s *Storage) cleanupEntries(feedID int64, entryHashes []string) error {
 }
 
 // RefreshFeedEntries updates feed entries while refreshing a feed.
-func (s *Storage) RefreshFeedEntries(userID, feedID int64, entries model.Entries, updateExistingEntries bool) (err error) {
+// It returns the number of new entries and the number of existing entries with a different content.
+func (s *Storage) RefreshFeedEntries(userID, feedID int64, entries model.Entries, updateExistingEntries bool) (newEntries, updatedEntries int, err error) {
+	newEntries, updatedEntries, err = s.AddFeedEntries(userID, feedID, entries, updateExistingEntries)
+	if err != nil {
+		return newEntries, updatedEntries, err
+	}
+
 	var entryHashes []string
+	for _, entry := range entries {
+		entryHashes = append(entryHashes, entry.Hash)
+	}
+
+	go func() {
+		if err := s.cleanupEntries(feedID, entryHashes); err != nil {
+			logger.Error(`store: feed #%d: %v`, feedID, err)
+		}
+	}()
+
+	return newEntries, updatedEntries, nil
+}
 
+// AddFeedEntries creates or updates the given entries without removing the entries missing from the list.
+// It's intended to be used with partial documents, like WebSub notifications.
+func (s *Storage) AddFeedEntries(userID, feedID int64, entries model.Entries, updateExistingEntries bool) (newEntries, updatedEntries int, err error) {
 	for _, entry := range entries {
 		entry.UserID = userID
 		entry.FeedID = feedID
 
 		tx, err := s.db.Begin()
 		if err != nil {
-			return fmt.Errorf(`store: unable to start transaction: %v`, err)
+			return newEntries, updatedEntries, fmt.Errorf(`store: unable to start transaction: %v`, err)
 		}
 
+		created, changed := false, false
 		if s.entryExists(tx, entry) {
 			if updateExistingEntries {
-				err = s.updateEntry(tx, entry)
+				changed, err = s.updateEntry(tx, entry)
 			}
-		} else {
+		} else if !s.entryTombstoneExists(tx, entry) {
 			err = s.createEntry(tx, entry)
+			created = err == nil
 		}
 
 		if err != nil {
 			tx.Rollback()
-			return err
+			return newEntries, updatedEntries, err
 		}
 
 		if err := tx.Commit(); err != nil {
-			return fmt.Errorf(`store: unable to commit transaction: %v`, err)
+			return newEntries, updatedEntries, fmt.Errorf(`store: unable to commit transaction: %v`, err)
 		}
 
-		entryHashes = append(entryHashes, entry.Hash)
+		if created {
+			newEntries++
+		}
+		if changed {
+			updatedEntries++
+		}
 	}
 
-	go func() {
-		if err := s.cleanupEntries(feedID, entryHashes); err != nil {
-			logger.Error(`store: feed #%d: %v`, feedID, err)
+	return newEntries, updatedEntries, nil
+}
+
+// RekeyFeedEntries replaces the hash of the feed entries, the map keys are the current hashes.
+//
+// An entry keeps its hash when another entry of the feed already uses the new one.
+// The tombstones of the purged entries are re-keyed as well.
+func (s *Storage) RekeyFeedEntries(userID, feedID int64, hashes map[string]string) (rekeyedEntries int, err error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf(`store: unable to start transaction: %v`, err)
+	}
+
+	query := `
+		UPDATE
+			entries
+		SET
+			hash=$1
+		WHERE
+			user_id=$2 AND feed_id=$3 AND hash=$4 AND
+			NOT EXISTS (SELECT 1 FROM entries WHERE feed_id=$3 AND hash=$1)
+	`
+	tombstoneQuery := `
+		UPDATE
+			entry_tombstones
+		SET
+			hash=$1
+		WHERE
+			feed_id=$2 AND hash=$3 AND
+			NOT EXISTS (SELECT 1 FROM entry_tombstones WHERE feed_id=$2 AND hash=$1)
+	`
+	for oldHash, newHash := range hashes {
+		if oldHash == newHash {
+			continue
 		}
-	}()
 
-	return nil
+		result, err := tx.Exec(query, newHash, userID, feedID, oldHash)
+		if err != nil {
+			tx.Rollback()
+			return 0, fmt.Errorf(`store: unable to rekey entries of feed #%d: %v`, feedID, err)
+		}
+
+		count, _ := result.RowsAffected()
+		rekeyedEntries += int(count)
+
+		if _, err := tx.Exec(tombstoneQuery, newHash, feedID, oldHash); err != nil {
+			tx.Rollback()
+			return 0, fmt.Errorf(`store: unable to rekey entry tombstones of feed #%d: %v`, feedID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf(`store: unable to commit transaction: %v`, err)
+	}
+
+	return rekeyedEntries, nil
 }
 
 // ArchiveEntries changes the status of entries to "removed" after the given number of days.
+//
+// The retention settings of the feeds and their categories take precedence over the given number of days,
+// a negative number of days disables the cleanup of the other feeds.
 func (s *Storage) ArchiveEntries(status string, days, limit int) (int64, error) {
-	if days < 0 || limit <= 0 {
+	if limit <= 0 {
 		return 0, nil
 	}
 
+	column := "retention_read_days"
+	if status == model.EntryStatusUnread {
+		column = "retention_unread_days"
+	}
+
 	query := `
 		UPDATE
 			entries
 		SET
-			status='removed'
+			status='removed',
+			changed_at=now()
 		WHERE
-			id=ANY(SELECT id FROM entries WHERE status=$1 AND starred is false AND share_code='' AND created_at < now () - '%d days'::interval ORDER BY created_at ASC LIMIT %d)
+			id=ANY(
+				SELECT
+					e.id
+				FROM
+					entries e
+				JOIN
+					feeds f ON f.id=e.feed_id
+				JOIN
+					categories c ON c.id=f.category_id
+				CROSS JOIN LATERAL
+					(SELECT coalesce(nullif(f.%[1]s, 0), nullif(c.%[1]s, 0), $2) AS days) retention
+				WHERE
+					e.status=$1 AND e.starred is false AND e.share_code='' AND
+					retention.days >= 0 AND e.created_at < now() - make_interval(days => retention.days)
+				ORDER BY
+					e.created_at ASC
+				LIMIT $3
+			)
 	`
 
-	result, err := s.db.Exec(fmt.Sprintf(query, days, limit), status)
+	result, err := s.db.Exec(fmt.Sprintf(query, column), status, days, limit)
 	if err != nil {
 		return 0, fmt.Errorf(`store: unable to archive %s entries: %v`, status, err)
 	}
@@ -332,6 +487,57 @@ func (s *Storage) ArchiveEntries(status string, days, limit int) (int64, error)
 	return count, nil
 }
 
+// ArchiveExcessEntries changes the status of entries to "removed" when their feed has more entries than allowed
+// by its retention settings, or the ones of its category. The most recent entries are kept.
+func (s *Storage) ArchiveExcessEntries(limit int) (int64, error) {
+	if limit <= 0 {
+		return 0, nil
+	}
+
+	query := `
+		UPDATE
+			entries
+		SET
+			status='removed',
+			changed_at=now()
+		WHERE
+			id=ANY(
+				SELECT
+					ranked.id
+				FROM (
+					SELECT
+						e.id,
+						row_number() OVER (PARTITION BY e.feed_id ORDER BY e.published_at DESC, e.id DESC) AS position,
+						coalesce(nullif(f.retention_max_entries, 0), c.retention_max_entries) AS max_entries
+					FROM
+						entries e
+					JOIN
+						feeds f ON f.id=e.feed_id
+					JOIN
+						categories c ON c.id=f.category_id
+					WHERE
+						e.status <> 'removed' AND e.starred is false AND e.share_code='' AND
+						coalesce(nullif(f.retention_max_entries, 0), c.retention_max_entries) > 0
+				) ranked
+				WHERE
+					ranked.position > ranked.max_entries
+				LIMIT $1
+			)
+	`
+
+	result, err := s.db.Exec(query, limit)
+	if err != nil {
+		return 0, fmt.Errorf(`store: unable to archive excess entries: %v`, err)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf(`store: unable to get the number of rows affected: %v`, err)
+	}
+
+	return count, nil
+}
+
 // SetEntriesStatus update the status of the given list of entries.
 func (s *Storage) SetEntriesStatus(userID int64, entryIDs []int64, status string) error {
 	query := `UPDATE entries SET status=$1, changed_at=now() WHERE user_id=$2 AND id=ANY($3)`
@@ -416,6 +622,26 @@ func (s *Storage) ToggleBookmark(userID int64, entryID int64) error {
 	return nil
 }
 
+// ToggleReadLater toggles entry read later value.
+func (s *Storage) ToggleReadLater(userID int64, entryID int64) error {
+	query := `UPDATE entries SET read_later = NOT read_later, changed_at=now() WHERE user_id=$1 AND id=$2`
+	result, err := s.db.Exec(query, userID, entryID)
+	if err != nil {
+		return fmt.Errorf(`store: unable to toggle read later flag for entry #%d: %v`, entryID, err)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf(`store: unable to toggle read later flag for entry #%d: %v`, entryID, err)
+	}
+
+	if count == 0 {
+		return errors.New(`store: nothing has been updated`)
+	}
+
+	return nil
+}
+
 // FlushHistory set all entries with the status "read" to "removed".
 func (s *Storage) FlushHistory(userID int64) error {
 	query := `
@@ -526,14 +752,64 @@ func (s *Storage) MarkCategoryAsRead(userID, categoryID int64, before time.Time)
 	return nil
 }
 
-// EntryURLExists returns true if an entry with this URL already exists.
-func (s *Storage) EntryURLExists(feedID int64, entryURL string) bool {
+// EntryExists returns true if the feed already has an entry with this hash.
+//
+// The entries are identified by their hash when they are stored, the processor must decide which entries are new the same way.
+func (s *Storage) EntryExists(feedID int64, hash string) bool {
 	var result bool
-	query := `SELECT true FROM entries WHERE feed_id=$1 AND url=$2`
-	s.db.QueryRow(query, feedID, entryURL).Scan(&result)
+	query := `SELECT true FROM entries WHERE feed_id=$1 AND hash=$2`
+	s.db.QueryRow(query, feedID, hash).Scan(&result)
 	return result
 }
 
+// DuplicateEntryGroup returns the duplicate group of the entry with the same normalized URL in another feed of the user.
+//
+// The ID of the first copy is returned when it has no group yet, 0 is returned when there is no copy.
+func (s *Storage) DuplicateEntryGroup(userID, feedID int64, entryURL string) int64 {
+	var groupID int64
+	query := `
+		SELECT
+			coalesce(duplicate_group_id, id)
+		FROM
+			entries
+		WHERE
+			user_id=$1 AND feed_id <> $2 AND normalized_url=$3
+		ORDER BY id ASC
+		LIMIT 1
+	`
+	s.db.QueryRow(query, userID, feedID, url.Normalize(entryURL)).Scan(&groupID)
+	return groupID
+}
+
+// RecentEntriesFromOtherFeeds returns the ID, the duplicate group and the title of the entries created since the given date in the other feeds of the user.
+func (s *Storage) RecentEntriesFromOtherFeeds(userID, feedID int64, since time.Time) (model.Entries, error) {
+	query := `
+		SELECT
+			id, coalesce(duplicate_group_id, 0), title
+		FROM
+			entries
+		WHERE
+			user_id=$1 AND feed_id <> $2 AND created_at >= $3
+		ORDER BY id ASC
+	`
+	rows, err := s.db.Query(query, userID, feedID, since)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch recent entries: %v`, err)
+	}
+	defer rows.Close()
+
+	entries := make(model.Entries, 0)
+	for rows.Next() {
+		var entry model.Entry
+		if err := rows.Scan(&entry.ID, &entry.DuplicateGroupID, &entry.Title); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch recent entry row: %v`, err)
+		}
+		entries = append(entries, &entry)
+	}
+
+	return entries, nil
+}
+
 // EntryShareCode returns the share code of the provided entry.
 // It generates a new one if not already defined.
 func (s *Storage) EntryShareCode(userID int64, entryID int64) (shareCode string, err error) {
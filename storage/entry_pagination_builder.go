@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"miniflux.app/model"
+	"miniflux.app/search"
 	"miniflux.app/timer"
 )
 
@@ -24,11 +25,10 @@ type EntryPaginationBuilder struct {
 	direction  string
 }
 
-// WithSearchQuery adds full-text search query to the condition.
+// WithSearchQuery adds the conditions of a search query, see the search package for the syntax.
 func (e *EntryPaginationBuilder) WithSearchQuery(query string) {
 	if query != "" {
-		e.conditions = append(e.conditions, fmt.Sprintf("e.document_vectors @@ plainto_tsquery($%d)", len(e.args)+1))
-		e.args = append(e.args, query)
+		e.conditions, e.args, _ = searchConditions(search.Parse(query), e.conditions, e.args)
 	}
 }
 
@@ -37,6 +37,11 @@ func (e *EntryPaginationBuilder) WithStarred() {
 	e.conditions = append(e.conditions, "e.starred is true")
 }
 
+// WithReadLater adds read_later to the condition.
+func (e *EntryPaginationBuilder) WithReadLater() {
+	e.conditions = append(e.conditions, "e.read_later is true")
+}
+
 // WithFeedID adds feed_id to the condition.
 func (e *EntryPaginationBuilder) WithFeedID(feedID int64) {
 	if feedID != 0 {
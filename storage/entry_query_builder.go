@@ -13,9 +13,23 @@ import (
 	"github.com/lib/pq"
 
 	"miniflux.app/model"
+	"miniflux.app/search"
 	"miniflux.app/timezone"
 )
 
+// entrySortColumns maps the sorting orders of the API to the columns compared by the cursors.
+var entrySortColumns = map[string]string{
+	"id":             "e.id",
+	"status":         "e.status",
+	"changed_at":     "e.changed_at",
+	"published_at":   "e.published_at",
+	"created_at":     "e.created_at",
+	"category_title": "c.title",
+	"category_id":    "f.category_id",
+	"title":          "e.title",
+	"author":         "e.author",
+}
+
 // EntryQueryBuilder builds a SQL query to fetch entries.
 type EntryQueryBuilder struct {
 	store      *Storage
@@ -25,17 +39,21 @@ type EntryQueryBuilder struct {
 	direction  string
 	limit      int
 	offset     int
+	backward   bool
 }
 
-// WithSearchQuery adds full-text search query to the condition.
+// WithSearchQuery adds the conditions of a search query, see the search package for the syntax.
 func (e *EntryQueryBuilder) WithSearchQuery(query string) *EntryQueryBuilder {
 	if query != "" {
-		nArgs := len(e.args) + 1
-		e.conditions = append(e.conditions, fmt.Sprintf("e.document_vectors @@ plainto_tsquery($%d)", nArgs))
-		e.args = append(e.args, query)
+		var rank string
+		e.conditions, e.args, rank = searchConditions(search.Parse(query), e.conditions, e.args)
 
-		// 0.0000001 = 0.1 / (seconds_in_a_day)
-		e.WithOrder(fmt.Sprintf("ts_rank(document_vectors, plainto_tsquery($%d)) - extract (epoch from now() - published_at)::float * 0.0000001", nArgs))
+		if rank != "" {
+			// 0.0000001 = 0.1 / (seconds_in_a_day)
+			e.WithOrder(fmt.Sprintf("ts_rank(document_vectors, %s) - extract (epoch from now() - published_at)::float * 0.0000001", rank))
+		} else {
+			e.WithOrder("published_at")
+		}
 		e.WithDirection("DESC")
 	}
 	return e
@@ -51,6 +69,29 @@ func (e *EntryQueryBuilder) WithStarred(starred bool) *EntryQueryBuilder {
 	return e
 }
 
+// WithReadLater adds read later filter.
+func (e *EntryQueryBuilder) WithReadLater(readLater bool) *EntryQueryBuilder {
+	if readLater {
+		e.conditions = append(e.conditions, "e.read_later is true")
+	} else {
+		e.conditions = append(e.conditions, "e.read_later is false")
+	}
+	return e
+}
+
+// WithoutDuplicateCopies excludes the copies of entries already published in another feed.
+func (e *EntryQueryBuilder) WithoutDuplicateCopies() *EntryQueryBuilder {
+	e.conditions = append(e.conditions, "(e.duplicate_group_id IS NULL OR e.duplicate_group_id = e.id)")
+	return e
+}
+
+// WithDuplicateCopiesOf adds a condition to fetch the copies of the given duplicate groups.
+func (e *EntryQueryBuilder) WithDuplicateCopiesOf(groupIDs []int64) *EntryQueryBuilder {
+	e.conditions = append(e.conditions, fmt.Sprintf("e.duplicate_group_id = ANY($%d) AND e.id <> e.duplicate_group_id", len(e.args)+1))
+	e.args = append(e.args, pq.Array(groupIDs))
+	return e
+}
+
 // BeforeDate adds a condition < published_at
 func (e *EntryQueryBuilder) BeforeDate(date time.Time) *EntryQueryBuilder {
 	e.conditions = append(e.conditions, fmt.Sprintf("e.published_at < $%d", len(e.args)+1))
@@ -83,6 +124,41 @@ func (e *EntryQueryBuilder) AfterEntryID(entryID int64) *EntryQueryBuilder {
 	return e
 }
 
+// WithCursor adds the conditions to fetch the entries following the cursor position.
+//
+// The cursor sets the sorting order and direction. The entries before a backward
+// cursor are fetched in the reverse direction, GetEntries returns them in the cursor direction.
+func (e *EntryQueryBuilder) WithCursor(cursor *model.EntryCursor) *EntryQueryBuilder {
+	direction := cursor.Direction
+	if cursor.Backward {
+		e.backward = true
+		if direction == "asc" {
+			direction = "desc"
+		} else {
+			direction = "asc"
+		}
+	}
+
+	e.WithOrder(cursor.Order)
+	e.WithDirection(direction)
+
+	if cursor.Order == "id" {
+		if direction == "asc" {
+			return e.AfterEntryID(cursor.EntryID)
+		}
+		return e.BeforeEntryID(cursor.EntryID)
+	}
+
+	operator := "<"
+	if direction == "asc" {
+		operator = ">"
+	}
+
+	e.conditions = append(e.conditions, fmt.Sprintf("(%s, e.id) %s ($%d, $%d)", entrySortColumns[cursor.Order], operator, len(e.args)+1, len(e.args)+2))
+	e.args = append(e.args, cursor.SortKey, cursor.EntryID)
+	return e
+}
+
 // WithEntryIDs filter by entry IDs.
 func (e *EntryQueryBuilder) WithEntryIDs(entryIDs []int64) *EntryQueryBuilder {
 	e.conditions = append(e.conditions, fmt.Sprintf("e.id = ANY($%d)", len(e.args)+1))
@@ -146,6 +222,15 @@ func (e *EntryQueryBuilder) WithTags(tags []string) *EntryQueryBuilder {
 	return e
 }
 
+// WithLanguage filter by the language of the entries.
+func (e *EntryQueryBuilder) WithLanguage(language string) *EntryQueryBuilder {
+	if language != "" {
+		e.conditions = append(e.conditions, fmt.Sprintf("e.language = $%d", len(e.args)+1))
+		e.args = append(e.args, model.NormalizeLanguage(language))
+	}
+	return e
+}
+
 // WithoutStatus set the entry status that should not be returned.
 func (e *EntryQueryBuilder) WithoutStatus(status string) *EntryQueryBuilder {
 	if status != "" {
@@ -258,20 +343,30 @@ func (e *EntryQueryBuilder) GetEntries() (model.Entries, error) {
 			e.content,
 			e.status,
 			e.starred,
+			e.read_later,
 			e.reading_time,
 			e.created_at,
 			e.changed_at,
 			e.tags,
+			coalesce(e.duplicate_group_id, 0),
+			e.revision_count,
+			e.language,
 			f.title as feed_title,
 			f.feed_url,
 			f.site_url,
 			f.checked_at,
 			f.category_id, c.title as category_title,
+			f.proxy_name,
+			c.proxy_name as category_proxy_name,
 			f.scraper_rules,
 			f.rewrite_rules,
 			f.crawler,
 			f.user_agent,
 			f.cookie,
+			f.headers,
+			f.tls_client_certificate,
+			f.tls_client_key,
+			f.tls_ca_bundle,
 			f.no_media_player,
 			fi.icon_id,
 			u.timezone
@@ -303,6 +398,7 @@ func (e *EntryQueryBuilder) GetEntries() (model.Entries, error) {
 		var entry model.Entry
 		var iconID sql.NullInt64
 		var tz string
+		var tlsClientKey []byte
 
 		entry.Feed = &model.Feed{}
 		entry.Feed.Category = &model.Category{}
@@ -322,21 +418,31 @@ func (e *EntryQueryBuilder) GetEntries() (model.Entries, error) {
 			&entry.Content,
 			&entry.Status,
 			&entry.Starred,
+			&entry.ReadLater,
 			&entry.ReadingTime,
 			&entry.CreatedAt,
 			&entry.ChangedAt,
 			pq.Array(&entry.Tags),
+			&entry.DuplicateGroupID,
+			&entry.RevisionCount,
+			&entry.Language,
 			&entry.Feed.Title,
 			&entry.Feed.FeedURL,
 			&entry.Feed.SiteURL,
 			&entry.Feed.CheckedAt,
 			&entry.Feed.Category.ID,
 			&entry.Feed.Category.Title,
+			&entry.Feed.ProxyName,
+			&entry.Feed.Category.ProxyName,
 			&entry.Feed.ScraperRules,
 			&entry.Feed.RewriteRules,
 			&entry.Feed.Crawler,
 			&entry.Feed.UserAgent,
 			&entry.Feed.Cookie,
+			&entry.Feed.Headers,
+			&entry.Feed.TLSClientCertificate,
+			&tlsClientKey,
+			&entry.Feed.TLSCABundle,
 			&entry.Feed.NoMediaPlayer,
 			&iconID,
 			&tz,
@@ -352,6 +458,8 @@ func (e *EntryQueryBuilder) GetEntries() (model.Entries, error) {
 			entry.Feed.Icon.IconID = 0
 		}
 
+		entry.Feed.TLSClientKey = decryptSecret(tlsClientKey)
+
 		// Make sure that timestamp fields contains timezone information (API)
 		entry.Date = timezone.Convert(tz, entry.Date)
 		entry.CreatedAt = timezone.Convert(tz, entry.CreatedAt)
@@ -365,6 +473,12 @@ func (e *EntryQueryBuilder) GetEntries() (model.Entries, error) {
 		entries = append(entries, &entry)
 	}
 
+	if e.backward {
+		for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+			entries[i], entries[j] = entries[j], entries[i]
+		}
+	}
+
 	return entries, nil
 }
 
@@ -396,6 +510,30 @@ func (e *EntryQueryBuilder) GetEntryIDs() ([]int64, error) {
 	return entryIDs, nil
 }
 
+// GetFeedIDs returns the distinct feed IDs of the entries.
+func (e *EntryQueryBuilder) GetFeedIDs() ([]int64, error) {
+	query := `SELECT DISTINCT e.feed_id FROM entries e LEFT JOIN feeds f ON f.id=e.feed_id WHERE %s`
+	query = fmt.Sprintf(query, e.buildCondition())
+
+	rows, err := e.store.db.Query(query, e.args...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get feed IDs: %v", err)
+	}
+	defer rows.Close()
+
+	var feedIDs []int64
+	for rows.Next() {
+		var feedID int64
+		if err := rows.Scan(&feedID); err != nil {
+			return nil, fmt.Errorf("unable to fetch feed ID row: %v", err)
+		}
+
+		feedIDs = append(feedIDs, feedID)
+	}
+
+	return feedIDs, nil
+}
+
 func (e *EntryQueryBuilder) buildCondition() string {
 	return strings.Join(e.conditions, " AND ")
 }
@@ -411,6 +549,11 @@ func (e *EntryQueryBuilder) buildSorting() string {
 		parts = append(parts, e.direction)
 	}
 
+	// The entry ID breaks the ties of the sorting column, the cursors need a stable order.
+	if _, found := entrySortColumns[e.order]; found && e.order != "id" {
+		parts = append(parts, fmt.Sprintf(`, e.id %s`, e.direction))
+	}
+
 	if e.limit > 0 {
 		parts = append(parts, fmt.Sprintf(`LIMIT %d`, e.limit))
 	}
@@ -0,0 +1,90 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package storage // import "miniflux.app/storage"
+
+import (
+	"database/sql"
+	"fmt"
+
+	"miniflux.app/model"
+)
+
+// createEntryRevision saves the previous version of an entry, only the most recent revisions are kept.
+func (s *Storage) createEntryRevision(tx *sql.Tx, revision *model.EntryRevision, limit int) error {
+	if limit <= 0 {
+		return nil
+	}
+
+	query := `
+		INSERT INTO entry_revisions
+			(user_id, entry_id, title, url, content)
+		VALUES
+			($1, $2, $3, $4, $5)
+	`
+	if _, err := tx.Exec(query, revision.UserID, revision.EntryID, revision.Title, revision.URL, revision.Content); err != nil {
+		return fmt.Errorf(`store: unable to create revision of entry #%d: %v`, revision.EntryID, err)
+	}
+
+	query = `
+		DELETE FROM
+			entry_revisions
+		WHERE
+			entry_id=$1 AND
+			id NOT IN (SELECT id FROM entry_revisions WHERE entry_id=$1 ORDER BY id DESC LIMIT $2)
+	`
+	if _, err := tx.Exec(query, revision.EntryID, limit); err != nil {
+		return fmt.Errorf(`store: unable to remove old revisions of entry #%d: %v`, revision.EntryID, err)
+	}
+
+	if _, err := tx.Exec(`UPDATE entries SET revision_count=revision_count+1 WHERE id=$1`, revision.EntryID); err != nil {
+		return fmt.Errorf(`store: unable to update revision count of entry #%d: %v`, revision.EntryID, err)
+	}
+
+	return nil
+}
+
+// EntryRevisions returns the previous versions of an entry, the most recent first.
+func (s *Storage) EntryRevisions(userID, entryID int64) (model.EntryRevisions, error) {
+	query := `
+		SELECT
+			id,
+			user_id,
+			entry_id,
+			title,
+			url,
+			content,
+			created_at
+		FROM
+			entry_revisions
+		WHERE
+			user_id=$1 AND entry_id=$2
+		ORDER BY
+			id DESC
+	`
+	rows, err := s.db.Query(query, userID, entryID)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch revisions of entry #%d: %v`, entryID, err)
+	}
+	defer rows.Close()
+
+	revisions := make(model.EntryRevisions, 0)
+	for rows.Next() {
+		var revision model.EntryRevision
+		if err := rows.Scan(
+			&revision.ID,
+			&revision.UserID,
+			&revision.EntryID,
+			&revision.Title,
+			&revision.URL,
+			&revision.Content,
+			&revision.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch revision row: %v`, err)
+		}
+		revisions = append(revisions, &revision)
+	}
+
+	return revisions, nil
+}
@@ -0,0 +1,95 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package storage // import "miniflux.app/storage"
+
+import (
+	"fmt"
+	"strings"
+
+	"miniflux.app/model"
+	"miniflux.app/search"
+)
+
+// searchConditions appends the SQL conditions and the arguments of a search query.
+//
+// The returned rank is the text search query of the words and the phrases, empty if there isn't any.
+// The conditions only use the entries and the feeds tables.
+func searchConditions(query *search.Query, conditions []string, args []interface{}) ([]string, []interface{}, string) {
+	placeholder := func(value interface{}) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	var textQueries []string
+	if len(query.Terms) > 0 {
+		textQueries = append(textQueries, textSearchQuery("plainto_tsquery", placeholder(strings.Join(query.Terms, " "))))
+	}
+
+	for _, phrase := range query.Phrases {
+		textQueries = append(textQueries, textSearchQuery("phraseto_tsquery", placeholder(phrase)))
+	}
+
+	for _, textQuery := range textQueries {
+		conditions = append(conditions, "e.document_vectors @@ "+textQuery)
+	}
+
+	for _, excluded := range query.Excluded {
+		conditions = append(conditions, "NOT (e.document_vectors @@ "+textSearchQuery("phraseto_tsquery", placeholder(excluded))+")")
+	}
+
+	for _, filter := range query.Filters {
+		var condition string
+		switch filter.Field {
+		case search.FieldFeed:
+			pattern := placeholder(containsPattern(filter.Value))
+			condition = fmt.Sprintf("(f.title ILIKE %[1]s OR f.site_url ILIKE %[1]s)", pattern)
+		case search.FieldCategory:
+			condition = fmt.Sprintf("f.category_id IN (SELECT id FROM categories WHERE user_id=e.user_id AND title ILIKE %s)", placeholder(containsPattern(filter.Value)))
+		case search.FieldAuthor:
+			condition = fmt.Sprintf("e.author ILIKE %s", placeholder(containsPattern(filter.Value)))
+		case search.FieldTag:
+			condition = fmt.Sprintf("EXISTS (SELECT 1 FROM unnest(e.tags) AS tag WHERE lower(tag) = lower(%s))", placeholder(filter.Value))
+		case search.FieldLanguage:
+			condition = fmt.Sprintf("e.language = %s", placeholder(model.NormalizeLanguage(filter.Value)))
+		case search.FieldIs:
+			switch filter.Value {
+			case search.IsUnread:
+				condition = fmt.Sprintf("e.status = %s", placeholder(model.EntryStatusUnread))
+			case search.IsRead:
+				condition = fmt.Sprintf("e.status = %s", placeholder(model.EntryStatusRead))
+			case search.IsStarred:
+				condition = "e.starred is true"
+			case search.IsReadLater:
+				condition = "e.read_later is true"
+			}
+		}
+
+		if condition == "" {
+			continue
+		}
+
+		if filter.Exclude {
+			condition = "NOT (" + condition + ")"
+		}
+		conditions = append(conditions, condition)
+	}
+
+	// The dates are the beginning of a day in the timezone of the user.
+	if !query.After.IsZero() {
+		conditions = append(conditions, fmt.Sprintf("e.published_at >= (%s::timestamp AT TIME ZONE (SELECT timezone FROM users WHERE id=e.user_id))", placeholder(query.After.Format("2006-01-02"))))
+	}
+
+	if !query.Before.IsZero() {
+		conditions = append(conditions, fmt.Sprintf("e.published_at < (%s::timestamp AT TIME ZONE (SELECT timezone FROM users WHERE id=e.user_id))", placeholder(query.Before.Format("2006-01-02"))))
+	}
+
+	return conditions, args, strings.Join(textQueries, " && ")
+}
+
+// containsPattern returns the LIKE pattern matching the texts containing a value.
+func containsPattern(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	return "%" + replacer.Replace(value) + "%"
+}
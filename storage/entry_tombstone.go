@@ -0,0 +1,89 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package storage // import "miniflux.app/storage"
+
+import (
+	"database/sql"
+	"fmt"
+
+	"miniflux.app/model"
+)
+
+// EntryTombstoneExists returns true if the entry has been purged from the feed.
+func (s *Storage) EntryTombstoneExists(feedID int64, hash string) bool {
+	var result bool
+	query := `SELECT true FROM entry_tombstones WHERE feed_id=$1 AND hash=$2`
+	s.db.QueryRow(query, feedID, hash).Scan(&result)
+	return result
+}
+
+func (s *Storage) entryTombstoneExists(tx *sql.Tx, entry *model.Entry) bool {
+	var result bool
+	tx.QueryRow(`SELECT true FROM entry_tombstones WHERE feed_id=$1 AND hash=$2`, entry.FeedID, entry.Hash).Scan(&result)
+	return result
+}
+
+// CountEntryTombstones returns the number of purged entries remembered in the database.
+func (s *Storage) CountEntryTombstones() int64 {
+	var result int64
+	s.db.QueryRow(`SELECT count(*) FROM entry_tombstones`).Scan(&result)
+	return result
+}
+
+// PurgeRemovedEntries deletes the entries removed for more than the given number of days.
+//
+// Only a tombstone made of the feed and the hash of each entry is kept, so the entry is never imported again.
+// The entries are deleted in batches of the given size, a negative number of days disables the purge.
+func (s *Storage) PurgeRemovedEntries(days, batchSize int) (int64, error) {
+	if days < 0 || batchSize <= 0 {
+		return 0, nil
+	}
+
+	query := `
+		WITH purged AS (
+			DELETE FROM
+				entries
+			WHERE
+				id=ANY(
+					SELECT
+						id
+					FROM
+						entries
+					WHERE
+						status=$1 AND starred is false AND share_code='' AND changed_at < now() - make_interval(days => $2)
+					ORDER BY
+						changed_at ASC
+					LIMIT $3
+				)
+			RETURNING
+				feed_id, hash
+		), tombstones AS (
+			INSERT INTO entry_tombstones
+				(feed_id, hash)
+			SELECT
+				feed_id, hash
+			FROM
+				purged
+			ON CONFLICT DO NOTHING
+		)
+		SELECT
+			count(*)
+		FROM
+			purged
+	`
+
+	var total int64
+	for {
+		var count int64
+		if err := s.db.QueryRow(query, model.EntryStatusRemoved, days, batchSize).Scan(&count); err != nil {
+			return total, fmt.Errorf(`store: unable to purge removed entries: %v`, err)
+		}
+
+		total += count
+		if count < int64(batchSize) {
+			return total, nil
+		}
+	}
+}
@@ -14,6 +14,8 @@ import (
 	"miniflux.app/config"
 	"miniflux.app/logger"
 	"miniflux.app/model"
+
+	"github.com/lib/pq"
 )
 
 type byStateAndName struct{ f model.Feeds }
@@ -58,6 +60,14 @@ func (s *Storage) AnotherFeedURLExists(userID, feedID int64, feedURL string) boo
 	return result
 }
 
+// FeedIDByURL returns the ID of the feed subscribed with the given URL, or 0 if there is none.
+func (s *Storage) FeedIDByURL(userID int64, feedURL string) int64 {
+	var feedID int64
+	query := `SELECT id FROM feeds WHERE user_id=$1 AND feed_url=$2`
+	s.db.QueryRow(query, userID, feedURL).Scan(&feedID)
+	return feedID
+}
+
 // CountAllFeeds returns the number of feeds in the database.
 func (s *Storage) CountAllFeeds() map[string]int64 {
 	rows, err := s.db.Query(`SELECT disabled, count(*) FROM feeds GROUP BY disabled`)
@@ -220,6 +230,11 @@ func (s *Storage) FeedByID(userID, feedID int64) (*model.Feed, error) {
 
 // CreateFeed creates a new feed.
 func (s *Storage) CreateFeed(feed *model.Feed) error {
+	tlsClientKey, err := encryptSecret(feed.TLSClientKey)
+	if err != nil {
+		return fmt.Errorf(`store: unable to encrypt the client key of feed %q: %v`, feed.FeedURL, err)
+	}
+
 	sql := `
 		INSERT INTO feeds (
 			feed_url,
@@ -244,14 +259,26 @@ func (s *Storage) CreateFeed(feed *model.Feed) error {
 			fetch_via_proxy,
 			hide_globally,
 			url_rewrite_rules,
-			no_media_player
+			no_media_player,
+			ttl,
+			skip_hours,
+			skip_days,
+			headers,
+			tls_client_certificate,
+			tls_client_key,
+			tls_ca_bundle,
+			proxy_name,
+			entry_identity,
+			retention_read_days,
+			retention_unread_days,
+			retention_max_entries
 		)
 		VALUES
-			($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23)
+			($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28, $29, $30, $31, $32, $33, $34, $35)
 		RETURNING
 			id
 	`
-	err := s.db.QueryRow(
+	err = s.db.QueryRow(
 		sql,
 		feed.FeedURL,
 		feed.SiteURL,
@@ -276,6 +303,18 @@ func (s *Storage) CreateFeed(feed *model.Feed) error {
 		feed.HideGlobally,
 		feed.UrlRewriteRules,
 		feed.NoMediaPlayer,
+		feed.TTL,
+		pq.Array(feed.SkipHours),
+		pq.Array(feed.SkipDays),
+		feed.Headers,
+		feed.TLSClientCertificate,
+		tlsClientKey,
+		feed.TLSCABundle,
+		feed.ProxyName,
+		feed.EntryIdentity,
+		feed.RetentionReadDays,
+		feed.RetentionUnreadDays,
+		feed.RetentionMaxEntries,
 	).Scan(&feed.ID)
 	if err != nil {
 		return fmt.Errorf(`store: unable to create feed %q: %v`, feed.FeedURL, err)
@@ -307,6 +346,11 @@ func (s *Storage) CreateFeed(feed *model.Feed) error {
 
 // UpdateFeed updates an existing feed.
 func (s *Storage) UpdateFeed(feed *model.Feed) (err error) {
+	tlsClientKey, err := encryptSecret(feed.TLSClientKey)
+	if err != nil {
+		return fmt.Errorf(`store: unable to encrypt the client key of feed #%d: %v`, feed.ID, err)
+	}
+
 	query := `
 		UPDATE
 			feeds
@@ -336,9 +380,24 @@ func (s *Storage) UpdateFeed(feed *model.Feed) (err error) {
 			fetch_via_proxy=$23,
 			hide_globally=$24,
 			url_rewrite_rules=$25,
-			no_media_player=$26
+			no_media_player=$26,
+			next_check_reason=$27,
+			ttl=$28,
+			skip_hours=$29,
+			skip_days=$30,
+			headers=$31,
+			tls_client_certificate=$32,
+			tls_client_key=$33,
+			tls_ca_bundle=$34,
+			moved_to_url=$35,
+			proxy_name=$36,
+			entry_identity=$37,
+			previous_entry_identity=$38,
+			retention_read_days=$39,
+			retention_unread_days=$40,
+			retention_max_entries=$41
 		WHERE
-			id=$27 AND user_id=$28
+			id=$42 AND user_id=$43
 	`
 	_, err = s.db.Exec(query,
 		feed.FeedURL,
@@ -367,6 +426,21 @@ func (s *Storage) UpdateFeed(feed *model.Feed) (err error) {
 		feed.HideGlobally,
 		feed.UrlRewriteRules,
 		feed.NoMediaPlayer,
+		feed.NextCheckReason,
+		feed.TTL,
+		pq.Array(feed.SkipHours),
+		pq.Array(feed.SkipDays),
+		feed.Headers,
+		feed.TLSClientCertificate,
+		tlsClientKey,
+		feed.TLSCABundle,
+		feed.MovedToURL,
+		feed.ProxyName,
+		feed.EntryIdentity,
+		feed.PreviousEntryIdentity,
+		feed.RetentionReadDays,
+		feed.RetentionUnreadDays,
+		feed.RetentionMaxEntries,
 		feed.ID,
 		feed.UserID,
 	)
@@ -387,15 +461,17 @@ func (s *Storage) UpdateFeedError(feed *model.Feed) (err error) {
 			parsing_error_msg=$1,
 			parsing_error_count=$2,
 			checked_at=$3,
-			next_check_at=$4
+			next_check_at=$4,
+			next_check_reason=$5
 		WHERE
-			id=$5 AND user_id=$6
+			id=$6 AND user_id=$7
 	`
 	_, err = s.db.Exec(query,
 		feed.ParsingErrorMsg,
 		feed.ParsingErrorCount,
 		feed.CheckedAt,
 		feed.NextCheckAt,
+		feed.NextCheckReason,
 		feed.ID,
 		feed.UserID,
 	)
@@ -11,6 +11,8 @@ import (
 
 	"miniflux.app/model"
 	"miniflux.app/timezone"
+
+	"github.com/lib/pq"
 )
 
 // FeedQueryBuilder builds a SQL query to fetch feeds.
@@ -151,6 +153,8 @@ func (f *FeedQueryBuilder) GetFeeds() (model.Feeds, error) {
 			f.last_modified_header,
 			f.user_id,
 			f.checked_at at time zone u.timezone,
+			f.next_check_at at time zone u.timezone,
+			f.next_check_reason,
 			f.parsing_error_count,
 			f.parsing_error_msg,
 			f.scraper_rules,
@@ -166,12 +170,29 @@ func (f *FeedQueryBuilder) GetFeeds() (model.Feeds, error) {
 			f.ignore_http_cache,
 			f.allow_self_signed_certificates,
 			f.fetch_via_proxy,
+			f.proxy_name,
+			f.entry_identity,
+			f.previous_entry_identity,
+			f.retention_read_days,
+			f.retention_unread_days,
+			f.retention_max_entries,
 			f.disabled,
 			f.no_media_player,
 			f.hide_globally,
+			f.ttl,
+			f.skip_hours,
+			f.skip_days,
+			coalesce(ws.state='active' AND ws.lease_expires_at > now(), false) as websub_active,
+			f.claimed_by,
+			f.moved_to_url,
+			f.headers,
+			f.tls_client_certificate,
+			f.tls_client_key,
+			f.tls_ca_bundle,
 			f.category_id,
 			c.title as category_title,
 			c.hide_globally as category_hidden,
+			c.proxy_name as category_proxy_name,
 			fi.icon_id,
 			u.timezone
 		FROM
@@ -182,6 +203,8 @@ func (f *FeedQueryBuilder) GetFeeds() (model.Feeds, error) {
 			feed_icons fi ON fi.feed_id=f.id
 		LEFT JOIN
 			users u ON u.id=f.user_id
+		LEFT JOIN
+			websub_subscriptions ws ON ws.feed_id=f.id
 		WHERE %s 
 		%s
 	`
@@ -204,6 +227,7 @@ func (f *FeedQueryBuilder) GetFeeds() (model.Feeds, error) {
 		var feed model.Feed
 		var iconID sql.NullInt64
 		var tz string
+		var tlsClientKey []byte
 		feed.Category = &model.Category{}
 
 		err := rows.Scan(
@@ -215,6 +239,8 @@ func (f *FeedQueryBuilder) GetFeeds() (model.Feeds, error) {
 			&feed.LastModifiedHeader,
 			&feed.UserID,
 			&feed.CheckedAt,
+			&feed.NextCheckAt,
+			&feed.NextCheckReason,
 			&feed.ParsingErrorCount,
 			&feed.ParsingErrorMsg,
 			&feed.ScraperRules,
@@ -230,12 +256,29 @@ func (f *FeedQueryBuilder) GetFeeds() (model.Feeds, error) {
 			&feed.IgnoreHTTPCache,
 			&feed.AllowSelfSignedCertificates,
 			&feed.FetchViaProxy,
+			&feed.ProxyName,
+			&feed.EntryIdentity,
+			&feed.PreviousEntryIdentity,
+			&feed.RetentionReadDays,
+			&feed.RetentionUnreadDays,
+			&feed.RetentionMaxEntries,
 			&feed.Disabled,
 			&feed.NoMediaPlayer,
 			&feed.HideGlobally,
+			&feed.TTL,
+			pq.Array(&feed.SkipHours),
+			pq.Array(&feed.SkipDays),
+			&feed.WebSubActive,
+			&feed.ClaimedBy,
+			&feed.MovedToURL,
+			&feed.Headers,
+			&feed.TLSClientCertificate,
+			&tlsClientKey,
+			&feed.TLSCABundle,
 			&feed.Category.ID,
 			&feed.Category.Title,
 			&feed.Category.HideGlobally,
+			&feed.Category.ProxyName,
 			&iconID,
 			&tz,
 		)
@@ -261,7 +304,9 @@ func (f *FeedQueryBuilder) GetFeeds() (model.Feeds, error) {
 			}
 		}
 
+		feed.TLSClientKey = decryptSecret(tlsClientKey)
 		feed.CheckedAt = timezone.Convert(tz, feed.CheckedAt)
+		feed.NextCheckAt = timezone.Convert(tz, feed.NextCheckAt)
 		feed.Category.UserID = feed.UserID
 		feeds = append(feeds, &feed)
 	}
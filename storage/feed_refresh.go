@@ -0,0 +1,131 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package storage // import "miniflux.app/storage"
+
+import (
+	"fmt"
+
+	"miniflux.app/model"
+	"miniflux.app/timezone"
+)
+
+// CreateFeedRefresh records a refresh attempt in the history of the feed.
+func (s *Storage) CreateFeedRefresh(refresh *model.FeedRefresh) error {
+	query := `
+		INSERT INTO feed_refreshes
+			(feed_id, user_id, started_at, duration, status_code, effective_url, redirects, not_modified, new_entries, updated_entries, error_msg)
+		VALUES
+			($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING
+			id
+	`
+	err := s.db.QueryRow(
+		query,
+		refresh.FeedID,
+		refresh.UserID,
+		refresh.StartedAt,
+		refresh.Duration,
+		refresh.StatusCode,
+		refresh.EffectiveURL,
+		refresh.Redirects,
+		refresh.NotModified,
+		refresh.NewEntries,
+		refresh.UpdatedEntries,
+		refresh.Error,
+	).Scan(&refresh.ID)
+
+	if err != nil {
+		return fmt.Errorf(`store: unable to create refresh history for feed #%d: %v`, refresh.FeedID, err)
+	}
+
+	return nil
+}
+
+// FeedRefreshes returns the most recent refresh attempts of a feed.
+func (s *Storage) FeedRefreshes(userID, feedID int64, limit int) (model.FeedRefreshes, error) {
+	query := `
+		SELECT
+			r.id,
+			r.feed_id,
+			r.user_id,
+			r.started_at,
+			r.duration,
+			r.status_code,
+			r.effective_url,
+			r.redirects,
+			r.not_modified,
+			r.new_entries,
+			r.updated_entries,
+			r.error_msg,
+			u.timezone
+		FROM
+			feed_refreshes r
+		JOIN
+			users u ON u.id=r.user_id
+		WHERE
+			r.user_id=$1 AND r.feed_id=$2
+		ORDER BY
+			r.started_at DESC, r.id DESC
+		LIMIT $3
+	`
+	rows, err := s.db.Query(query, userID, feedID, limit)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch refresh history of feed #%d: %v`, feedID, err)
+	}
+	defer rows.Close()
+
+	refreshes := make(model.FeedRefreshes, 0)
+	for rows.Next() {
+		var refresh model.FeedRefresh
+		var tz string
+		if err := rows.Scan(
+			&refresh.ID,
+			&refresh.FeedID,
+			&refresh.UserID,
+			&refresh.StartedAt,
+			&refresh.Duration,
+			&refresh.StatusCode,
+			&refresh.EffectiveURL,
+			&refresh.Redirects,
+			&refresh.NotModified,
+			&refresh.NewEntries,
+			&refresh.UpdatedEntries,
+			&refresh.Error,
+			&tz,
+		); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch refresh history row: %v`, err)
+		}
+
+		refresh.StartedAt = timezone.Convert(tz, refresh.StartedAt)
+		refreshes = append(refreshes, &refresh)
+	}
+
+	return refreshes, nil
+}
+
+// CleanOldFeedRefreshes removes the refresh history older than the given number of days,
+// and keeps at most maxPerFeed attempts for each feed.
+func (s *Storage) CleanOldFeedRefreshes(days, maxPerFeed int) (int64, error) {
+	query := `
+		DELETE FROM
+			feed_refreshes
+		WHERE
+			started_at < now() - $1 * interval '1 day' OR
+			id IN (
+				SELECT id FROM (
+					SELECT id, row_number() OVER (PARTITION BY feed_id ORDER BY started_at DESC, id DESC) AS position
+					FROM feed_refreshes
+				) AS ranked_refreshes
+				WHERE position > $2
+			)
+	`
+	result, err := s.db.Exec(query, days, maxPerFeed)
+	if err != nil {
+		return 0, fmt.Errorf(`store: unable to clean refresh history: %v`, err)
+	}
+
+	count, _ := result.RowsAffected()
+	return count, nil
+}
@@ -0,0 +1,154 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package storage // import "miniflux.app/storage"
+
+import (
+	"fmt"
+
+	"miniflux.app/model"
+	"miniflux.app/timezone"
+)
+
+// CreateFeedURLChange records a change of URL in the history of the feed.
+func (s *Storage) CreateFeedURLChange(change *model.FeedURLChange) error {
+	query := `
+		INSERT INTO feed_url_changes
+			(feed_id, user_id, previous_url, new_url, reason)
+		VALUES
+			($1, $2, $3, $4, $5)
+		RETURNING
+			id, created_at
+	`
+	err := s.db.QueryRow(
+		query,
+		change.FeedID,
+		change.UserID,
+		change.PreviousURL,
+		change.NewURL,
+		change.Reason,
+	).Scan(&change.ID, &change.CreatedAt)
+
+	if err != nil {
+		return fmt.Errorf(`store: unable to record URL change of feed #%d: %v`, change.FeedID, err)
+	}
+
+	return nil
+}
+
+// FeedURLChanges returns the URL history of a feed, the most recent change first.
+func (s *Storage) FeedURLChanges(userID, feedID int64) (model.FeedURLChanges, error) {
+	query := `
+		SELECT
+			c.id,
+			c.feed_id,
+			c.user_id,
+			c.previous_url,
+			c.new_url,
+			c.reason,
+			c.created_at,
+			u.timezone
+		FROM
+			feed_url_changes c
+		JOIN
+			users u ON u.id=c.user_id
+		WHERE
+			c.user_id=$1 AND c.feed_id=$2
+		ORDER BY
+			c.created_at DESC, c.id DESC
+	`
+	rows, err := s.db.Query(query, userID, feedID)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch URL history of feed #%d: %v`, feedID, err)
+	}
+	defer rows.Close()
+
+	changes := make(model.FeedURLChanges, 0)
+	for rows.Next() {
+		var change model.FeedURLChange
+		var tz string
+		if err := rows.Scan(
+			&change.ID,
+			&change.FeedID,
+			&change.UserID,
+			&change.PreviousURL,
+			&change.NewURL,
+			&change.Reason,
+			&change.CreatedAt,
+			&tz,
+		); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch URL history row: %v`, err)
+		}
+
+		change.CreatedAt = timezone.Convert(tz, change.CreatedAt)
+		changes = append(changes, &change)
+	}
+
+	return changes, nil
+}
+
+// MergeFeeds moves the entries and the URL history of the source feed to the target feed, then removes the source feed.
+//
+// Entries already present in the target feed are not moved.
+func (s *Storage) MergeFeeds(userID, sourceFeedID, targetFeedID int64) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf(`store: unable to start transaction: %v`, err)
+	}
+
+	// Bookmarks of the entries already present in the target feed are kept.
+	_, err = tx.Exec(`
+		UPDATE entries t
+		SET starred='t'
+		FROM entries s
+		WHERE s.user_id=$1 AND s.feed_id=$2 AND s.starred='t' AND t.feed_id=$3 AND t.hash=s.hash
+	`, userID, sourceFeedID, targetFeedID)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf(`store: unable to merge bookmarks of feed #%d: %v`, sourceFeedID, err)
+	}
+
+	_, err = tx.Exec(`
+		UPDATE entries
+		SET feed_id=$3
+		WHERE user_id=$1 AND feed_id=$2 AND hash NOT IN (SELECT hash FROM entries WHERE feed_id=$3)
+	`, userID, sourceFeedID, targetFeedID)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf(`store: unable to move entries of feed #%d: %v`, sourceFeedID, err)
+	}
+
+	_, err = tx.Exec(`UPDATE feed_url_changes SET feed_id=$3 WHERE user_id=$1 AND feed_id=$2`, userID, sourceFeedID, targetFeedID)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf(`store: unable to move URL history of feed #%d: %v`, sourceFeedID, err)
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO feed_url_changes (feed_id, user_id, previous_url, new_url, reason)
+		SELECT t.id, t.user_id, s.feed_url, t.feed_url, $4
+		FROM feeds s, feeds t
+		WHERE s.user_id=$1 AND s.id=$2 AND t.user_id=$1 AND t.id=$3
+	`, userID, sourceFeedID, targetFeedID, model.FeedURLChangeReasonMerge)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf(`store: unable to record the merge of feed #%d: %v`, sourceFeedID, err)
+	}
+
+	if _, err = tx.Exec(`DELETE FROM entries WHERE user_id=$1 AND feed_id=$2`, userID, sourceFeedID); err != nil {
+		tx.Rollback()
+		return fmt.Errorf(`store: unable to delete entries of feed #%d: %v`, sourceFeedID, err)
+	}
+
+	if _, err = tx.Exec(`DELETE FROM feeds WHERE user_id=$1 AND id=$2`, userID, sourceFeedID); err != nil {
+		tx.Rollback()
+		return fmt.Errorf(`store: unable to delete feed #%d: %v`, sourceFeedID, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf(`store: unable to commit transaction: %v`, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,90 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package storage // import "miniflux.app/storage"
+
+import (
+	"fmt"
+
+	"miniflux.app/model"
+	"miniflux.app/reader/filter"
+)
+
+// IncrementFilterRuleMatches counts an entry matched by a global filter rule of the user.
+//
+// Blocked entries are not stored and are seen again on each refresh, the matched entries
+// are remembered to count each of them only once.
+func (s *Storage) IncrementFilterRuleMatches(userID, feedID int64, list, rule, hash string) error {
+	query := `
+		WITH matched_entry AS (
+			INSERT INTO filter_rule_matched_entries
+				(user_id, feed_id, list, rule, hash)
+			VALUES
+				($1, $2, $3, $4, $5)
+			ON CONFLICT DO NOTHING
+			RETURNING
+				user_id
+		)
+		INSERT INTO filter_rule_matches
+			(user_id, list, rule, matches)
+		SELECT
+			user_id, $3, $4, 1
+		FROM
+			matched_entry
+		ON CONFLICT (user_id, list, rule) DO UPDATE SET
+			matches = filter_rule_matches.matches + 1
+	`
+	if _, err := s.db.Exec(query, userID, feedID, list, rule, hash); err != nil {
+		return fmt.Errorf(`store: unable to count the matches of the rule %q: %v`, rule, err)
+	}
+
+	return nil
+}
+
+// FilterRules returns the global filter rules of the user with the number of entries they matched.
+func (s *Storage) FilterRules(user *model.User) (model.FilterRules, error) {
+	query := `SELECT list, rule, matches FROM filter_rule_matches WHERE user_id=$1`
+	rows, err := s.db.Query(query, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch filter rule matches: %v`, err)
+	}
+	defer rows.Close()
+
+	matches := make(map[string]map[string]int64)
+	for rows.Next() {
+		var list, rule string
+		var count int64
+		if err := rows.Scan(&list, &rule, &count); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch filter rule matches: %v`, err)
+		}
+
+		if matches[list] == nil {
+			matches[list] = make(map[string]int64)
+		}
+		matches[list][rule] = count
+	}
+
+	var filterRules model.FilterRules
+	for _, list := range []string{model.FilterRuleBlocklist, model.FilterRuleKeeplist} {
+		text := user.BlocklistRules
+		if list == model.FilterRuleKeeplist {
+			text = user.KeeplistRules
+		}
+
+		rules, err := filter.ParseListRules(text)
+		if err != nil {
+			return nil, fmt.Errorf(`store: invalid %s rules: %v`, list, err)
+		}
+
+		for _, rule := range rules {
+			filterRules = append(filterRules, &model.FilterRule{
+				List:    list,
+				Rule:    rule.Text,
+				Matches: matches[list][rule.Text],
+			})
+		}
+	}
+
+	return filterRules, nil
+}
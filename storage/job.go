@@ -6,36 +6,64 @@ package storage // import "miniflux.app/storage"
 
 import (
 	"fmt"
+	"time"
 
-	"miniflux.app/config"
 	"miniflux.app/model"
 )
 
-// NewBatch returns a series of jobs.
-func (s *Storage) NewBatch(batchSize int) (jobs model.JobList, err error) {
-	pollingParsingErrorLimit := config.Opts.PollingParsingErrorLimit()
+// NewBatch claims a series of jobs for the given node.
+//
+// Feeds in error are not excluded, their next check is delayed with an exponential backoff.
+//
+// The feeds are locked with SKIP LOCKED and marked as claimed by the node, so the
+// other instances sharing the database skip them until the claim is released with
+// ReleaseFeedClaim or until the claim is older than claimTimeout.
+func (s *Storage) NewBatch(batchSize int, node string, claimTimeout time.Duration) (jobs model.JobList, err error) {
 	query := `
-		SELECT
-			id,
-			user_id
-		FROM
+		UPDATE
 			feeds
+		SET
+			claimed_by=$2,
+			claimed_at=now()
 		WHERE
-			disabled is false AND next_check_at < now() AND 
-			CASE WHEN $1 > 0 THEN parsing_error_count < $1 ELSE parsing_error_count >= 0 END
-		ORDER BY next_check_at ASC LIMIT $2
+			id IN (
+				SELECT
+					id
+				FROM
+					feeds
+				WHERE
+					disabled is false AND next_check_at < now() AND
+					(claimed_at IS NULL OR claimed_at < now() - $3 * interval '1 second')
+				ORDER BY next_check_at ASC
+				LIMIT $1
+				FOR UPDATE SKIP LOCKED
+			)
+		RETURNING
+			id,
+			user_id,
+			feed_url
 	`
-	return s.fetchBatchRows(query, pollingParsingErrorLimit, batchSize)
+	return s.fetchBatchRows(query, batchSize, node, int64(claimTimeout.Seconds()))
+}
+
+// ReleaseFeedClaim removes the claim of the node on the feed, the feed is claimed again once it's due.
+func (s *Storage) ReleaseFeedClaim(feedID int64, node string) error {
+	query := `UPDATE feeds SET claimed_at=NULL WHERE id=$1 AND claimed_by=$2`
+	if _, err := s.db.Exec(query, feedID, node); err != nil {
+		return fmt.Errorf(`store: unable to release the claim of feed #%d: %v`, feedID, err)
+	}
+	return nil
 }
 
 // NewUserBatch returns a series of jobs but only for a given user.
 func (s *Storage) NewUserBatch(userID int64, batchSize int) (jobs model.JobList, err error) {
-	// We do not take the error counter into consideration when the given
+	// We do not take the error backoff into consideration when the given
 	// user refresh manually all his feeds to force a refresh.
 	query := `
 		SELECT
 			id,
-			user_id
+			user_id,
+			feed_url
 		FROM
 			feeds
 		WHERE
@@ -47,12 +75,13 @@ func (s *Storage) NewUserBatch(userID int64, batchSize int) (jobs model.JobList,
 
 // NewCategoryBatch returns a series of jobs but only for a given category.
 func (s *Storage) NewCategoryBatch(userID int64, categoryID int64, batchSize int) (jobs model.JobList, err error) {
-	// We do not take the error counter into consideration when the given
+	// We do not take the error backoff into consideration when the given
 	// user refresh manually all his feeds to force a refresh.
 	query := `
 		SELECT
 			id,
-			user_id
+			user_id,
+			feed_url
 		FROM
 			feeds
 		WHERE
@@ -71,7 +100,7 @@ func (s *Storage) fetchBatchRows(query string, args ...interface{}) (jobs model.
 
 	for rows.Next() {
 		var job model.Job
-		if err := rows.Scan(&job.FeedID, &job.UserID); err != nil {
+		if err := rows.Scan(&job.FeedID, &job.UserID, &job.FeedURL); err != nil {
 			return nil, fmt.Errorf(`store: unable to fetch job: %v`, err)
 		}
 
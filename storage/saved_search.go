@@ -0,0 +1,215 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package storage // import "miniflux.app/storage"
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"miniflux.app/model"
+)
+
+// SavedSearchExists checks if a saved search with the same title exists.
+func (s *Storage) SavedSearchExists(userID, savedSearchID int64, title string) bool {
+	var result bool
+	query := `SELECT true FROM saved_searches WHERE user_id=$1 AND id != $2 AND lower(title)=lower($3) LIMIT 1`
+	s.db.QueryRow(query, userID, savedSearchID, title).Scan(&result)
+	return result
+}
+
+// SavedSearches returns all saved searches that belongs to the given user.
+func (s *Storage) SavedSearches(userID int64) (model.SavedSearches, error) {
+	query := `
+		SELECT
+			id, user_id, title, query, created_at
+		FROM
+			saved_searches
+		WHERE
+			user_id=$1
+		ORDER BY title ASC
+	`
+	rows, err := s.db.Query(query, userID)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch saved searches: %v`, err)
+	}
+	defer rows.Close()
+
+	savedSearches := make(model.SavedSearches, 0)
+	for rows.Next() {
+		var savedSearch model.SavedSearch
+		if err := rows.Scan(
+			&savedSearch.ID,
+			&savedSearch.UserID,
+			&savedSearch.Title,
+			&savedSearch.Query,
+			&savedSearch.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch saved search row: %v`, err)
+		}
+
+		savedSearches = append(savedSearches, &savedSearch)
+	}
+
+	return savedSearches, nil
+}
+
+// SavedSearchesWithUnreadCount returns the saved searches of the user with their number of unread entries.
+func (s *Storage) SavedSearchesWithUnreadCount(userID int64) (model.SavedSearches, error) {
+	savedSearches, err := s.SavedSearches(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, savedSearch := range savedSearches {
+		builder := s.NewSavedSearchQueryBuilder(savedSearch)
+		builder.WithStatus(model.EntryStatusUnread)
+		if savedSearch.UnreadCount, err = builder.CountEntries(); err != nil {
+			return nil, fmt.Errorf(`store: unable to count the unread entries of saved search #%d: %v`, savedSearch.ID, err)
+		}
+	}
+
+	return savedSearches, nil
+}
+
+// SavedSearch returns a saved search by ID.
+func (s *Storage) SavedSearch(userID, savedSearchID int64) (*model.SavedSearch, error) {
+	query := `
+		SELECT
+			id, user_id, title, query, created_at
+		FROM
+			saved_searches
+		WHERE
+			user_id=$1 AND id=$2
+	`
+	var savedSearch model.SavedSearch
+	err := s.db.QueryRow(query, userID, savedSearchID).Scan(
+		&savedSearch.ID,
+		&savedSearch.UserID,
+		&savedSearch.Title,
+		&savedSearch.Query,
+		&savedSearch.CreatedAt,
+	)
+
+	switch {
+	case err == sql.ErrNoRows:
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf(`store: unable to fetch saved search #%d: %v`, savedSearchID, err)
+	}
+
+	return &savedSearch, nil
+}
+
+// SavedSearchByTitle finds a saved search by the title.
+func (s *Storage) SavedSearchByTitle(userID int64, title string) (*model.SavedSearch, error) {
+	query := `
+		SELECT
+			id, user_id, title, query, created_at
+		FROM
+			saved_searches
+		WHERE
+			user_id=$1 AND lower(title)=lower($2)
+	`
+	var savedSearch model.SavedSearch
+	err := s.db.QueryRow(query, userID, title).Scan(
+		&savedSearch.ID,
+		&savedSearch.UserID,
+		&savedSearch.Title,
+		&savedSearch.Query,
+		&savedSearch.CreatedAt,
+	)
+
+	switch {
+	case err == sql.ErrNoRows:
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf(`store: unable to fetch saved search %q: %v`, title, err)
+	}
+
+	return &savedSearch, nil
+}
+
+// CreateSavedSearch inserts a new saved search.
+func (s *Storage) CreateSavedSearch(savedSearch *model.SavedSearch) error {
+	query := `
+		INSERT INTO saved_searches
+			(user_id, title, query)
+		VALUES
+			($1, $2, $3)
+		RETURNING
+			id, created_at
+	`
+	err := s.db.QueryRow(
+		query,
+		savedSearch.UserID,
+		savedSearch.Title,
+		savedSearch.Query,
+	).Scan(
+		&savedSearch.ID,
+		&savedSearch.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf(`store: unable to create saved search %q: %v`, savedSearch.Title, err)
+	}
+
+	return nil
+}
+
+// UpdateSavedSearch updates an existing saved search.
+func (s *Storage) UpdateSavedSearch(savedSearch *model.SavedSearch) error {
+	query := `UPDATE saved_searches SET title=$1, query=$2 WHERE id=$3 AND user_id=$4`
+	_, err := s.db.Exec(
+		query,
+		savedSearch.Title,
+		savedSearch.Query,
+		savedSearch.ID,
+		savedSearch.UserID,
+	)
+	if err != nil {
+		return fmt.Errorf(`store: unable to update saved search #%d: %v`, savedSearch.ID, err)
+	}
+
+	return nil
+}
+
+// RemoveSavedSearch deletes a saved search.
+func (s *Storage) RemoveSavedSearch(userID, savedSearchID int64) error {
+	query := `DELETE FROM saved_searches WHERE id = $1 AND user_id = $2`
+	_, err := s.db.Exec(query, savedSearchID, userID)
+	if err != nil {
+		return fmt.Errorf(`store: unable to remove saved search #%d: %v`, savedSearchID, err)
+	}
+
+	return nil
+}
+
+// NewSavedSearchQueryBuilder returns a query builder for the entries matching a saved search.
+//
+// The results are computed when the entries are fetched, the removed entries are excluded.
+func (s *Storage) NewSavedSearchQueryBuilder(savedSearch *model.SavedSearch) *EntryQueryBuilder {
+	builder := s.NewEntryQueryBuilder(savedSearch.UserID)
+	builder.WithSearchQuery(savedSearch.Query)
+	builder.WithoutStatus(model.EntryStatusRemoved)
+	return builder
+}
+
+// MarkSavedSearchAsRead marks the unread entries matching a saved search and published before the given date as read.
+func (s *Storage) MarkSavedSearchAsRead(savedSearch *model.SavedSearch, before time.Time) error {
+	builder := s.NewSavedSearchQueryBuilder(savedSearch)
+	builder.WithStatus(model.EntryStatusUnread)
+	builder.BeforeDate(before)
+
+	entryIDs, err := builder.GetEntryIDs()
+	if err != nil {
+		return fmt.Errorf(`store: unable to fetch the unread entries of saved search #%d: %v`, savedSearch.ID, err)
+	}
+
+	if len(entryIDs) == 0 {
+		return nil
+	}
+
+	return s.SetEntriesStatus(savedSearch.UserID, entryIDs, model.EntryStatusRead)
+}
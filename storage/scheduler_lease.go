@@ -0,0 +1,42 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package storage // import "miniflux.app/storage"
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// AcquireSchedulerLease returns true when the given node holds the named lease,
+// which is granted to a single node at a time until it expires.
+//
+// The node holding the lease extends it each time it calls this function.
+func (s *Storage) AcquireSchedulerLease(name, node string, duration time.Duration) (bool, error) {
+	query := `
+		INSERT INTO scheduler_leases
+			(name, node, expires_at)
+		VALUES
+			($1, $2, now() + $3 * interval '1 second')
+		ON CONFLICT (name) DO UPDATE SET
+			node=EXCLUDED.node,
+			expires_at=EXCLUDED.expires_at
+		WHERE
+			scheduler_leases.node=EXCLUDED.node OR scheduler_leases.expires_at < now()
+		RETURNING
+			node
+	`
+
+	var holder string
+	err := s.db.QueryRow(query, name, node, int64(duration.Seconds())).Scan(&holder)
+	switch {
+	case err == sql.ErrNoRows:
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf(`store: unable to acquire scheduler lease %q: %v`, name, err)
+	}
+
+	return holder == node, nil
+}
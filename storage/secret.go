@@ -0,0 +1,41 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package storage // import "miniflux.app/storage"
+
+import (
+	"errors"
+
+	"miniflux.app/config"
+	"miniflux.app/crypto"
+	"miniflux.app/logger"
+)
+
+// encryptSecret encrypts a sensitive value with the configured encryption key before saving it.
+func encryptSecret(value string) ([]byte, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	if !config.Opts.HasEncryptionKey() {
+		return nil, errors.New("no encryption key configured to store the secret")
+	}
+
+	return crypto.Encrypt(config.Opts.EncryptionKey(), []byte(value))
+}
+
+// decryptSecret decrypts a value encrypted by encryptSecret, it returns an empty value when it's not possible.
+func decryptSecret(data []byte) string {
+	if len(data) == 0 {
+		return ""
+	}
+
+	value, err := crypto.Decrypt(config.Opts.EncryptionKey(), data)
+	if err != nil {
+		logger.Error("[Storage] Unable to decrypt secret: %v", err)
+		return ""
+	}
+
+	return string(value)
+}
@@ -0,0 +1,72 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package storage // import "miniflux.app/storage"
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// textSearchConfigurations maps the language codes to the text search configurations shipped with PostgreSQL.
+var textSearchConfigurations = map[string]string{
+	"da": "danish",
+	"de": "german",
+	"en": "english",
+	"es": "spanish",
+	"fi": "finnish",
+	"fr": "french",
+	"hu": "hungarian",
+	"it": "italian",
+	"nb": "norwegian",
+	"nl": "dutch",
+	"nn": "norwegian",
+	"no": "norwegian",
+	"pt": "portuguese",
+	"ro": "romanian",
+	"ru": "russian",
+	"sv": "swedish",
+	"tr": "turkish",
+}
+
+// textSearchConfiguration returns the SQL expression of the text search configuration for a language column or argument.
+//
+// Unknown languages use the default configuration of the database.
+func textSearchConfiguration(language string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "CASE %s", language)
+	for _, code := range sortedKeys(textSearchConfigurations) {
+		fmt.Fprintf(&b, " WHEN '%s' THEN '%s'::regconfig", code, textSearchConfigurations[code])
+	}
+	b.WriteString(" ELSE get_current_ts_config() END")
+	return b.String()
+}
+
+// documentVectors returns the SQL expression of the text search vectors of an entry.
+func documentVectors(title, content, language string) string {
+	configuration := textSearchConfiguration(language)
+	return fmt.Sprintf(
+		"setweight(to_tsvector(%s, left(coalesce(%s, ''), 500000)), 'A') || setweight(to_tsvector(%s, left(coalesce(%s, ''), 500000)), 'B')",
+		configuration, title, configuration, content,
+	)
+}
+
+// textSearchQuery returns the SQL expression of the text search query built by a function
+// like plainto_tsquery or phraseto_tsquery for a search argument.
+//
+// The words are stemmed with the configuration of the entry language, like the vectors of the entry.
+// The simple configuration matches the words as typed when the stemming of the language doesn't.
+func textSearchQuery(function, argument string) string {
+	return fmt.Sprintf("(%[1]s(%[2]s, %[3]s) || %[1]s('simple', %[3]s))", function, textSearchConfiguration("e.language"), argument)
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -90,7 +90,11 @@ func (s *Storage) CreateUser(userCreationRequest *model.UserCreationRequest) (*m
 		    default_reading_speed,
 		    cjk_reading_speed,
 		    default_home_page,
-		    categories_sorting_order
+		    categories_sorting_order,
+		    blocklist_rules,
+		    keeplist_rules,
+		    duplicate_entries,
+		    duplicate_title_matching
 	`
 
 	tx, err := s.db.Begin()
@@ -128,6 +132,10 @@ func (s *Storage) CreateUser(userCreationRequest *model.UserCreationRequest) (*m
 		&user.CJKReadingSpeed,
 		&user.DefaultHomePage,
 		&user.CategoriesSortingOrder,
+		&user.BlocklistRules,
+		&user.KeeplistRules,
+		&user.DuplicateEntries,
+		&user.DuplicateTitleMatching,
 	)
 	if err != nil {
 		tx.Rollback()
@@ -183,9 +191,13 @@ func (s *Storage) UpdateUser(user *model.User) error {
 				default_reading_speed=$18,
 				cjk_reading_speed=$19,
 				default_home_page=$20,
-				categories_sorting_order=$21
+				categories_sorting_order=$21,
+				blocklist_rules=$22,
+				keeplist_rules=$23,
+				duplicate_entries=$24,
+				duplicate_title_matching=$25
 			WHERE
-				id=$22
+				id=$26
 		`
 
 		_, err = s.db.Exec(
@@ -211,6 +223,10 @@ func (s *Storage) UpdateUser(user *model.User) error {
 			user.CJKReadingSpeed,
 			user.DefaultHomePage,
 			user.CategoriesSortingOrder,
+			user.BlocklistRules,
+			user.KeeplistRules,
+			user.DuplicateEntries,
+			user.DuplicateTitleMatching,
 			user.ID,
 		)
 		if err != nil {
@@ -238,9 +254,13 @@ func (s *Storage) UpdateUser(user *model.User) error {
 				default_reading_speed=$17,
 				cjk_reading_speed=$18,
 				default_home_page=$19,
-				categories_sorting_order=$20
+				categories_sorting_order=$20,
+				blocklist_rules=$21,
+				keeplist_rules=$22,
+				duplicate_entries=$23,
+				duplicate_title_matching=$24
 			WHERE
-				id=$21
+				id=$25
 		`
 
 		_, err := s.db.Exec(
@@ -265,6 +285,10 @@ func (s *Storage) UpdateUser(user *model.User) error {
 			user.CJKReadingSpeed,
 			user.DefaultHomePage,
 			user.CategoriesSortingOrder,
+			user.BlocklistRules,
+			user.KeeplistRules,
+			user.DuplicateEntries,
+			user.DuplicateTitleMatching,
 			user.ID,
 		)
 
@@ -311,7 +335,11 @@ func (s *Storage) UserByID(userID int64) (*model.User, error) {
 			default_reading_speed,
 			cjk_reading_speed,
 			default_home_page,
-			categories_sorting_order
+			categories_sorting_order,
+			blocklist_rules,
+			keeplist_rules,
+			duplicate_entries,
+			duplicate_title_matching
 		FROM
 			users
 		WHERE
@@ -345,7 +373,11 @@ func (s *Storage) UserByUsername(username string) (*model.User, error) {
 			default_reading_speed,
 			cjk_reading_speed,
 			default_home_page,
-			categories_sorting_order
+			categories_sorting_order,
+			blocklist_rules,
+			keeplist_rules,
+			duplicate_entries,
+			duplicate_title_matching
 		FROM
 			users
 		WHERE
@@ -379,7 +411,11 @@ func (s *Storage) UserByField(field, value string) (*model.User, error) {
 			default_reading_speed,
 			cjk_reading_speed,
 			default_home_page,
-			categories_sorting_order
+			categories_sorting_order,
+			blocklist_rules,
+			keeplist_rules,
+			duplicate_entries,
+			duplicate_title_matching
 		FROM
 			users
 		WHERE
@@ -420,7 +456,11 @@ func (s *Storage) UserByAPIKey(token string) (*model.User, error) {
 			u.default_reading_speed,
 			u.cjk_reading_speed,
 			u.default_home_page,
-			u.categories_sorting_order
+			u.categories_sorting_order,
+			u.blocklist_rules,
+			u.keeplist_rules,
+			u.duplicate_entries,
+			u.duplicate_title_matching
 		FROM
 			users u
 		LEFT JOIN
@@ -456,6 +496,10 @@ func (s *Storage) fetchUser(query string, args ...interface{}) (*model.User, err
 		&user.CJKReadingSpeed,
 		&user.DefaultHomePage,
 		&user.CategoriesSortingOrder,
+		&user.BlocklistRules,
+		&user.KeeplistRules,
+		&user.DuplicateEntries,
+		&user.DuplicateTitleMatching,
 	)
 
 	if err == sql.ErrNoRows {
@@ -552,7 +596,11 @@ func (s *Storage) Users() (model.Users, error) {
 			default_reading_speed,
 			cjk_reading_speed,
 			default_home_page,
-			categories_sorting_order
+			categories_sorting_order,
+			blocklist_rules,
+			keeplist_rules,
+			duplicate_entries,
+			duplicate_title_matching
 		FROM
 			users
 		ORDER BY username ASC
@@ -589,6 +637,10 @@ func (s *Storage) Users() (model.Users, error) {
 			&user.CJKReadingSpeed,
 			&user.DefaultHomePage,
 			&user.CategoriesSortingOrder,
+			&user.BlocklistRules,
+			&user.KeeplistRules,
+			&user.DuplicateEntries,
+			&user.DuplicateTitleMatching,
 		)
 
 		if err != nil {
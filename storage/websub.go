@@ -0,0 +1,151 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package storage // import "miniflux.app/storage"
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"miniflux.app/model"
+)
+
+const webSubSubscriptionColumns = `feed_id, user_id, hub_url, topic_url, secret, callback_token, state, lease_expires_at`
+
+// WebSubSubscription returns the WebSub subscription of a feed.
+func (s *Storage) WebSubSubscription(feedID int64) (*model.WebSubSubscription, error) {
+	query := `SELECT ` + webSubSubscriptionColumns + ` FROM websub_subscriptions WHERE feed_id=$1`
+	return s.fetchWebSubSubscription(query, feedID)
+}
+
+// WebSubSubscriptionByCallbackToken returns the WebSub subscription that owns the given callback token.
+func (s *Storage) WebSubSubscriptionByCallbackToken(token string) (*model.WebSubSubscription, error) {
+	query := `SELECT ` + webSubSubscriptionColumns + ` FROM websub_subscriptions WHERE callback_token=$1`
+	return s.fetchWebSubSubscription(query, token)
+}
+
+func (s *Storage) fetchWebSubSubscription(query string, arg interface{}) (*model.WebSubSubscription, error) {
+	var subscription model.WebSubSubscription
+	err := s.db.QueryRow(query, arg).Scan(
+		&subscription.FeedID,
+		&subscription.UserID,
+		&subscription.HubURL,
+		&subscription.TopicURL,
+		&subscription.Secret,
+		&subscription.CallbackToken,
+		&subscription.State,
+		&subscription.LeaseExpiresAt,
+	)
+
+	switch {
+	case err == sql.ErrNoRows:
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf(`store: unable to fetch WebSub subscription: %v`, err)
+	}
+
+	return &subscription, nil
+}
+
+// SaveWebSubSubscription creates or replaces the WebSub subscription of a feed.
+func (s *Storage) SaveWebSubSubscription(subscription *model.WebSubSubscription) error {
+	query := `
+		INSERT INTO websub_subscriptions
+			(feed_id, user_id, hub_url, topic_url, secret, callback_token, state, lease_expires_at, requested_at)
+		VALUES
+			($1, $2, $3, $4, $5, $6, $7, $8, now())
+		ON CONFLICT (feed_id) DO UPDATE SET
+			hub_url=EXCLUDED.hub_url,
+			topic_url=EXCLUDED.topic_url,
+			secret=EXCLUDED.secret,
+			callback_token=EXCLUDED.callback_token,
+			state=EXCLUDED.state,
+			lease_expires_at=EXCLUDED.lease_expires_at,
+			requested_at=EXCLUDED.requested_at
+	`
+	_, err := s.db.Exec(
+		query,
+		subscription.FeedID,
+		subscription.UserID,
+		subscription.HubURL,
+		subscription.TopicURL,
+		subscription.Secret,
+		subscription.CallbackToken,
+		subscription.State,
+		subscription.LeaseExpiresAt,
+	)
+	if err != nil {
+		return fmt.Errorf(`store: unable to save WebSub subscription for feed #%d: %v`, subscription.FeedID, err)
+	}
+
+	return nil
+}
+
+// UpdateWebSubSubscriptionState updates the state and the lease of a WebSub subscription.
+func (s *Storage) UpdateWebSubSubscriptionState(feedID int64, state string, leaseExpiresAt *time.Time) error {
+	query := `UPDATE websub_subscriptions SET state=$1, lease_expires_at=$2 WHERE feed_id=$3`
+	if _, err := s.db.Exec(query, state, leaseExpiresAt, feedID); err != nil {
+		return fmt.Errorf(`store: unable to update WebSub subscription for feed #%d: %v`, feedID, err)
+	}
+
+	return nil
+}
+
+// RemoveWebSubSubscription deletes the WebSub subscription of a feed.
+func (s *Storage) RemoveWebSubSubscription(feedID int64) error {
+	if _, err := s.db.Exec(`DELETE FROM websub_subscriptions WHERE feed_id=$1`, feedID); err != nil {
+		return fmt.Errorf(`store: unable to remove WebSub subscription for feed #%d: %v`, feedID, err)
+	}
+
+	return nil
+}
+
+// WebSubSubscriptionsToRenew returns the active subscriptions with a lease expiring before the given date,
+// and the subscriptions never verified by the hub since the given period.
+func (s *Storage) WebSubSubscriptionsToRenew(leaseExpiresBefore time.Time, pendingSince time.Duration) (model.WebSubSubscriptions, error) {
+	query := `
+		SELECT
+			` + webSubSubscriptionColumns + `
+		FROM
+			websub_subscriptions
+		WHERE
+			(state=$1 AND lease_expires_at < $2) OR
+			(state=$3 AND requested_at < now() - $4 * interval '1 second')
+		ORDER BY
+			lease_expires_at ASC NULLS LAST
+	`
+	rows, err := s.db.Query(
+		query,
+		model.WebSubStateActive,
+		leaseExpiresBefore,
+		model.WebSubStatePending,
+		int(pendingSince.Seconds()),
+	)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch WebSub subscriptions to renew: %v`, err)
+	}
+	defer rows.Close()
+
+	subscriptions := make(model.WebSubSubscriptions, 0)
+	for rows.Next() {
+		var subscription model.WebSubSubscription
+		if err := rows.Scan(
+			&subscription.FeedID,
+			&subscription.UserID,
+			&subscription.HubURL,
+			&subscription.TopicURL,
+			&subscription.Secret,
+			&subscription.CallbackToken,
+			&subscription.State,
+			&subscription.LeaseExpiresAt,
+		); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch WebSub subscription row: %v`, err)
+		}
+
+		subscriptions = append(subscriptions, &subscription)
+	}
+
+	return subscriptions, nil
+}
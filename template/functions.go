@@ -60,14 +60,14 @@ func (f *funcMap) Map() template.FuncMap {
 		"noescape": func(str string) template.HTML {
 			return template.HTML(str)
 		},
-		"proxyFilter": func(data string) string {
-			return proxy.ProxyRewriter(f.router, data)
+		"proxyFilter": func(data, proxyName string) string {
+			return proxy.ProxyRewriter(f.router, data, proxyName)
 		},
-		"proxyURL": func(link string) string {
+		"proxyURL": func(link, proxyName string) string {
 			proxyOption := config.Opts.ProxyOption()
 
 			if proxyOption == "all" || (proxyOption != "none" && !url.IsHTTPS(link)) {
-				return proxy.ProxifyURL(f.router, link)
+				return proxy.ProxifyURL(f.router, link, proxyName)
 			}
 
 			return link
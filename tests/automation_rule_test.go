@@ -0,0 +1,136 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+//go:build integration
+// +build integration
+
+package tests
+
+import (
+	"testing"
+
+	miniflux "miniflux.app/client"
+)
+
+func TestCreateAutomationRule(t *testing.T) {
+	client := createClient(t)
+	rule, err := client.CreateAutomationRule(&miniflux.AutomationRuleRequest{
+		Name:   "Star everything",
+		Rules:  "title matches .*",
+		Action: "star",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if rule.ID == 0 {
+		t.Fatalf(`Invalid rule ID, got %d`, rule.ID)
+	}
+
+	if rule.Name != "Star everything" || rule.Action != "star" || rule.Disabled {
+		t.Fatalf(`Unexpected rule: %+v`, rule)
+	}
+
+	rules, err := client.AutomationRules()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(rules) != 1 || rules[0].ID != rule.ID {
+		t.Fatalf(`Unexpected rules: %+v`, rules)
+	}
+}
+
+func TestCreateInvalidAutomationRule(t *testing.T) {
+	client := createClient(t)
+
+	requests := []*miniflux.AutomationRuleRequest{
+		{Name: "", Rules: "title contains go", Action: "star"},
+		{Name: "Invalid rule", Rules: "title contains go and", Action: "star"},
+		{Name: "Invalid action", Rules: "title contains go", Action: "delete"},
+		{Name: "Missing tags", Rules: "title contains go", Action: "add_tags"},
+		{Name: "Invalid integration", Rules: "title contains go", Action: "send_to_integration", Value: "unknown"},
+	}
+
+	for _, request := range requests {
+		if _, err := client.CreateAutomationRule(request); err == nil {
+			t.Errorf(`The rule %q should be refused`, request.Name)
+		}
+	}
+}
+
+func TestUpdateAutomationRule(t *testing.T) {
+	client := createClient(t)
+	rule, err := client.CreateAutomationRule(&miniflux.AutomationRuleRequest{
+		Name:   "Tag entries",
+		Rules:  "title contains go",
+		Action: "add_tags",
+		Value:  "golang",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	updatedRule, err := client.UpdateAutomationRule(rule.ID, &miniflux.AutomationRuleRequest{
+		Name:     "Read later",
+		Rules:    "title contains go",
+		Action:   "read_later",
+		Disabled: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if updatedRule.Name != "Read later" || updatedRule.Action != "read_later" || !updatedRule.Disabled {
+		t.Fatalf(`Unexpected rule: %+v`, updatedRule)
+	}
+}
+
+func TestDeleteAutomationRule(t *testing.T) {
+	client := createClient(t)
+	rule, err := client.CreateAutomationRule(&miniflux.AutomationRuleRequest{
+		Name:   "Mark as read",
+		Rules:  "title contains go",
+		Action: "mark_as_read",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := client.DeleteAutomationRule(rule.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	rules, err := client.AutomationRules()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(rules) != 0 {
+		t.Fatalf(`The rule should be removed, got %+v`, rules)
+	}
+}
+
+func TestAutomationRuleAppliedToNewEntries(t *testing.T) {
+	client := createClient(t)
+	_, err := client.CreateAutomationRule(&miniflux.AutomationRuleRequest{
+		Name:   "Read later",
+		Rules:  "title matches .*",
+		Action: "read_later",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	createFeed(t, client)
+
+	result, err := client.Entries(&miniflux.Filter{ReadLater: miniflux.FilterNotReadLater})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.Total != 0 {
+		t.Fatalf(`All new entries should be in the read later list, got %d other entries`, result.Total)
+	}
+}
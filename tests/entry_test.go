@@ -287,6 +287,88 @@ func TestSearchEntries(t *testing.T) {
 	}
 }
 
+func TestAdvancedSearchEntries(t *testing.T) {
+	client := createClient(t)
+	feed, _ := createFeed(t, client)
+
+	results, err := client.Entries(&miniflux.Filter{Search: "is:starred"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if results.Total != 0 {
+		t.Fatalf(`No entry should be starred, got %d`, results.Total)
+	}
+
+	entries, err := client.FeedEntries(feed.ID, &miniflux.Filter{Limit: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := client.ToggleBookmark(entries.Entries[0].ID); err != nil {
+		t.Fatal(err)
+	}
+
+	results, err = client.Entries(&miniflux.Filter{Search: "is:starred feed:miniflux after:2000"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if results.Total != 1 || results.Entries[0].ID != entries.Entries[0].ID {
+		t.Fatalf(`Only the starred entry should be returned, got %d entries`, results.Total)
+	}
+
+	results, err = client.Entries(&miniflux.Filter{Search: "is:starred -is:starred"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if results.Total != 0 {
+		t.Fatalf(`No entry should be returned, got %d`, results.Total)
+	}
+
+	results, err = client.Entries(&miniflux.Filter{Search: "2.0.8 -2.0.8"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if results.Total != 0 {
+		t.Fatalf(`Excluded terms should not be returned, got %d`, results.Total)
+	}
+}
+
+func TestFilterEntriesByLanguage(t *testing.T) {
+	client := createClient(t)
+	createFeed(t, client)
+
+	result, err := client.Entries(&miniflux.Filter{Limit: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.Entries[0].Language == "" {
+		t.Fatal(`The entry language should be declared by the feed or detected`)
+	}
+
+	results, err := client.Entries(&miniflux.Filter{Language: result.Entries[0].Language})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if results.Total == 0 {
+		t.Fatalf(`Entries in %q should be returned`, result.Entries[0].Language)
+	}
+
+	results, err = client.Entries(&miniflux.Filter{Language: "zz"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if results.Total != 0 {
+		t.Fatalf(`No entry should be returned, got %d`, results.Total)
+	}
+}
+
 func TestInvalidFilters(t *testing.T) {
 	client := createClient(t)
 	createFeed(t, client)
@@ -426,6 +508,64 @@ func TestToggleBookmark(t *testing.T) {
 	}
 }
 
+func TestToggleReadLater(t *testing.T) {
+	client := createClient(t)
+	createFeed(t, client)
+
+	result, err := client.Entries(&miniflux.Filter{Limit: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.Entries[0].ReadLater {
+		t.Fatal("The entry should not be in the read later list")
+	}
+
+	if err := client.ToggleReadLater(result.Entries[0].ID); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err = client.Entries(&miniflux.Filter{ReadLater: miniflux.FilterOnlyReadLater})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.Total != 1 || !result.Entries[0].ReadLater {
+		t.Fatal("The entry should be in the read later list")
+	}
+}
+
+func TestGetEntryRevisions(t *testing.T) {
+	client := createClient(t)
+	createFeed(t, client)
+
+	result, err := client.Entries(&miniflux.Filter{Limit: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.Entries[0].RevisionCount != 0 {
+		t.Fatalf(`Invalid revision count, got %d`, result.Entries[0].RevisionCount)
+	}
+
+	revisions, err := client.EntryRevisions(result.Entries[0].ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(revisions) != 0 {
+		t.Fatalf(`A new entry should not have any revision, got %d`, len(revisions))
+	}
+}
+
+func TestGetRevisionsOfUnknownEntry(t *testing.T) {
+	client := createClient(t)
+
+	if _, err := client.EntryRevisions(123456789); err == nil {
+		t.Fatal(`Getting the revisions of an unknown entry should raise an error`)
+	}
+}
+
 func TestHistoryOrder(t *testing.T) {
 	client := createClient(t)
 	createFeed(t, client)
@@ -451,3 +591,148 @@ func TestHistoryOrder(t *testing.T) {
 		t.Fatal("The entry that we just read should be at the top of the history")
 	}
 }
+
+func TestEntriesCursorPagination(t *testing.T) {
+	client := createClient(t)
+	feed, _ := createFeed(t, client)
+
+	allEntries, err := client.FeedEntries(feed.ID, &miniflux.Filter{Order: "published_at", Direction: "desc"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if allEntries.Total < 3 {
+		t.Fatalf(`The feed should have at least 3 entries, got %d`, allEntries.Total)
+	}
+
+	firstPage, err := client.FeedEntries(feed.ID, &miniflux.Filter{Order: "published_at", Direction: "desc", Limit: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if firstPage.NextCursor == "" || firstPage.PrevCursor != "" {
+		t.Fatalf(`The first page should only have a next cursor, got %q and %q`, firstPage.NextCursor, firstPage.PrevCursor)
+	}
+
+	secondPage, err := client.FeedEntries(feed.ID, &miniflux.Filter{Limit: 2, Cursor: firstPage.NextCursor})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if secondPage.Total != allEntries.Total || secondPage.Entries[0].ID != allEntries.Entries[2].ID {
+		t.Fatalf(`The second page should start with the third entry, got entry #%d`, secondPage.Entries[0].ID)
+	}
+
+	previousPage, err := client.FeedEntries(feed.ID, &miniflux.Filter{Limit: 2, Cursor: secondPage.PrevCursor})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(previousPage.Entries) != 2 || previousPage.Entries[0].ID != firstPage.Entries[0].ID || previousPage.Entries[1].ID != firstPage.Entries[1].ID {
+		t.Fatal(`The previous page should be the first page`)
+	}
+
+	iterator := client.IterateFeedEntries(feed.ID, &miniflux.Filter{Order: "published_at", Direction: "desc", Limit: 2})
+	var count int
+	for iterator.Next() {
+		if iterator.Entry().ID != allEntries.Entries[count].ID {
+			t.Fatalf(`Unexpected entry #%d at position %d`, iterator.Entry().ID, count)
+		}
+		count++
+	}
+
+	if err := iterator.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	if count != allEntries.Total {
+		t.Fatalf(`The iterator should return %d entries, got %d`, allEntries.Total, count)
+	}
+}
+
+func TestEntriesCursorPaginationWithSearch(t *testing.T) {
+	client := createClient(t)
+	feed, _ := createFeed(t, client)
+
+	filter := &miniflux.Filter{Search: "feed:miniflux", Order: "id", Direction: "asc"}
+	allEntries, err := client.FeedEntries(feed.ID, filter)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if allEntries.Total < 3 {
+		t.Fatalf(`The search should return at least 3 entries, got %d`, allEntries.Total)
+	}
+
+	for i := 1; i < len(allEntries.Entries); i++ {
+		if allEntries.Entries[i].ID < allEntries.Entries[i-1].ID {
+			t.Fatal(`The search should keep the requested order`)
+		}
+	}
+
+	filter.Limit = 2
+	iterator := client.IterateFeedEntries(feed.ID, filter)
+	var count int
+	for iterator.Next() {
+		if iterator.Entry().ID != allEntries.Entries[count].ID {
+			t.Fatalf(`Unexpected entry #%d at position %d`, iterator.Entry().ID, count)
+		}
+		count++
+	}
+
+	if err := iterator.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	if count != allEntries.Total {
+		t.Fatalf(`The iterator should return %d entries, got %d`, allEntries.Total, count)
+	}
+}
+
+func TestEntriesFullTextSearchPagination(t *testing.T) {
+	client := createClient(t)
+	feed, _ := createFeed(t, client)
+
+	firstPage, err := client.FeedEntries(feed.ID, &miniflux.Filter{Limit: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.FeedEntries(feed.ID, &miniflux.Filter{Search: "miniflux", Cursor: firstPage.NextCursor}); err == nil {
+		t.Fatal(`The cursor should be refused with a full-text search`)
+	}
+
+	results, err := client.FeedEntries(feed.ID, &miniflux.Filter{Search: "miniflux", Limit: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if results.Total < 2 || results.NextCursor != "" || results.PrevCursor != "" {
+		t.Fatalf(`The full-text search should have several entries and no cursor, got %d entries and %q, %q`, results.Total, results.NextCursor, results.PrevCursor)
+	}
+
+	seen := make(map[int64]bool)
+	iterator := client.IterateFeedEntries(feed.ID, &miniflux.Filter{Search: "miniflux", Limit: 1})
+	for iterator.Next() {
+		if seen[iterator.Entry().ID] {
+			t.Fatalf(`The entry #%d is returned twice`, iterator.Entry().ID)
+		}
+		seen[iterator.Entry().ID] = true
+	}
+
+	if err := iterator.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(seen) != results.Total {
+		t.Fatalf(`The iterator should return %d entries, got %d`, results.Total, len(seen))
+	}
+}
+
+func TestEntriesWithInvalidCursor(t *testing.T) {
+	client := createClient(t)
+
+	if _, err := client.Entries(&miniflux.Filter{Cursor: "invalid"}); err == nil {
+		t.Fatal(`An invalid cursor should be refused`)
+	}
+}
@@ -532,6 +532,225 @@ func TestUpdateFeedUserAgent(t *testing.T) {
 	}
 }
 
+func TestUpdateFeedHeaders(t *testing.T) {
+	client := createClient(t)
+	feed, _ := createFeed(t, client)
+
+	headers := map[string]string{"Authorization": "Bearer token"}
+	updatedFeed, err := client.UpdateFeed(feed.ID, &miniflux.FeedModificationRequest{Headers: &headers})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(updatedFeed.Headers) != 1 || updatedFeed.Headers["Authorization"] != "Bearer token" {
+		t.Fatalf(`Wrong Headers value, got "%v" instead of "%v"`, updatedFeed.Headers, headers)
+	}
+
+	headers = map[string]string{"Invalid Name": "value"}
+	if _, err := client.UpdateFeed(feed.ID, &miniflux.FeedModificationRequest{Headers: &headers}); err == nil {
+		t.Fatal(`Invalid headers should not be accepted`)
+	}
+}
+
+func TestFeedURLHistory(t *testing.T) {
+	client := createClient(t)
+	feed, _ := createFeed(t, client)
+
+	changes, err := client.FeedURLHistory(feed.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(changes) != 0 {
+		t.Fatalf(`The URL history should be empty, got %d changes`, len(changes))
+	}
+
+	newURL := testFeedURL + "?updated"
+	if _, err := client.UpdateFeed(feed.ID, &miniflux.FeedModificationRequest{FeedURL: &newURL}); err != nil {
+		t.Fatal(err)
+	}
+
+	changes, err = client.FeedURLHistory(feed.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(changes) != 1 {
+		t.Fatalf(`The URL history should have one change, got %d changes`, len(changes))
+	}
+
+	if changes[0].PreviousURL != testFeedURL || changes[0].NewURL != newURL || changes[0].Reason != "user" {
+		t.Fatalf(`Wrong URL change, got %+v`, changes[0])
+	}
+}
+
+func TestMergeFeed(t *testing.T) {
+	client := createClient(t)
+	sourceFeed, category := createFeed(t, client)
+
+	previousURL := testFeedURL + "?previous"
+	if _, err := client.UpdateFeed(sourceFeed.ID, &miniflux.FeedModificationRequest{FeedURL: &previousURL}); err != nil {
+		t.Fatal(err)
+	}
+
+	targetFeedID, err := client.CreateFeed(&miniflux.FeedCreationRequest{FeedURL: testFeedURL, CategoryID: category.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := client.MergeFeed(sourceFeed.ID, sourceFeed.ID); err == nil {
+		t.Fatal(`A feed should not be merged into itself`)
+	}
+
+	if err := client.MergeFeed(sourceFeed.ID, targetFeedID); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.Feed(sourceFeed.ID); err != miniflux.ErrNotFound {
+		t.Fatalf(`The merged feed should be removed, got %v`, err)
+	}
+
+	entries, err := client.FeedEntries(targetFeedID, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if entries.Total == 0 {
+		t.Fatal(`The target feed should have entries`)
+	}
+
+	changes, err := client.FeedURLHistory(targetFeedID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(changes) != 2 || changes[0].Reason != "merge" || changes[0].PreviousURL != previousURL {
+		t.Fatalf(`The URL history of the merged feed should be kept, got %+v`, changes)
+	}
+}
+
+func TestUpdateFeedTLSCABundle(t *testing.T) {
+	client := createClient(t)
+	feed, _ := createFeed(t, client)
+
+	bundle := "not a certificate"
+	if _, err := client.UpdateFeed(feed.ID, &miniflux.FeedModificationRequest{TLSCABundle: &bundle}); err == nil {
+		t.Fatal(`Invalid certificate authorities should not be accepted`)
+	}
+
+	key := "not a key"
+	if _, err := client.UpdateFeed(feed.ID, &miniflux.FeedModificationRequest{TLSClientKey: &key}); err == nil {
+		t.Fatal(`Invalid private keys should not be accepted`)
+	}
+
+	bundle = ""
+	updatedFeed, err := client.UpdateFeed(feed.ID, &miniflux.FeedModificationRequest{TLSCABundle: &bundle})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if updatedFeed.TLSCABundle != "" {
+		t.Fatalf(`Wrong TLSCABundle value, got %q instead of an empty value`, updatedFeed.TLSCABundle)
+	}
+}
+
+func TestUpdateFeedProxyName(t *testing.T) {
+	client := createClient(t)
+	feed, _ := createFeed(t, client)
+
+	proxyName := "unknown"
+	if _, err := client.UpdateFeed(feed.ID, &miniflux.FeedModificationRequest{ProxyName: &proxyName}); err == nil {
+		t.Fatal(`Unknown proxies should not be accepted`)
+	}
+
+	proxyName = "direct"
+	updatedFeed, err := client.UpdateFeed(feed.ID, &miniflux.FeedModificationRequest{ProxyName: &proxyName})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if updatedFeed.ProxyName != proxyName {
+		t.Fatalf(`Wrong ProxyName value, got %q instead of %q`, updatedFeed.ProxyName, proxyName)
+	}
+}
+
+func TestUpdateFeedEntryIdentity(t *testing.T) {
+	client := createClient(t)
+	feed, _ := createFeed(t, client)
+
+	if feed.EntryIdentity != "guid" {
+		t.Fatalf(`Wrong default EntryIdentity value, got %q`, feed.EntryIdentity)
+	}
+
+	entryIdentity := "unknown"
+	if _, err := client.UpdateFeed(feed.ID, &miniflux.FeedModificationRequest{EntryIdentity: &entryIdentity}); err == nil {
+		t.Fatal(`Unknown entry identity strategies should not be accepted`)
+	}
+
+	filter := &miniflux.Filter{Order: "id", Direction: "asc", Limit: 1000}
+	results, err := client.FeedEntries(feed.ID, filter)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The cache is ignored to make sure the document is parsed again.
+	entryIdentity = "url_title"
+	ignoreHTTPCache := true
+	updatedFeed, err := client.UpdateFeed(feed.ID, &miniflux.FeedModificationRequest{EntryIdentity: &entryIdentity, IgnoreHTTPCache: &ignoreHTTPCache})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if updatedFeed.EntryIdentity != entryIdentity {
+		t.Fatalf(`Wrong EntryIdentity value, got %q instead of %q`, updatedFeed.EntryIdentity, entryIdentity)
+	}
+
+	if err := client.RefreshFeed(feed.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	refreshedResults, err := client.FeedEntries(feed.ID, filter)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if refreshedResults.Total != results.Total || len(refreshedResults.Entries) != len(results.Entries) {
+		t.Fatalf(`The entries should be rekeyed instead of duplicated, got %d entries instead of %d`, refreshedResults.Total, results.Total)
+	}
+
+	for i, entry := range refreshedResults.Entries {
+		if entry.ID != results.Entries[i].ID {
+			t.Fatalf(`The entry #%d should be kept, got #%d`, results.Entries[i].ID, entry.ID)
+		}
+	}
+}
+
+func TestUpdateFeedRetentionPolicy(t *testing.T) {
+	client := createClient(t)
+	feed, _ := createFeed(t, client)
+
+	maxEntries := -2
+	if _, err := client.UpdateFeed(feed.ID, &miniflux.FeedModificationRequest{RetentionMaxEntries: &maxEntries}); err == nil {
+		t.Fatal(`Invalid retention settings should not be accepted`)
+	}
+
+	maxEntries = 50
+	unreadDays := 2
+	readDays := -1
+	updatedFeed, err := client.UpdateFeed(feed.ID, &miniflux.FeedModificationRequest{
+		RetentionReadDays:   &readDays,
+		RetentionUnreadDays: &unreadDays,
+		RetentionMaxEntries: &maxEntries,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if updatedFeed.RetentionReadDays != readDays || updatedFeed.RetentionUnreadDays != unreadDays || updatedFeed.RetentionMaxEntries != maxEntries {
+		t.Fatalf(`Wrong retention settings, got %d, %d and %d`, updatedFeed.RetentionReadDays, updatedFeed.RetentionUnreadDays, updatedFeed.RetentionMaxEntries)
+	}
+}
+
 func TestUpdateFeedCookie(t *testing.T) {
 	client := createClient(t)
 	feed, _ := createFeed(t, client)
@@ -725,6 +944,78 @@ func TestRefreshFeed(t *testing.T) {
 	}
 }
 
+func TestGetFeedHistory(t *testing.T) {
+	client := createClient(t)
+	feed, _ := createFeed(t, client)
+	if err := client.RefreshFeed(feed.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	refreshes, err := client.FeedHistory(feed.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(refreshes) != 1 {
+		t.Fatalf(`Invalid number of refreshes, got %d`, len(refreshes))
+	}
+
+	if refreshes[0].FeedID != feed.ID {
+		t.Fatalf(`Invalid feed ID, got %d instead of %d`, refreshes[0].FeedID, feed.ID)
+	}
+
+	if refreshes[0].Error != "" {
+		t.Fatalf(`The refresh should not have failed, got %q`, refreshes[0].Error)
+	}
+}
+
+func TestGetFeedHistoryNotFound(t *testing.T) {
+	client := createClient(t)
+	if _, err := client.FeedHistory(42); err == nil {
+		t.Fatalf(`The feed history should not be found`)
+	}
+}
+
+func TestPreviewFeed(t *testing.T) {
+	client := createClient(t)
+	feed, _ := createFeed(t, client)
+
+	blocklistRules := ".*"
+	preview, err := client.PreviewFeed(feed.ID, &miniflux.FeedPreviewRequest{BlocklistRules: &blocklistRules})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(preview.Entries) == 0 {
+		t.Fatalf(`The preview should list the entries of the feed`)
+	}
+
+	for _, entry := range preview.Entries {
+		if !entry.Blocked || entry.BlocklistRule != blocklistRules {
+			t.Fatalf(`The entry %q should be blocked by the rule %q`, entry.URL, blocklistRules)
+		}
+	}
+
+	updatedFeed, err := client.Feed(feed.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if updatedFeed.BlocklistRules != "" {
+		t.Fatalf(`The preview rules should not be saved, got %q`, updatedFeed.BlocklistRules)
+	}
+}
+
+func TestPreviewFeedWithInvalidRule(t *testing.T) {
+	client := createClient(t)
+	feed, _ := createFeed(t, client)
+
+	blocklistRules := "["
+	if _, err := client.PreviewFeed(feed.ID, &miniflux.FeedPreviewRequest{BlocklistRules: &blocklistRules}); err == nil {
+		t.Fatalf(`Invalid rules should not be accepted`)
+	}
+}
+
 func TestGetFeed(t *testing.T) {
 	client := createClient(t)
 	feed, category := createFeed(t, client)
@@ -0,0 +1,192 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+//go:build integration
+// +build integration
+
+package tests
+
+import (
+	"database/sql"
+	"os"
+	"testing"
+	"time"
+
+	"miniflux.app/database"
+	"miniflux.app/storage"
+)
+
+const testBatchSize = 10000
+
+func createStorage(t *testing.T) (*sql.DB, *storage.Storage) {
+	db, err := database.NewConnectionPool(os.Getenv("DATABASE_URL"), 1, 5, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db, storage.NewStorage(db)
+}
+
+func createDueFeed(t *testing.T, db *sql.DB) int64 {
+	client := createClient(t)
+	feed, _ := createFeed(t, client)
+
+	_, err := db.Exec(`UPDATE feeds SET next_check_at=now() - interval '1 hour', claimed_by='', claimed_at=NULL WHERE id=$1`, feed.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return feed.ID
+}
+
+func batchContains(t *testing.T, store *storage.Storage, node string, claimTimeout time.Duration, feedID int64) bool {
+	jobs, err := store.NewBatch(testBatchSize, node, claimTimeout)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, job := range jobs {
+		if job.FeedID == feedID {
+			return true
+		}
+	}
+	return false
+}
+
+func TestNewBatchClaimsFeedOnce(t *testing.T) {
+	db, store := createStorage(t)
+	feedID := createDueFeed(t, db)
+
+	if !batchContains(t, store, "node-a", time.Hour, feedID) {
+		t.Fatal(`The due feed should be claimed`)
+	}
+
+	var claimedBy string
+	if err := db.QueryRow(`SELECT claimed_by FROM feeds WHERE id=$1`, feedID).Scan(&claimedBy); err != nil {
+		t.Fatal(err)
+	}
+
+	if claimedBy != "node-a" {
+		t.Fatalf(`The feed should be claimed by node-a, got %q`, claimedBy)
+	}
+
+	if batchContains(t, store, "node-b", time.Hour, feedID) {
+		t.Fatal(`A claimed feed should not be given to another node`)
+	}
+
+	if batchContains(t, store, "node-a", time.Hour, feedID) {
+		t.Fatal(`A claimed feed should not be given again to the same node`)
+	}
+}
+
+func TestNewBatchReclaimsExpiredClaims(t *testing.T) {
+	db, store := createStorage(t)
+	feedID := createDueFeed(t, db)
+
+	_, err := db.Exec(`UPDATE feeds SET claimed_by='node-a', claimed_at=now() - interval '2 hours' WHERE id=$1`, feedID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !batchContains(t, store, "node-b", time.Hour, feedID) {
+		t.Fatal(`An expired claim should be taken over by another node`)
+	}
+
+	var claimedBy string
+	if err := db.QueryRow(`SELECT claimed_by FROM feeds WHERE id=$1`, feedID).Scan(&claimedBy); err != nil {
+		t.Fatal(err)
+	}
+
+	if claimedBy != "node-b" {
+		t.Fatalf(`The feed should be claimed by node-b, got %q`, claimedBy)
+	}
+}
+
+func TestNewBatchSkipsLockedFeeds(t *testing.T) {
+	db, store := createStorage(t)
+	feedID := createDueFeed(t, db)
+
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`SELECT id FROM feeds WHERE id=$1 FOR UPDATE`, feedID); err != nil {
+		t.Fatal(err)
+	}
+
+	if batchContains(t, store, "node-a", time.Hour, feedID) {
+		t.Fatal(`A feed locked by another transaction should be skipped`)
+	}
+
+	if err := tx.Rollback(); err != nil {
+		t.Fatal(err)
+	}
+
+	if !batchContains(t, store, "node-a", time.Hour, feedID) {
+		t.Fatal(`The feed should be claimed once the lock is released`)
+	}
+}
+
+func TestReleaseFeedClaim(t *testing.T) {
+	db, store := createStorage(t)
+	feedID := createDueFeed(t, db)
+
+	if !batchContains(t, store, "node-a", time.Hour, feedID) {
+		t.Fatal(`The due feed should be claimed`)
+	}
+
+	if err := store.ReleaseFeedClaim(feedID, "node-b"); err != nil {
+		t.Fatal(err)
+	}
+
+	if batchContains(t, store, "node-b", time.Hour, feedID) {
+		t.Fatal(`A node should not release the claim of another node`)
+	}
+
+	if err := store.ReleaseFeedClaim(feedID, "node-a"); err != nil {
+		t.Fatal(err)
+	}
+
+	if !batchContains(t, store, "node-b", time.Hour, feedID) {
+		t.Fatal(`A released feed should be claimed again while it's due`)
+	}
+}
+
+func TestAcquireSchedulerLease(t *testing.T) {
+	db, store := createStorage(t)
+	name := "test-" + getRandomUsername()
+
+	acquire := func(node string) bool {
+		acquired, err := store.AcquireSchedulerLease(name, node, time.Hour)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return acquired
+	}
+
+	if !acquire("node-a") {
+		t.Fatal(`The first node should acquire the lease`)
+	}
+
+	if acquire("node-b") {
+		t.Fatal(`The lease should not be given to another node before it expires`)
+	}
+
+	if !acquire("node-a") {
+		t.Fatal(`The holder should be able to renew the lease`)
+	}
+
+	if _, err := db.Exec(`UPDATE scheduler_leases SET expires_at=now() - interval '1 second' WHERE name=$1`, name); err != nil {
+		t.Fatal(err)
+	}
+
+	if !acquire("node-b") {
+		t.Fatal(`An expired lease should be handed over to another node`)
+	}
+
+	if acquire("node-a") {
+		t.Fatal(`The previous holder should not get the lease back`)
+	}
+}
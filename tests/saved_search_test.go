@@ -0,0 +1,152 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+//go:build integration
+// +build integration
+
+package tests
+
+import (
+	"testing"
+
+	miniflux "miniflux.app/client"
+)
+
+func TestCreateSavedSearch(t *testing.T) {
+	client := createClient(t)
+	createFeed(t, client)
+
+	savedSearch, err := client.CreateSavedSearch(&miniflux.SavedSearchRequest{Title: "Miniflux", Query: "feed:miniflux"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if savedSearch.ID == 0 || savedSearch.Title != "Miniflux" || savedSearch.Query != "feed:miniflux" {
+		t.Fatalf(`Unexpected saved search: %+v`, savedSearch)
+	}
+
+	savedSearches, err := client.SavedSearches()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(savedSearches) != 1 || savedSearches[0].ID != savedSearch.ID {
+		t.Fatalf(`Unexpected saved searches: %+v`, savedSearches)
+	}
+
+	if savedSearches[0].UnreadCount == 0 {
+		t.Fatal(`The saved search should have unread entries`)
+	}
+}
+
+func TestCreateInvalidSavedSearch(t *testing.T) {
+	client := createClient(t)
+
+	if _, err := client.CreateSavedSearch(&miniflux.SavedSearchRequest{Title: "Miniflux", Query: ""}); err == nil {
+		t.Fatal(`A saved search without query should be refused`)
+	}
+
+	if _, err := client.CreateSavedSearch(&miniflux.SavedSearchRequest{Title: "Miniflux", Query: "go"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.CreateSavedSearch(&miniflux.SavedSearchRequest{Title: "miniflux", Query: "golang"}); err == nil {
+		t.Fatal(`Duplicate saved search titles should be refused`)
+	}
+}
+
+func TestSavedSearchAndCategoryTitlesAreDistinct(t *testing.T) {
+	client := createClient(t)
+
+	if _, err := client.CreateCategory("News"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.CreateSavedSearch(&miniflux.SavedSearchRequest{Title: "news", Query: "go"}); err == nil {
+		t.Fatal(`A saved search should not have the title of a category`)
+	}
+
+	if _, err := client.CreateSavedSearch(&miniflux.SavedSearchRequest{Title: "Golang", Query: "go"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.CreateCategory("golang"); err == nil {
+		t.Fatal(`A category should not have the title of a saved search`)
+	}
+}
+
+func TestUpdateAndRemoveSavedSearch(t *testing.T) {
+	client := createClient(t)
+	savedSearch, err := client.CreateSavedSearch(&miniflux.SavedSearchRequest{Title: "Go", Query: "go"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	updatedSavedSearch, err := client.UpdateSavedSearch(savedSearch.ID, &miniflux.SavedSearchRequest{Title: "Starred", Query: "is:starred"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if updatedSavedSearch.Title != "Starred" || updatedSavedSearch.Query != "is:starred" {
+		t.Fatalf(`Unexpected saved search: %+v`, updatedSavedSearch)
+	}
+
+	if err := client.DeleteSavedSearch(savedSearch.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	savedSearches, err := client.SavedSearches()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(savedSearches) != 0 {
+		t.Fatalf(`The saved search should be removed, got %+v`, savedSearches)
+	}
+}
+
+func TestSavedSearchEntriesAndMarkAsRead(t *testing.T) {
+	client := createClient(t)
+	feed, _ := createFeed(t, client)
+
+	savedSearch, err := client.CreateSavedSearch(&miniflux.SavedSearchRequest{Title: "Miniflux", Query: "feed:miniflux"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	feedEntries, err := client.FeedEntries(feed.ID, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := client.SavedSearchEntries(savedSearch.ID, &miniflux.Filter{Status: miniflux.EntryStatusUnread})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if results.Total != feedEntries.Total {
+		t.Fatalf(`The saved search should return %d entries, got %d`, feedEntries.Total, results.Total)
+	}
+
+	if err := client.MarkSavedSearchAsRead(savedSearch.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	results, err = client.SavedSearchEntries(savedSearch.ID, &miniflux.Filter{Status: miniflux.EntryStatusUnread})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if results.Total != 0 {
+		t.Fatalf(`All entries should be marked as read, got %d unread entries`, results.Total)
+	}
+}
+
+func TestGetEntriesOfUnknownSavedSearch(t *testing.T) {
+	client := createClient(t)
+
+	if _, err := client.SavedSearchEntries(12345, nil); err != miniflux.ErrNotFound {
+		t.Fatalf(`An unknown saved search should return a not found error, got %v`, err)
+	}
+}
@@ -375,6 +375,108 @@ func TestUpdateUserFields(t *testing.T) {
 	}
 }
 
+func TestUpdateUserFilterRules(t *testing.T) {
+	username := getRandomUsername()
+	client := miniflux.New(testBaseURL, testAdminUsername, testAdminPassword)
+	user, err := client.CreateUser(username, testStandardPassword, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	blocklistRules := "#!rules\ntitle contains football\ntitle matches sponsored"
+	user, err = client.UpdateUser(user.ID, &miniflux.UserModificationRequest{BlocklistRules: &blocklistRules})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if user.BlocklistRules != blocklistRules {
+		t.Fatalf(`Unable to update user BlocklistRules: got %q instead of %q`, user.BlocklistRules, blocklistRules)
+	}
+
+	filterRules, err := client.UserFilterRules(user.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(filterRules) != 2 || filterRules[0].Rule != "title contains football" || filterRules[0].List != "blocklist" || filterRules[0].Matches != 0 {
+		t.Fatalf(`Unexpected filter rules: %+v`, filterRules)
+	}
+
+	keeplistRules := "#!rules\ntitle contains \"football"
+	if _, err := client.UpdateUser(user.ID, &miniflux.UserModificationRequest{KeeplistRules: &keeplistRules}); err == nil {
+		t.Fatal(`Invalid keeplist rules should be rejected`)
+	}
+}
+
+func TestFilterRuleMatchesAreCountedOnce(t *testing.T) {
+	client := createClient(t)
+	user, err := client.Me()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	blocklistRules := "(?i)."
+	if _, err := client.UpdateUser(user.ID, &miniflux.UserModificationRequest{BlocklistRules: &blocklistRules}); err != nil {
+		t.Fatal(err)
+	}
+
+	feed, _ := createFeed(t, client)
+	filterRules, err := client.UserFilterRules(user.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(filterRules) != 1 || filterRules[0].Matches == 0 {
+		t.Fatalf(`The blocked entries should be counted: %+v`, filterRules)
+	}
+	matches := filterRules[0].Matches
+
+	if err := client.RefreshFeed(feed.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	filterRules, err = client.UserFilterRules(user.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if filterRules[0].Matches != matches {
+		t.Fatalf(`The entries blocked again should not be counted twice, got %d instead of %d`, filterRules[0].Matches, matches)
+	}
+}
+
+func TestUpdateUserDuplicateEntries(t *testing.T) {
+	username := getRandomUsername()
+	client := miniflux.New(testBaseURL, testAdminUsername, testAdminPassword)
+	user, err := client.CreateUser(username, testStandardPassword, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if user.DuplicateEntries != "keep" || user.DuplicateTitleMatching {
+		t.Fatalf(`Unexpected default duplicate entries options: %q, %v`, user.DuplicateEntries, user.DuplicateTitleMatching)
+	}
+
+	duplicateEntries := "collapse"
+	duplicateTitleMatching := true
+	user, err = client.UpdateUser(user.ID, &miniflux.UserModificationRequest{
+		DuplicateEntries:       &duplicateEntries,
+		DuplicateTitleMatching: &duplicateTitleMatching,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if user.DuplicateEntries != duplicateEntries || !user.DuplicateTitleMatching {
+		t.Fatalf(`Unable to update the duplicate entries options: %q, %v`, user.DuplicateEntries, user.DuplicateTitleMatching)
+	}
+
+	duplicateEntries = "delete"
+	if _, err := client.UpdateUser(user.ID, &miniflux.UserModificationRequest{DuplicateEntries: &duplicateEntries}); err == nil {
+		t.Fatal(`Invalid duplicate entries option should be rejected`)
+	}
+}
+
 func TestUpdateUserThemeWithInvalidValue(t *testing.T) {
 	username := getRandomUsername()
 	client := miniflux.New(testBaseURL, testAdminUsername, testAdminPassword)
@@ -0,0 +1,56 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package ui // import "miniflux.app/ui"
+
+import (
+	"net/http"
+
+	"miniflux.app/http/request"
+	"miniflux.app/http/response/html"
+	"miniflux.app/integration"
+	"miniflux.app/model"
+	"miniflux.app/ui/form"
+	"miniflux.app/ui/session"
+	"miniflux.app/ui/view"
+)
+
+func (h *handler) showEditAutomationRulePage(w http.ResponseWriter, r *http.Request) {
+	sess := session.New(h.store, request.SessionID(r))
+	view := view.New(h.tpl, r, sess)
+
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	automationRule, err := h.store.AutomationRule(user.ID, request.RouteInt64Param(r, "ruleID"))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if automationRule == nil {
+		html.NotFound(w, r)
+		return
+	}
+
+	view.Set("form", &form.AutomationRuleForm{
+		Name:     automationRule.Name,
+		Rules:    automationRule.Rules,
+		Action:   automationRule.Action,
+		Value:    automationRule.Value,
+		Disabled: automationRule.Disabled,
+	})
+	view.Set("automationRule", automationRule)
+	view.Set("automationActions", model.AutomationActions())
+	view.Set("integrationNames", integration.SavingIntegrations())
+	view.Set("menu", "settings")
+	view.Set("user", user)
+	view.Set("countUnread", h.store.CountUnreadEntries(user.ID))
+	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID))
+
+	html.OK(w, r, view.Render("edit_automation_rule"))
+}
@@ -0,0 +1,58 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package ui // import "miniflux.app/ui"
+
+import (
+	"net/http"
+
+	"miniflux.app/http/request"
+	"miniflux.app/http/response/html"
+	"miniflux.app/http/route"
+	"miniflux.app/integration"
+	"miniflux.app/logger"
+	"miniflux.app/model"
+	"miniflux.app/ui/form"
+	"miniflux.app/ui/session"
+	"miniflux.app/ui/view"
+	"miniflux.app/validator"
+)
+
+func (h *handler) saveAutomationRule(w http.ResponseWriter, r *http.Request) {
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	automationRuleForm := form.NewAutomationRuleForm(r)
+
+	sess := session.New(h.store, request.SessionID(r))
+	view := view.New(h.tpl, r, sess)
+	view.Set("form", automationRuleForm)
+	view.Set("automationActions", model.AutomationActions())
+	view.Set("integrationNames", integration.SavingIntegrations())
+	view.Set("menu", "settings")
+	view.Set("user", user)
+	view.Set("countUnread", h.store.CountUnreadEntries(user.ID))
+	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID))
+
+	ruleRequest := automationRuleForm.Request()
+	if validationErr := validator.ValidateAutomationRule(h.store, user.ID, 0, ruleRequest); validationErr != nil {
+		view.Set("errorMessage", validationErr.Localized())
+		html.OK(w, r, view.Render("create_automation_rule"))
+		return
+	}
+
+	automationRule := &model.AutomationRule{UserID: user.ID}
+	ruleRequest.Patch(automationRule)
+	if err := h.store.CreateAutomationRule(automationRule); err != nil {
+		logger.Error("[UI:SaveAutomationRule] %v", err)
+		view.Set("errorMessage", "error.unable_to_create_automation_rule")
+		html.OK(w, r, view.Render("create_automation_rule"))
+		return
+	}
+
+	html.Redirect(w, r, route.Path(h.router, "automationRules"))
+}
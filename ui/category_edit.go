@@ -7,6 +7,7 @@ package ui // import "miniflux.app/ui"
 import (
 	"net/http"
 
+	"miniflux.app/config"
 	"miniflux.app/http/request"
 	"miniflux.app/http/response/html"
 	"miniflux.app/ui/form"
@@ -37,8 +38,12 @@ func (h *handler) showEditCategoryPage(w http.ResponseWriter, r *http.Request) {
 	}
 
 	categoryForm := form.CategoryForm{
-		Title:        category.Title,
-		HideGlobally: "",
+		Title:               category.Title,
+		HideGlobally:        "",
+		ProxyName:           category.ProxyName,
+		RetentionReadDays:   category.RetentionReadDays,
+		RetentionUnreadDays: category.RetentionUnreadDays,
+		RetentionMaxEntries: category.RetentionMaxEntries,
 	}
 	if category.HideGlobally {
 		categoryForm.HideGlobally = "checked"
@@ -46,6 +51,7 @@ func (h *handler) showEditCategoryPage(w http.ResponseWriter, r *http.Request) {
 
 	view.Set("form", categoryForm)
 	view.Set("category", category)
+	view.Set("proxyNames", config.Opts.HTTPClientProxyNames())
 	view.Set("menu", "categories")
 	view.Set("user", user)
 	view.Set("countUnread", h.store.CountUnreadEntries(user.ID))
@@ -42,6 +42,7 @@ func (h *handler) showCategoryEntriesPage(w http.ResponseWriter, r *http.Request
 	builder.WithStatus(model.EntryStatusUnread)
 	builder.WithOffset(offset)
 	builder.WithLimit(user.EntriesPerPage)
+	collapseDuplicates(builder, user)
 
 	entries, err := builder.GetEntries()
 	if err != nil {
@@ -49,6 +50,12 @@ func (h *handler) showCategoryEntriesPage(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	duplicateCopies, err := h.duplicateCopies(user, entries)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
 	count, err := builder.CountEntries()
 	if err != nil {
 		html.ServerError(w, r, err)
@@ -60,6 +67,7 @@ func (h *handler) showCategoryEntriesPage(w http.ResponseWriter, r *http.Request
 	view.Set("category", category)
 	view.Set("total", count)
 	view.Set("entries", entries)
+	view.Set("duplicateCopies", duplicateCopies)
 	view.Set("pagination", getPagination(route.Path(h.router, "categoryEntries", "categoryID", category.ID), count, offset, user.EntriesPerPage))
 	view.Set("menu", "categories")
 	view.Set("user", user)
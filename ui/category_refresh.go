@@ -10,6 +10,7 @@ import (
 	"miniflux.app/http/request"
 	"miniflux.app/http/response/html"
 	"miniflux.app/http/route"
+	"miniflux.app/worker"
 )
 
 func (h *handler) refreshCategoryEntriesPage(w http.ResponseWriter, r *http.Request) {
@@ -32,9 +33,7 @@ func (h *handler) refreshCategory(w http.ResponseWriter, r *http.Request) int64
 		return 0
 	}
 
-	go func() {
-		h.pool.Push(jobs)
-	}()
+	h.pool.Push(jobs, worker.PriorityInteractive)
 
 	return categoryID
 }
@@ -7,6 +7,7 @@ package ui // import "miniflux.app/ui"
 import (
 	"net/http"
 
+	"miniflux.app/config"
 	"miniflux.app/http/request"
 	"miniflux.app/http/response/html"
 	"miniflux.app/http/route"
@@ -43,14 +44,19 @@ func (h *handler) updateCategory(w http.ResponseWriter, r *http.Request) {
 	view := view.New(h.tpl, r, sess)
 	view.Set("form", categoryForm)
 	view.Set("category", category)
+	view.Set("proxyNames", config.Opts.HTTPClientProxyNames())
 	view.Set("menu", "categories")
 	view.Set("user", loggedUser)
 	view.Set("countUnread", h.store.CountUnreadEntries(loggedUser.ID))
 	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(loggedUser.ID))
 
 	categoryRequest := &model.CategoryRequest{
-		Title:        categoryForm.Title,
-		HideGlobally: categoryForm.HideGlobally,
+		Title:               categoryForm.Title,
+		HideGlobally:        categoryForm.HideGlobally,
+		ProxyName:           categoryForm.ProxyName,
+		RetentionReadDays:   categoryForm.RetentionReadDays,
+		RetentionUnreadDays: categoryForm.RetentionUnreadDays,
+		RetentionMaxEntries: categoryForm.RetentionMaxEntries,
 	}
 
 	if validationErr := validator.ValidateCategoryModification(h.store, loggedUser.ID, category.ID, categoryRequest); validationErr != nil {
@@ -0,0 +1,51 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package ui // import "miniflux.app/ui"
+
+import (
+	"miniflux.app/model"
+	"miniflux.app/storage"
+)
+
+// collapseDuplicates hides the copies of entries already published in another feed, when the user wants them collapsed.
+func collapseDuplicates(builder *storage.EntryQueryBuilder, user *model.User) {
+	if user.DuplicateEntries == model.DuplicateEntriesCollapse {
+		builder.WithoutDuplicateCopies()
+	}
+}
+
+// duplicateCopies returns the hidden copies of the entries, grouped by duplicate group.
+func (h *handler) duplicateCopies(user *model.User, entries model.Entries) (map[int64]model.Entries, error) {
+	copies := make(map[int64]model.Entries)
+	if user.DuplicateEntries != model.DuplicateEntriesCollapse {
+		return copies, nil
+	}
+
+	var groupIDs []int64
+	for _, entry := range entries {
+		if entry.DuplicateGroupID != 0 {
+			groupIDs = append(groupIDs, entry.DuplicateGroupID)
+		}
+	}
+
+	if len(groupIDs) == 0 {
+		return copies, nil
+	}
+
+	builder := h.store.NewEntryQueryBuilder(user.ID)
+	builder.WithDuplicateCopiesOf(groupIDs)
+	builder.WithoutStatus(model.EntryStatusRemoved)
+	builder.WithOrder(model.DefaultSortingOrder)
+	builder.WithDirection("asc")
+	duplicates, err := builder.GetEntries()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, duplicate := range duplicates {
+		copies[duplicate.DuplicateGroupID] = append(copies[duplicate.DuplicateGroupID], duplicate)
+	}
+	return copies, nil
+}
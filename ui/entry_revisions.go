@@ -0,0 +1,82 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package ui // import "miniflux.app/ui"
+
+import (
+	"net/http"
+	"time"
+
+	"miniflux.app/diff"
+	"miniflux.app/http/request"
+	"miniflux.app/http/response/html"
+	"miniflux.app/model"
+	"miniflux.app/reader/sanitizer"
+	"miniflux.app/ui/session"
+	"miniflux.app/ui/view"
+)
+
+// entryChange is the difference between a revision and the version that replaced it.
+type entryChange struct {
+	CreatedAt time.Time
+	URL       string
+	Title     string
+	Content   string
+}
+
+func (h *handler) showEntryRevisionsPage(w http.ResponseWriter, r *http.Request) {
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	builder := h.store.NewEntryQueryBuilder(user.ID)
+	builder.WithEntryID(request.RouteInt64Param(r, "entryID"))
+	builder.WithoutStatus(model.EntryStatusRemoved)
+
+	entry, err := builder.GetEntry()
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if entry == nil {
+		html.NotFound(w, r)
+		return
+	}
+
+	revisions, err := h.store.EntryRevisions(user.ID, entry.ID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	// The revisions are sorted from the most recent, each one is compared to
+	// the version that replaced it, starting with the current entry.
+	changes := make([]entryChange, 0, len(revisions))
+	title, url, content := entry.Title, entry.URL, sanitizer.StripTags(entry.Content)
+	for _, revision := range revisions {
+		revisionContent := sanitizer.StripTags(revision.Content)
+		changes = append(changes, entryChange{
+			CreatedAt: revision.CreatedAt,
+			URL:       url,
+			Title:     diff.HTML(revision.Title, title),
+			Content:   diff.HTML(revisionContent, content),
+		})
+
+		title, url, content = revision.Title, revision.URL, revisionContent
+	}
+
+	sess := session.New(h.store, request.SessionID(r))
+	view := view.New(h.tpl, r, sess)
+	view.Set("entry", entry)
+	view.Set("changes", changes)
+	view.Set("menu", "feeds")
+	view.Set("user", user)
+	view.Set("countUnread", h.store.CountUnreadEntries(user.ID))
+	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID))
+
+	html.OK(w, r, view.Render("entry_revisions"))
+}
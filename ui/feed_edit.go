@@ -10,11 +10,15 @@ import (
 	"miniflux.app/config"
 	"miniflux.app/http/request"
 	"miniflux.app/http/response/html"
+	"miniflux.app/model"
 	"miniflux.app/ui/form"
 	"miniflux.app/ui/session"
 	"miniflux.app/ui/view"
 )
 
+// feedRefreshHistorySize is the number of refresh attempts displayed on the edit page.
+const feedRefreshHistorySize = 20
+
 func (h *handler) showEditFeedPage(w http.ResponseWriter, r *http.Request) {
 	user, err := h.store.UserByID(request.UserID(r))
 	if err != nil {
@@ -40,6 +44,18 @@ func (h *handler) showEditFeedPage(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	refreshes, err := h.store.FeedRefreshes(user.ID, feedID, feedRefreshHistorySize)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	urlChanges, err := h.store.FeedURLChanges(user.ID, feedID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
 	feedForm := form.FeedForm{
 		SiteURL:                     feed.SiteURL,
 		FeedURL:                     feed.FeedURL,
@@ -52,12 +68,20 @@ func (h *handler) showEditFeedPage(w http.ResponseWriter, r *http.Request) {
 		Crawler:                     feed.Crawler,
 		UserAgent:                   feed.UserAgent,
 		Cookie:                      feed.Cookie,
+		Headers:                     feed.Headers.String(),
+		TLSClientCertificate:        feed.TLSClientCertificate,
+		TLSCABundle:                 feed.TLSCABundle,
 		CategoryID:                  feed.Category.ID,
 		Username:                    feed.Username,
 		Password:                    feed.Password,
 		IgnoreHTTPCache:             feed.IgnoreHTTPCache,
 		AllowSelfSignedCertificates: feed.AllowSelfSignedCertificates,
 		FetchViaProxy:               feed.FetchViaProxy,
+		ProxyName:                   feed.ProxyName,
+		EntryIdentity:               feed.EntryIdentity,
+		RetentionReadDays:           feed.RetentionReadDays,
+		RetentionUnreadDays:         feed.RetentionUnreadDays,
+		RetentionMaxEntries:         feed.RetentionMaxEntries,
 		Disabled:                    feed.Disabled,
 		NoMediaPlayer:               feed.NoMediaPlayer,
 		HideGlobally:                feed.HideGlobally,
@@ -69,12 +93,19 @@ func (h *handler) showEditFeedPage(w http.ResponseWriter, r *http.Request) {
 	view.Set("form", feedForm)
 	view.Set("categories", categories)
 	view.Set("feed", feed)
+	view.Set("refreshes", refreshes)
+	view.Set("urlChanges", urlChanges)
+	if feed.MovedToURL != "" {
+		view.Set("movedToFeedID", h.store.FeedIDByURL(user.ID, feed.MovedToURL))
+	}
 	view.Set("menu", "feeds")
 	view.Set("user", user)
 	view.Set("countUnread", h.store.CountUnreadEntries(user.ID))
 	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID))
 	view.Set("defaultUserAgent", config.Opts.HTTPClientUserAgent())
 	view.Set("hasProxyConfigured", config.Opts.HasHTTPClientProxyConfigured())
+	view.Set("proxyNames", config.Opts.HTTPClientProxyNames())
+	view.Set("entryIdentities", model.EntryIdentities())
 
 	html.OK(w, r, view.Render("edit_feed"))
 }
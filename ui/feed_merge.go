@@ -0,0 +1,31 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package ui // import "miniflux.app/ui"
+
+import (
+	"net/http"
+
+	"miniflux.app/http/request"
+	"miniflux.app/http/response/html"
+	"miniflux.app/http/route"
+)
+
+func (h *handler) mergeFeed(w http.ResponseWriter, r *http.Request) {
+	userID := request.UserID(r)
+	feedID := request.RouteInt64Param(r, "feedID")
+	targetFeedID := request.RouteInt64Param(r, "targetFeedID")
+
+	if feedID == targetFeedID || !h.store.FeedExists(userID, feedID) || !h.store.FeedExists(userID, targetFeedID) {
+		html.NotFound(w, r)
+		return
+	}
+
+	if err := h.store.MergeFeeds(userID, feedID, targetFeedID); err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	html.Redirect(w, r, route.Path(h.router, "feedEntries", "feedID", targetFeedID))
+}
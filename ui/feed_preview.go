@@ -0,0 +1,95 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package ui // import "miniflux.app/ui"
+
+import (
+	"net/http"
+
+	"miniflux.app/config"
+	"miniflux.app/http/request"
+	"miniflux.app/http/response/html"
+	"miniflux.app/logger"
+	"miniflux.app/model"
+	feedHandler "miniflux.app/reader/handler"
+	"miniflux.app/ui/form"
+	"miniflux.app/ui/session"
+	"miniflux.app/ui/view"
+	"miniflux.app/validator"
+)
+
+// previewFeed shows the edit page with the entries processed with the rules of the form, which are not saved.
+func (h *handler) previewFeed(w http.ResponseWriter, r *http.Request) {
+	loggedUser, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	feedID := request.RouteInt64Param(r, "feedID")
+	feed, err := h.store.FeedByID(loggedUser.ID, feedID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if feed == nil {
+		html.NotFound(w, r)
+		return
+	}
+
+	categories, err := h.store.Categories(loggedUser.ID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	refreshes, err := h.store.FeedRefreshes(loggedUser.ID, feedID, feedRefreshHistorySize)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	feedForm := form.NewFeedForm(r)
+
+	sess := session.New(h.store, request.SessionID(r))
+	view := view.New(h.tpl, r, sess)
+	view.Set("form", feedForm)
+	view.Set("categories", categories)
+	view.Set("feed", feed)
+	view.Set("refreshes", refreshes)
+	view.Set("menu", "feeds")
+	view.Set("user", loggedUser)
+	view.Set("countUnread", h.store.CountUnreadEntries(loggedUser.ID))
+	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(loggedUser.ID))
+	view.Set("defaultUserAgent", config.Opts.HTTPClientUserAgent())
+	view.Set("hasProxyConfigured", config.Opts.HasHTTPClientProxyConfigured())
+	view.Set("proxyNames", config.Opts.HTTPClientProxyNames())
+
+	feedPreviewRequest := &model.FeedPreviewRequest{
+		ScraperRules:    &feedForm.ScraperRules,
+		RewriteRules:    &feedForm.RewriteRules,
+		BlocklistRules:  &feedForm.BlocklistRules,
+		KeeplistRules:   &feedForm.KeeplistRules,
+		UrlRewriteRules: &feedForm.UrlRewriteRules,
+		Crawler:         &feedForm.Crawler,
+	}
+
+	if validationErr := validator.ValidateFeedPreview(feedPreviewRequest); validationErr != nil {
+		view.Set("errorMessage", validationErr.Localized())
+		html.OK(w, r, view.Render("edit_feed"))
+		return
+	}
+
+	preview, err := feedHandler.PreviewFeed(h.store, loggedUser.ID, feedID, feedPreviewRequest)
+	if err != nil {
+		logger.Error("[UI:PreviewFeed] %v", err)
+		view.Set("previewError", err.Error())
+		html.OK(w, r, view.Render("edit_feed"))
+		return
+	}
+
+	view.Set("preview", preview)
+	html.OK(w, r, view.Render("edit_feed"))
+}
@@ -12,6 +12,7 @@ import (
 	"miniflux.app/http/route"
 	"miniflux.app/logger"
 	feedHandler "miniflux.app/reader/handler"
+	"miniflux.app/worker"
 )
 
 func (h *handler) refreshFeed(w http.ResponseWriter, r *http.Request) {
@@ -31,9 +32,7 @@ func (h *handler) refreshAllFeeds(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	go func() {
-		h.pool.Push(jobs)
-	}()
+	h.pool.Push(jobs, worker.PriorityInteractive)
 
 	html.Redirect(w, r, route.Path(h.router, "feeds"))
 }
@@ -56,24 +56,46 @@ func (h *handler) updateFeed(w http.ResponseWriter, r *http.Request) {
 	view.Set("countUnread", h.store.CountUnreadEntries(loggedUser.ID))
 	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(loggedUser.ID))
 	view.Set("defaultUserAgent", config.Opts.HTTPClientUserAgent())
+	view.Set("proxyNames", config.Opts.HTTPClientProxyNames())
+	view.Set("entryIdentities", model.EntryIdentities())
+
+	headers, err := model.ParseFeedHeaders(feedForm.Headers)
+	if err != nil {
+		view.Set("errorMessage", "error.feed_invalid_headers")
+		html.OK(w, r, view.Render("edit_feed"))
+		return
+	}
 
 	feedModificationRequest := &model.FeedModificationRequest{
-		FeedURL:         model.OptionalString(feedForm.FeedURL),
-		SiteURL:         model.OptionalString(feedForm.SiteURL),
-		Title:           model.OptionalString(feedForm.Title),
-		CategoryID:      model.OptionalInt64(feedForm.CategoryID),
-		BlocklistRules:  model.OptionalString(feedForm.BlocklistRules),
-		KeeplistRules:   model.OptionalString(feedForm.KeeplistRules),
-		UrlRewriteRules: model.OptionalString(feedForm.UrlRewriteRules),
+		FeedURL:              model.OptionalString(feedForm.FeedURL),
+		SiteURL:              model.OptionalString(feedForm.SiteURL),
+		Title:                model.OptionalString(feedForm.Title),
+		CategoryID:           model.OptionalInt64(feedForm.CategoryID),
+		BlocklistRules:       model.OptionalString(feedForm.BlocklistRules),
+		KeeplistRules:        model.OptionalString(feedForm.KeeplistRules),
+		UrlRewriteRules:      model.OptionalString(feedForm.UrlRewriteRules),
+		Headers:              &headers,
+		TLSClientCertificate: model.OptionalString(feedForm.TLSClientCertificate),
+		TLSClientKey:         model.OptionalString(feedForm.TLSClientKey),
+		TLSCABundle:          model.OptionalString(feedForm.TLSCABundle),
+		ProxyName:            model.OptionalString(feedForm.ProxyName),
+		EntryIdentity:        model.OptionalString(feedForm.EntryIdentity),
+		RetentionReadDays:    &feedForm.RetentionReadDays,
+		RetentionUnreadDays:  &feedForm.RetentionUnreadDays,
+		RetentionMaxEntries:  &feedForm.RetentionMaxEntries,
 	}
 
-	if validationErr := validator.ValidateFeedModification(h.store, loggedUser.ID, feedModificationRequest); validationErr != nil {
-		view.Set("errorMessage", validationErr.TranslationKey)
+	if validationErr := validator.ValidateFeedModification(h.store, loggedUser.ID, feedID, feedModificationRequest); validationErr != nil {
+		view.Set("errorMessage", validationErr.Localized())
 		html.OK(w, r, view.Render("edit_feed"))
 		return
 	}
 
-	err = h.store.UpdateFeed(feedForm.Merge(feed))
+	previousURL := feed.FeedURL
+	feed = feedForm.Merge(feed)
+	feed.Headers = headers
+
+	err = h.store.UpdateFeed(feed)
 	if err != nil {
 		logger.Error("[UI:UpdateFeed] %v", err)
 		view.Set("errorMessage", "error.unable_to_update_feed")
@@ -81,5 +103,12 @@ func (h *handler) updateFeed(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if feed.FeedURL != previousURL {
+		change := &model.FeedURLChange{FeedID: feed.ID, UserID: feed.UserID, PreviousURL: previousURL, NewURL: feed.FeedURL, Reason: model.FeedURLChangeReasonUser}
+		if err := h.store.CreateFeedURLChange(change); err != nil {
+			logger.Error("[UI:UpdateFeed] %v", err)
+		}
+	}
+
 	html.Redirect(w, r, route.Path(h.router, "feedEntries", "feedID", feed.ID))
 }
@@ -0,0 +1,42 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package form // import "miniflux.app/ui/form"
+
+import (
+	"net/http"
+
+	"miniflux.app/model"
+)
+
+// AutomationRuleForm represents the automation rule form.
+type AutomationRuleForm struct {
+	Name     string
+	Rules    string
+	Action   string
+	Value    string
+	Disabled bool
+}
+
+// Request returns the automation rule request to validate.
+func (a AutomationRuleForm) Request() *model.AutomationRuleRequest {
+	return &model.AutomationRuleRequest{
+		Name:     a.Name,
+		Rules:    a.Rules,
+		Action:   a.Action,
+		Value:    a.Value,
+		Disabled: a.Disabled,
+	}
+}
+
+// NewAutomationRuleForm returns a new AutomationRuleForm.
+func NewAutomationRuleForm(r *http.Request) *AutomationRuleForm {
+	return &AutomationRuleForm{
+		Name:     r.FormValue("name"),
+		Rules:    r.FormValue("rules"),
+		Action:   r.FormValue("action"),
+		Value:    r.FormValue("value"),
+		Disabled: r.FormValue("disabled") == "1",
+	}
+}
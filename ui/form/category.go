@@ -10,14 +10,22 @@ import (
 
 // CategoryForm represents a feed form in the UI
 type CategoryForm struct {
-	Title        string
-	HideGlobally string
+	Title               string
+	HideGlobally        string
+	ProxyName           string
+	RetentionReadDays   int
+	RetentionUnreadDays int
+	RetentionMaxEntries int
 }
 
 // NewCategoryForm returns a new CategoryForm.
 func NewCategoryForm(r *http.Request) *CategoryForm {
 	return &CategoryForm{
-		Title:        r.FormValue("title"),
-		HideGlobally: r.FormValue("hide_globally"),
+		Title:               r.FormValue("title"),
+		HideGlobally:        r.FormValue("hide_globally"),
+		ProxyName:           r.FormValue("proxy_name"),
+		RetentionReadDays:   retentionValue(r, "retention_read_days"),
+		RetentionUnreadDays: retentionValue(r, "retention_unread_days"),
+		RetentionMaxEntries: retentionValue(r, "retention_max_entries"),
 	}
 }
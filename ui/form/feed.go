@@ -24,12 +24,21 @@ type FeedForm struct {
 	Crawler                     bool
 	UserAgent                   string
 	Cookie                      string
+	Headers                     string
+	TLSClientCertificate        string
+	TLSClientKey                string // Empty when the current key is kept
+	TLSCABundle                 string
 	CategoryID                  int64
 	Username                    string
 	Password                    string
 	IgnoreHTTPCache             bool
 	AllowSelfSignedCertificates bool
 	FetchViaProxy               bool
+	ProxyName                   string
+	EntryIdentity               string
+	RetentionReadDays           int
+	RetentionUnreadDays         int
+	RetentionMaxEntries         int
 	Disabled                    bool
 	NoMediaPlayer               bool
 	HideGlobally                bool
@@ -50,6 +59,13 @@ func (f FeedForm) Merge(feed *model.Feed) *model.Feed {
 	feed.Crawler = f.Crawler
 	feed.UserAgent = f.UserAgent
 	feed.Cookie = f.Cookie
+	feed.TLSClientCertificate = f.TLSClientCertificate
+	feed.TLSCABundle = f.TLSCABundle
+	if f.TLSClientCertificate == "" {
+		feed.TLSClientKey = ""
+	} else if f.TLSClientKey != "" {
+		feed.TLSClientKey = f.TLSClientKey
+	}
 	feed.ParsingErrorCount = 0
 	feed.ParsingErrorMsg = ""
 	feed.Username = f.Username
@@ -57,6 +73,11 @@ func (f FeedForm) Merge(feed *model.Feed) *model.Feed {
 	feed.IgnoreHTTPCache = f.IgnoreHTTPCache
 	feed.AllowSelfSignedCertificates = f.AllowSelfSignedCertificates
 	feed.FetchViaProxy = f.FetchViaProxy
+	feed.ProxyName = f.ProxyName
+	feed.ChangeEntryIdentity(f.EntryIdentity)
+	feed.RetentionReadDays = f.RetentionReadDays
+	feed.RetentionUnreadDays = f.RetentionUnreadDays
+	feed.RetentionMaxEntries = f.RetentionMaxEntries
 	feed.Disabled = f.Disabled
 	feed.NoMediaPlayer = f.NoMediaPlayer
 	feed.HideGlobally = f.HideGlobally
@@ -76,6 +97,10 @@ func NewFeedForm(r *http.Request) *FeedForm {
 		ScraperRules:                r.FormValue("scraper_rules"),
 		UserAgent:                   r.FormValue("user_agent"),
 		Cookie:                      r.FormValue("cookie"),
+		Headers:                     r.FormValue("headers"),
+		TLSClientCertificate:        r.FormValue("tls_client_certificate"),
+		TLSClientKey:                r.FormValue("tls_client_key"),
+		TLSCABundle:                 r.FormValue("tls_ca_bundle"),
 		RewriteRules:                r.FormValue("rewrite_rules"),
 		BlocklistRules:              r.FormValue("blocklist_rules"),
 		KeeplistRules:               r.FormValue("keeplist_rules"),
@@ -87,6 +112,11 @@ func NewFeedForm(r *http.Request) *FeedForm {
 		IgnoreHTTPCache:             r.FormValue("ignore_http_cache") == "1",
 		AllowSelfSignedCertificates: r.FormValue("allow_self_signed_certificates") == "1",
 		FetchViaProxy:               r.FormValue("fetch_via_proxy") == "1",
+		ProxyName:                   r.FormValue("proxy_name"),
+		EntryIdentity:               r.FormValue("entry_identity"),
+		RetentionReadDays:           retentionValue(r, "retention_read_days"),
+		RetentionUnreadDays:         retentionValue(r, "retention_unread_days"),
+		RetentionMaxEntries:         retentionValue(r, "retention_max_entries"),
 		Disabled:                    r.FormValue("disabled") == "1",
 		NoMediaPlayer:               r.FormValue("no_media_player") == "1",
 		HideGlobally:                r.FormValue("hide_globally") == "1",
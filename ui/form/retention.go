@@ -0,0 +1,21 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package form // import "miniflux.app/ui/form"
+
+import (
+	"net/http"
+	"strconv"
+
+	"miniflux.app/model"
+)
+
+// retentionValue returns the retention setting of the given field, an empty field inherits the setting.
+func retentionValue(r *http.Request, name string) int {
+	value, err := strconv.Atoi(r.FormValue(name))
+	if err != nil {
+		return model.RetentionInherit
+	}
+	return value
+}
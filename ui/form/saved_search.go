@@ -0,0 +1,33 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package form // import "miniflux.app/ui/form"
+
+import (
+	"net/http"
+
+	"miniflux.app/model"
+)
+
+// SavedSearchForm represents the saved search form.
+type SavedSearchForm struct {
+	Title string
+	Query string
+}
+
+// Request returns the saved search request to validate.
+func (s SavedSearchForm) Request() *model.SavedSearchRequest {
+	return &model.SavedSearchRequest{
+		Title: s.Title,
+		Query: s.Query,
+	}
+}
+
+// NewSavedSearchForm returns a new SavedSearchForm.
+func NewSavedSearchForm(r *http.Request) *SavedSearchForm {
+	return &SavedSearchForm{
+		Title: r.FormValue("title"),
+		Query: r.FormValue("query"),
+	}
+}
@@ -33,6 +33,10 @@ type SettingsForm struct {
 	CJKReadingSpeed        int
 	DefaultHomePage        string
 	CategoriesSortingOrder string
+	BlocklistRules         string
+	KeeplistRules          string
+	DuplicateEntries       string
+	DuplicateTitleMatching bool
 }
 
 // Merge updates the fields of the given user.
@@ -54,6 +58,10 @@ func (s *SettingsForm) Merge(user *model.User) *model.User {
 	user.DefaultReadingSpeed = s.DefaultReadingSpeed
 	user.DefaultHomePage = s.DefaultHomePage
 	user.CategoriesSortingOrder = s.CategoriesSortingOrder
+	user.BlocklistRules = s.BlocklistRules
+	user.KeeplistRules = s.KeeplistRules
+	user.DuplicateEntries = s.DuplicateEntries
+	user.DuplicateTitleMatching = s.DuplicateTitleMatching
 
 	if s.Password != "" {
 		user.Password = s.Password
@@ -120,5 +128,9 @@ func NewSettingsForm(r *http.Request) *SettingsForm {
 		CJKReadingSpeed:        int(cjkReadingSpeed),
 		DefaultHomePage:        r.FormValue("default_home_page"),
 		CategoriesSortingOrder: r.FormValue("categories_sorting_order"),
+		BlocklistRules:         r.FormValue("blocklist_rules"),
+		KeeplistRules:          r.FormValue("keeplist_rules"),
+		DuplicateEntries:       r.FormValue("duplicate_entries"),
+		DuplicateTitleMatching: r.FormValue("duplicate_title_matching") == "1",
 	}
 }
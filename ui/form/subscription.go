@@ -40,12 +40,12 @@ func (s *SubscriptionForm) Validate() error {
 		return errors.NewLocalizedError("error.invalid_feed_url")
 	}
 
-	if !validator.IsValidRegex(s.BlocklistRules) {
-		return errors.NewLocalizedError("error.feed_invalid_blocklist_rule")
+	if err := validator.ValidateFilterRules(s.BlocklistRules, "error.feed_invalid_blocklist_rule_at_line"); err != nil {
+		return err.Localized()
 	}
 
-	if !validator.IsValidRegex(s.KeeplistRules) {
-		return errors.NewLocalizedError("error.feed_invalid_keeplist_rule")
+	if err := validator.ValidateFilterRules(s.KeeplistRules, "error.feed_invalid_keeplist_rule_at_line"); err != nil {
+		return err.Localized()
 	}
 
 	if !validator.IsValidRegex(s.UrlRewriteRules) {
@@ -6,18 +6,21 @@ package ui // import "miniflux.app/ui"
 
 import (
 	"crypto/hmac"
-	"crypto/sha256"
 	"encoding/base64"
 	"errors"
+	"net"
 	"net/http"
+	"net/url"
 	"time"
 
 	"miniflux.app/config"
 	"miniflux.app/crypto"
+	"miniflux.app/http/client"
 	"miniflux.app/http/request"
 	"miniflux.app/http/response"
 	"miniflux.app/http/response/html"
 	"miniflux.app/logger"
+	"miniflux.app/proxy"
 )
 
 func (h *handler) mediaProxy(w http.ResponseWriter, r *http.Request) {
@@ -46,11 +49,8 @@ func (h *handler) mediaProxy(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	mac := hmac.New(sha256.New, config.Opts.ProxyPrivateKey())
-	mac.Write(decodedURL)
-	expectedMAC := mac.Sum(nil)
-
-	if !hmac.Equal(decodedDigest, expectedMAC) {
+	proxyName := request.QueryStringParam(r, "proxy", "")
+	if !hmac.Equal(decodedDigest, proxy.Signature(string(decodedURL), proxyName)) {
 		html.Forbidden(w, r)
 		return
 	}
@@ -74,14 +74,35 @@ func (h *handler) mediaProxy(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	dialer := &net.Dialer{}
+	transport := &http.Transport{
+		DialContext:     dialer.DialContext,
+		IdleConnTimeout: time.Duration(config.Opts.ProxyHTTPClientTimeout()) * time.Second,
+	}
+
+	// The media are fetched through the outbound proxy selected for the feed, if any.
+	if rawProxyURL, found := config.Opts.HTTPClientProxies()[proxyName]; found {
+		proxyURL, err := url.Parse(rawProxyURL)
+		if err != nil {
+			html.ServerError(w, r, err)
+			return
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	client.NewNetworkGuard(config.Opts.HTTPClientAllowedNetworks()).Protect(transport, dialer)
+
 	clt := &http.Client{
-		Transport: &http.Transport{
-			IdleConnTimeout: time.Duration(config.Opts.ProxyHTTPClientTimeout()) * time.Second,
-		},
-		Timeout: time.Duration(config.Opts.ProxyHTTPClientTimeout()) * time.Second,
+		Transport: transport,
+		Timeout:   time.Duration(config.Opts.ProxyHTTPClientTimeout()) * time.Second,
 	}
 
 	resp, err := clt.Do(req)
+	if client.IsForbiddenDestination(err) {
+		logger.Error(`[Proxy] Refused to fetch %q: %v`, mediaURL, err)
+		html.Forbidden(w, r)
+		return
+	}
 	if err != nil {
 		logger.Error(`[Proxy] Unable to initialize HTTP client: %v`, err)
 		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
@@ -0,0 +1,78 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package ui // import "miniflux.app/ui"
+
+import (
+	"net/http"
+
+	"miniflux.app/http/request"
+	"miniflux.app/http/response/html"
+	"miniflux.app/http/route"
+	"miniflux.app/model"
+	"miniflux.app/ui/session"
+	"miniflux.app/ui/view"
+)
+
+func (h *handler) showSavedSearchEntriesPage(w http.ResponseWriter, r *http.Request) {
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	savedSearch, err := h.store.SavedSearch(user.ID, request.RouteInt64Param(r, "savedSearchID"))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if savedSearch == nil {
+		html.NotFound(w, r)
+		return
+	}
+
+	offset := request.QueryIntParam(r, "offset", 0)
+	builder := h.store.NewSavedSearchQueryBuilder(savedSearch)
+	builder.WithOrder(user.EntryOrder)
+	builder.WithDirection(user.EntryDirection)
+	builder.WithStatus(model.EntryStatusUnread)
+	builder.WithOffset(offset)
+	builder.WithLimit(user.EntriesPerPage)
+	collapseDuplicates(builder, user)
+
+	entries, err := builder.GetEntries()
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	duplicateCopies, err := h.duplicateCopies(user, entries)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	count, err := builder.CountEntries()
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	sess := session.New(h.store, request.SessionID(r))
+	view := view.New(h.tpl, r, sess)
+	view.Set("savedSearch", savedSearch)
+	view.Set("total", count)
+	view.Set("entries", entries)
+	view.Set("duplicateCopies", duplicateCopies)
+	view.Set("pagination", getPagination(route.Path(h.router, "savedSearchEntries", "savedSearchID", savedSearch.ID), count, offset, user.EntriesPerPage))
+	view.Set("menu", "savedSearches")
+	view.Set("user", user)
+	view.Set("countUnread", h.store.CountUnreadEntries(user.ID))
+	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID))
+	view.Set("hasSaveEntry", h.store.HasSaveEntry(user.ID))
+	view.Set("showOnlyUnreadEntries", true)
+
+	html.OK(w, r, view.Render("saved_search_entries"))
+}
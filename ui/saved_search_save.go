@@ -0,0 +1,55 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package ui // import "miniflux.app/ui"
+
+import (
+	"net/http"
+
+	"miniflux.app/http/request"
+	"miniflux.app/http/response/html"
+	"miniflux.app/http/route"
+	"miniflux.app/logger"
+	"miniflux.app/model"
+	"miniflux.app/ui/form"
+	"miniflux.app/ui/session"
+	"miniflux.app/ui/view"
+	"miniflux.app/validator"
+)
+
+func (h *handler) saveSavedSearch(w http.ResponseWriter, r *http.Request) {
+	loggedUser, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	savedSearchForm := form.NewSavedSearchForm(r)
+
+	sess := session.New(h.store, request.SessionID(r))
+	view := view.New(h.tpl, r, sess)
+	view.Set("form", savedSearchForm)
+	view.Set("menu", "savedSearches")
+	view.Set("user", loggedUser)
+	view.Set("countUnread", h.store.CountUnreadEntries(loggedUser.ID))
+	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(loggedUser.ID))
+
+	savedSearchRequest := savedSearchForm.Request()
+	if validationErr := validator.ValidateSavedSearch(h.store, loggedUser.ID, 0, savedSearchRequest); validationErr != nil {
+		view.Set("errorMessage", validationErr.TranslationKey)
+		html.OK(w, r, view.Render("create_saved_search"))
+		return
+	}
+
+	savedSearch := &model.SavedSearch{UserID: loggedUser.ID}
+	savedSearchRequest.Patch(savedSearch)
+	if err := h.store.CreateSavedSearch(savedSearch); err != nil {
+		logger.Error("[UI:SaveSavedSearch] %v", err)
+		view.Set("errorMessage", "error.unable_to_create_saved_search")
+		html.OK(w, r, view.Render("create_saved_search"))
+		return
+	}
+
+	html.Redirect(w, r, route.Path(h.router, "savedSearchEntries", "savedSearchID", savedSearch.ID))
+}
@@ -44,6 +44,10 @@ func (h *handler) showSettingsPage(w http.ResponseWriter, r *http.Request) {
 		CJKReadingSpeed:        user.CJKReadingSpeed,
 		DefaultHomePage:        user.DefaultHomePage,
 		CategoriesSortingOrder: user.CategoriesSortingOrder,
+		BlocklistRules:         user.BlocklistRules,
+		KeeplistRules:          user.KeeplistRules,
+		DuplicateEntries:       user.DuplicateEntries,
+		DuplicateTitleMatching: user.DuplicateTitleMatching,
 	}
 
 	timezones, err := h.store.Timezones()
@@ -52,7 +56,14 @@ func (h *handler) showSettingsPage(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	filterRules, err := h.store.FilterRules(user)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
 	view.Set("form", settingsForm)
+	view.Set("filterRules", filterRules)
 	view.Set("themes", model.Themes())
 	view.Set("languages", locale.AvailableLanguages())
 	view.Set("timezones", timezones)
@@ -62,6 +73,7 @@ func (h *handler) showSettingsPage(w http.ResponseWriter, r *http.Request) {
 	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID))
 	view.Set("default_home_pages", model.HomePages())
 	view.Set("categories_sorting_options", model.CategoriesSortingOptions())
+	view.Set("duplicate_entries_options", model.DuplicateEntriesOptions())
 
 	html.OK(w, r, view.Render("settings"))
 }
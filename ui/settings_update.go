@@ -41,6 +41,7 @@ func (h *handler) updateSettings(w http.ResponseWriter, r *http.Request) {
 	view.Set("themes", model.Themes())
 	view.Set("languages", locale.AvailableLanguages())
 	view.Set("timezones", timezones)
+	view.Set("duplicate_entries_options", model.DuplicateEntriesOptions())
 	view.Set("menu", "settings")
 	view.Set("user", loggedUser)
 	view.Set("countUnread", h.store.CountUnreadEntries(loggedUser.ID))
@@ -65,10 +66,13 @@ func (h *handler) updateSettings(w http.ResponseWriter, r *http.Request) {
 		DefaultReadingSpeed: model.OptionalInt(settingsForm.DefaultReadingSpeed),
 		CJKReadingSpeed:     model.OptionalInt(settingsForm.CJKReadingSpeed),
 		DefaultHomePage:     model.OptionalString(settingsForm.DefaultHomePage),
+		BlocklistRules:      model.OptionalString(settingsForm.BlocklistRules),
+		KeeplistRules:       model.OptionalString(settingsForm.KeeplistRules),
+		DuplicateEntries:    model.OptionalString(settingsForm.DuplicateEntries),
 	}
 
 	if validationErr := validator.ValidateUserModification(h.store, loggedUser.ID, userModificationRequest); validationErr != nil {
-		view.Set("errorMessage", validationErr.TranslationKey)
+		view.Set("errorMessage", validationErr.Localized())
 		html.OK(w, r, view.Render("settings"))
 		return
 	}
@@ -315,6 +315,32 @@ function toggleBookmark(parentElement, toasting) {
     request.execute();
 }
 
+// Send the Ajax request and change the label when adding an entry to the read later list.
+function handleReadLater(element) {
+    let currentEntry = findEntry(element);
+    if (!currentEntry) {
+        return;
+    }
+
+    element = currentEntry.querySelector("a[data-toggle-read-later]");
+    if (!element) {
+        return;
+    }
+
+    let iconElement = document.querySelector("template#icon-save");
+    element.innerHTML = '<span class="icon-label">' + element.dataset.labelLoading + '</span>';
+
+    let request = new RequestBuilder(element.dataset.readLaterUrl);
+    request.withCallback(() => {
+        let newValue = element.dataset.value === "on" ? "off" : "on";
+        let label = newValue === "on" ? element.dataset.labelRemove : element.dataset.labelAdd;
+
+        element.innerHTML = iconElement.innerHTML + '<span class="icon-label">' + label + '</span>';
+        element.dataset.value = newValue;
+    });
+    request.execute();
+}
+
 // Send the Ajax request to download the original web page.
 function handleFetchOriginalContent() {
     if (isListView()) {
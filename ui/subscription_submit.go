@@ -47,7 +47,7 @@ func (h *handler) submitSubscription(w http.ResponseWriter, r *http.Request) {
 	subscriptionForm := form.NewSubscriptionForm(r)
 	if err := subscriptionForm.Validate(); err != nil {
 		v.Set("form", subscriptionForm)
-		v.Set("errorMessage", err.Error())
+		v.Set("errorMessage", err)
 		html.OK(w, r, v.Render("add_subscription"))
 		return
 	}
@@ -58,6 +58,10 @@ func Serve(router *mux.Router, store *storage.Storage, pool *worker.Pool) {
 	uiRouter.HandleFunc("/starred", handler.showStarredPage).Name("starred").Methods(http.MethodGet)
 	uiRouter.HandleFunc("/starred/entry/{entryID}", handler.showStarredEntryPage).Name("starredEntry").Methods(http.MethodGet)
 
+	// Read later pages.
+	uiRouter.HandleFunc("/read-later", handler.showReadLaterPage).Name("readLater").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/read-later/entry/{entryID}", handler.showReadLaterEntryPage).Name("readLaterEntry").Methods(http.MethodGet)
+
 	// Search pages.
 	uiRouter.HandleFunc("/search", handler.showSearchEntriesPage).Name("searchEntries").Methods(http.MethodGet)
 	uiRouter.HandleFunc("/search/entry/{entryID}", handler.showSearchEntryPage).Name("searchEntry").Methods(http.MethodGet)
@@ -71,12 +75,26 @@ func Serve(router *mux.Router, store *storage.Storage, pool *worker.Pool) {
 	uiRouter.HandleFunc("/feed/{feedID}/edit", handler.showEditFeedPage).Name("editFeed").Methods(http.MethodGet)
 	uiRouter.HandleFunc("/feed/{feedID}/remove", handler.removeFeed).Name("removeFeed").Methods(http.MethodPost)
 	uiRouter.HandleFunc("/feed/{feedID}/update", handler.updateFeed).Name("updateFeed").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/feed/{feedID}/preview", handler.previewFeed).Name("previewFeed").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/feed/{feedID}/merge/{targetFeedID}", handler.mergeFeed).Name("mergeFeed").Methods(http.MethodPost)
 	uiRouter.HandleFunc("/feed/{feedID}/entries", handler.showFeedEntriesPage).Name("feedEntries").Methods(http.MethodGet)
 	uiRouter.HandleFunc("/feed/{feedID}/entries/all", handler.showFeedEntriesAllPage).Name("feedEntriesAll").Methods(http.MethodGet)
 	uiRouter.HandleFunc("/feed/{feedID}/entry/{entryID}", handler.showFeedEntryPage).Name("feedEntry").Methods(http.MethodGet)
 	uiRouter.HandleFunc("/feed/icon/{iconID}", handler.showIcon).Name("icon").Methods(http.MethodGet)
 	uiRouter.HandleFunc("/feed/{feedID}/mark-all-as-read", handler.markFeedAsRead).Name("markFeedAsRead").Methods(http.MethodPost)
 
+	// Saved search pages.
+	uiRouter.HandleFunc("/saved-searches", handler.showSavedSearchListPage).Name("savedSearches").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/saved-search/create", handler.showCreateSavedSearchPage).Name("createSavedSearch").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/saved-search/save", handler.saveSavedSearch).Name("saveSavedSearch").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/saved-search/{savedSearchID}/entries", handler.showSavedSearchEntriesPage).Name("savedSearchEntries").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/saved-search/{savedSearchID}/entries/all", handler.showSavedSearchEntriesAllPage).Name("savedSearchEntriesAll").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/saved-search/{savedSearchID}/entry/{entryID}", handler.showSavedSearchEntryPage).Name("savedSearchEntry").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/saved-search/{savedSearchID}/edit", handler.showEditSavedSearchPage).Name("editSavedSearch").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/saved-search/{savedSearchID}/update", handler.updateSavedSearch).Name("updateSavedSearch").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/saved-search/{savedSearchID}/remove", handler.removeSavedSearch).Name("removeSavedSearch").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/saved-search/{savedSearchID}/mark-all-as-read", handler.markSavedSearchAsRead).Name("markSavedSearchAsRead").Methods(http.MethodPost)
+
 	// Category pages.
 	uiRouter.HandleFunc("/category/{categoryID}/entry/{entryID}", handler.showCategoryEntryPage).Name("categoryEntry").Methods(http.MethodGet)
 	uiRouter.HandleFunc("/categories", handler.showCategoryListPage).Name("categories").Methods(http.MethodGet)
@@ -95,10 +113,12 @@ func Serve(router *mux.Router, store *storage.Storage, pool *worker.Pool) {
 	// Entry pages.
 	uiRouter.HandleFunc("/entry/status", handler.updateEntriesStatus).Name("updateEntriesStatus").Methods(http.MethodPost)
 	uiRouter.HandleFunc("/entry/save/{entryID}", handler.saveEntry).Name("saveEntry").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/entry/revisions/{entryID}", handler.showEntryRevisionsPage).Name("entryRevisions").Methods(http.MethodGet)
 	uiRouter.HandleFunc("/entry/enclosure/{enclosureID}/save-progression", handler.saveEnclosureProgression).Name("saveEnclosureProgression").Methods(http.MethodPost)
 	uiRouter.HandleFunc("/entry/download/{entryID}", handler.fetchContent).Name("fetchContent").Methods(http.MethodPost)
 	uiRouter.HandleFunc("/proxy/{encodedDigest}/{encodedURL}", handler.mediaProxy).Name("proxy").Methods(http.MethodGet)
 	uiRouter.HandleFunc("/entry/bookmark/{entryID}", handler.toggleBookmark).Name("toggleBookmark").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/entry/read-later/{entryID}", handler.toggleReadLater).Name("toggleReadLater").Methods(http.MethodPost)
 
 	// Share pages.
 	uiRouter.HandleFunc("/entry/share/{entryID}", handler.createSharedEntry).Name("shareEntry").Methods(http.MethodGet)
@@ -133,6 +153,14 @@ func Serve(router *mux.Router, store *storage.Storage, pool *worker.Pool) {
 	uiRouter.HandleFunc("/keys/create", handler.showCreateAPIKeyPage).Name("createAPIKey").Methods(http.MethodGet)
 	uiRouter.HandleFunc("/keys/save", handler.saveAPIKey).Name("saveAPIKey").Methods(http.MethodPost)
 
+	// Automation rules pages.
+	uiRouter.HandleFunc("/automation-rules", handler.showAutomationRulesPage).Name("automationRules").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/automation-rules/create", handler.showCreateAutomationRulePage).Name("createAutomationRule").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/automation-rules/save", handler.saveAutomationRule).Name("saveAutomationRule").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/automation-rules/{ruleID}/edit", handler.showEditAutomationRulePage).Name("editAutomationRule").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/automation-rules/{ruleID}/update", handler.updateAutomationRule).Name("updateAutomationRule").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/automation-rules/{ruleID}/remove", handler.removeAutomationRule).Name("removeAutomationRule").Methods(http.MethodPost)
+
 	// OPML pages.
 	uiRouter.HandleFunc("/export", handler.exportFeeds).Name("export").Methods(http.MethodGet)
 	uiRouter.HandleFunc("/import", handler.showImportPage).Name("import").Methods(http.MethodGet)
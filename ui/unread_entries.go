@@ -35,6 +35,7 @@ func (h *handler) showUnreadPage(w http.ResponseWriter, r *http.Request) {
 	builder := h.store.NewEntryQueryBuilder(user.ID)
 	builder.WithStatus(model.EntryStatusUnread)
 	builder.WithGloballyVisible()
+	collapseDuplicates(builder, user)
 	countUnread, err := builder.CountEntries()
 	if err != nil {
 		html.ServerError(w, r, err)
@@ -54,14 +55,22 @@ func (h *handler) showUnreadPage(w http.ResponseWriter, r *http.Request) {
 	builder.WithOffset(offset)
 	builder.WithLimit(user.EntriesPerPage)
 	builder.WithGloballyVisible()
+	collapseDuplicates(builder, user)
 	entries, err := builder.GetEntries()
 	if err != nil {
 		html.ServerError(w, r, err)
 		return
 	}
+
+	duplicateCopies, err := h.duplicateCopies(user, entries)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
 	finishSqlFetchUnreadEntries := time.Now()
 
 	view.Set("entries", entries)
+	view.Set("duplicateCopies", duplicateCopies)
 	view.Set("pagination", getPagination(route.Path(h.router, "unread"), countUnread, offset, user.EntriesPerPage))
 	view.Set("menu", "unread")
 	view.Set("user", user)
@@ -7,9 +7,25 @@ package url // import "miniflux.app/url"
 import (
 	"fmt"
 	"net/url"
+	"sort"
 	"strings"
 )
 
+// trackingParameters are removed from the query string by Normalize.
+var trackingParameters = map[string]bool{
+	"fbclid":  true,
+	"gclid":   true,
+	"dclid":   true,
+	"msclkid": true,
+	"yclid":   true,
+	"igshid":  true,
+	"mc_cid":  true,
+	"mc_eid":  true,
+	"ref":     true,
+	"ref_src": true,
+	"_ga":     true,
+}
+
 // IsAbsoluteURL returns true if the link is absolute.
 func IsAbsoluteURL(link string) bool {
 	u, err := url.Parse(link)
@@ -80,3 +96,45 @@ func Domain(websiteURL string) string {
 
 	return parsedURL.Host
 }
+
+// Normalize returns a key used to compare the URLs of entries published in different feeds.
+//
+// The scheme, the "www." prefix, the fragment, the trailing slash and the tracking parameters are removed,
+// the remaining query parameters are sorted.
+func Normalize(websiteURL string) string {
+	parsedURL, err := url.Parse(strings.TrimSpace(websiteURL))
+	if err != nil || parsedURL.Host == "" {
+		return websiteURL
+	}
+
+	host := strings.TrimPrefix(strings.ToLower(parsedURL.Hostname()), "www.")
+	if port := parsedURL.Port(); port != "" && port != "80" && port != "443" {
+		host += ":" + port
+	}
+
+	values := parsedURL.Query()
+	for name := range values {
+		if trackingParameters[strings.ToLower(name)] || strings.HasPrefix(strings.ToLower(name), "utm_") {
+			values.Del(name)
+		}
+	}
+
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var query []string
+	for _, name := range names {
+		for _, value := range values[name] {
+			query = append(query, url.QueryEscape(name)+"="+url.QueryEscape(value))
+		}
+	}
+
+	normalizedURL := host + strings.TrimSuffix(parsedURL.EscapedPath(), "/")
+	if len(query) > 0 {
+		normalizedURL += "?" + strings.Join(query, "&")
+	}
+	return normalizedURL
+}
@@ -90,3 +90,23 @@ func TestDomain(t *testing.T) {
 		}
 	}
 }
+
+func TestNormalize(t *testing.T) {
+	scenarios := map[string]string{
+		"https://example.org/article":                                "example.org/article",
+		"http://www.Example.org/article/":                            "example.org/article",
+		"https://example.org:443/article#comments":                   "example.org/article",
+		"https://example.org:8080/article":                           "example.org:8080/article",
+		"https://example.org/article?utm_source=rss&utm_medium=feed": "example.org/article",
+		"https://example.org/article?id=2&fbclid=abc&a=1":            "example.org/article?a=1&id=2",
+		"https://example.org/?p=42&ref=hackernews":                   "example.org?p=42",
+		"invalid url": "invalid url",
+	}
+
+	for input, expected := range scenarios {
+		actual := Normalize(input)
+		if actual != expected {
+			t.Errorf(`Unexpected result for %q, got %q instead of %q`, input, actual, expected)
+		}
+	}
+}
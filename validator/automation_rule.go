@@ -0,0 +1,53 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package validator // import "miniflux.app/validator"
+
+import (
+	"strings"
+
+	"miniflux.app/integration"
+	"miniflux.app/model"
+	"miniflux.app/reader/filter"
+	"miniflux.app/storage"
+)
+
+// ValidateAutomationRule validates automation rule creation and modification.
+func ValidateAutomationRule(store *storage.Storage, userID, ruleID int64, request *model.AutomationRuleRequest) *ValidationError {
+	if strings.TrimSpace(request.Name) == "" {
+		return NewValidationError("error.fields_mandatory")
+	}
+
+	if store.AutomationRuleExists(userID, ruleID, request.Name) {
+		return NewValidationError("error.automation_rule_already_exists")
+	}
+
+	if strings.TrimSpace(request.Rules) == "" {
+		return NewValidationError("error.fields_mandatory")
+	}
+
+	if _, err := filter.Parse(request.Rules); err != nil {
+		return newFilterRulesError(err, "error.automation_rule_invalid_rule_at_line")
+	}
+
+	switch request.Action {
+	case model.AutomationActionMarkAsRead, model.AutomationActionStar, model.AutomationActionReadLater:
+		return nil
+	case model.AutomationActionAddTags:
+		rule := &model.AutomationRule{Value: request.Value}
+		if len(rule.Tags()) == 0 {
+			return NewValidationError("error.automation_rule_tags_required")
+		}
+		return nil
+	case model.AutomationActionSendToIntegration:
+		for _, name := range integration.SavingIntegrations() {
+			if request.Value == name {
+				return nil
+			}
+		}
+		return NewValidationError("error.automation_rule_invalid_integration")
+	}
+
+	return NewValidationError("error.automation_rule_invalid_action")
+}
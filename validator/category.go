@@ -19,6 +19,18 @@ func ValidateCategoryCreation(store *storage.Storage, userID int64, request *mod
 		return NewValidationError("error.category_already_exists")
 	}
 
+	if store.SavedSearchExists(userID, 0, request.Title) {
+		return NewValidationError("error.category_saved_search_exists")
+	}
+
+	if !IsValidProxyName(request.ProxyName) {
+		return NewValidationError("error.invalid_proxy_name")
+	}
+
+	if !IsValidRetentionPolicy(request.RetentionReadDays, request.RetentionUnreadDays, request.RetentionMaxEntries) {
+		return NewValidationError("error.invalid_retention_policy")
+	}
+
 	return nil
 }
 
@@ -32,5 +44,17 @@ func ValidateCategoryModification(store *storage.Storage, userID, categoryID int
 		return NewValidationError("error.category_already_exists")
 	}
 
+	if store.SavedSearchExists(userID, 0, request.Title) {
+		return NewValidationError("error.category_saved_search_exists")
+	}
+
+	if !IsValidProxyName(request.ProxyName) {
+		return NewValidationError("error.invalid_proxy_name")
+	}
+
+	if !IsValidRetentionPolicy(request.RetentionReadDays, request.RetentionUnreadDays, request.RetentionMaxEntries) {
+		return NewValidationError("error.invalid_retention_policy")
+	}
+
 	return nil
 }
@@ -38,3 +38,12 @@ func ValidateEntryOrder(order string) error {
 
 	return fmt.Errorf(`Invalid entry order, valid order values are: "id", "status", "changed_at", "published_at", "created_at", "category_title", "category_id", "title", "author"`)
 }
+
+// ValidateEntryCursor makes sure the sorting order and direction of a pagination cursor are valid.
+func ValidateEntryCursor(cursor *model.EntryCursor) error {
+	if err := ValidateEntryOrder(cursor.Order); err != nil {
+		return err
+	}
+
+	return ValidateDirection(cursor.Direction)
+}
@@ -58,3 +58,17 @@ func TestValidateEntryOrder(t *testing.T) {
 		t.Error(`An invalid order should generate a error`)
 	}
 }
+
+func TestValidateEntryCursor(t *testing.T) {
+	if err := ValidateEntryCursor(&model.EntryCursor{Order: "published_at", Direction: "desc", EntryID: 1}); err != nil {
+		t.Error(`A valid cursor should not generate any error`)
+	}
+
+	if err := ValidateEntryCursor(&model.EntryCursor{Order: "e.id; DROP TABLE entries", Direction: "desc", EntryID: 1}); err == nil {
+		t.Error(`A cursor with an invalid order should generate a error`)
+	}
+
+	if err := ValidateEntryCursor(&model.EntryCursor{Order: "id", Direction: "up", EntryID: 1}); err == nil {
+		t.Error(`A cursor with an invalid direction should generate a error`)
+	}
+}
@@ -5,6 +5,7 @@
 package validator // import "miniflux.app/validator"
 
 import (
+	"miniflux.app/config"
 	"miniflux.app/model"
 	"miniflux.app/storage"
 )
@@ -27,19 +28,49 @@ func ValidateFeedCreation(store *storage.Storage, userID int64, request *model.F
 		return NewValidationError("error.feed_category_not_found")
 	}
 
-	if !IsValidRegex(request.BlocklistRules) {
-		return NewValidationError("error.feed_invalid_blocklist_rule")
+	if err := ValidateFilterRules(request.BlocklistRules, "error.feed_invalid_blocklist_rule_at_line"); err != nil {
+		return err
 	}
 
-	if !IsValidRegex(request.KeeplistRules) {
-		return NewValidationError("error.feed_invalid_keeplist_rule")
+	if err := ValidateFilterRules(request.KeeplistRules, "error.feed_invalid_keeplist_rule_at_line"); err != nil {
+		return err
+	}
+
+	if !IsValidHTTPHeaders(request.Headers) {
+		return NewValidationError("error.feed_invalid_headers")
+	}
+
+	if request.TLSClientCertificate != "" || request.TLSClientKey != "" {
+		if !IsValidTLSKeyPair(request.TLSClientCertificate, request.TLSClientKey) {
+			return NewValidationError("error.feed_invalid_tls_client_certificate")
+		}
+
+		if !config.Opts.HasEncryptionKey() {
+			return NewValidationError("error.feed_tls_encryption_key_missing")
+		}
+	}
+
+	if request.TLSCABundle != "" && !IsValidPEMCertificates(request.TLSCABundle) {
+		return NewValidationError("error.feed_invalid_tls_ca_bundle")
+	}
+
+	if !IsValidProxyName(request.ProxyName) {
+		return NewValidationError("error.invalid_proxy_name")
+	}
+
+	if request.EntryIdentity != "" && !model.IsValidEntryIdentity(request.EntryIdentity) {
+		return NewValidationError("error.invalid_entry_identity")
+	}
+
+	if !IsValidRetentionPolicy(request.RetentionReadDays, request.RetentionUnreadDays, request.RetentionMaxEntries) {
+		return NewValidationError("error.invalid_retention_policy")
 	}
 
 	return nil
 }
 
 // ValidateFeedModification validates feed modification.
-func ValidateFeedModification(store *storage.Storage, userID int64, request *model.FeedModificationRequest) *ValidationError {
+func ValidateFeedModification(store *storage.Storage, userID, feedID int64, request *model.FeedModificationRequest) *ValidationError {
 	if request.FeedURL != nil {
 		if *request.FeedURL == "" {
 			return NewValidationError("error.feed_url_not_empty")
@@ -73,14 +104,105 @@ func ValidateFeedModification(store *storage.Storage, userID int64, request *mod
 	}
 
 	if request.BlocklistRules != nil {
-		if !IsValidRegex(*request.BlocklistRules) {
-			return NewValidationError("error.feed_invalid_blocklist_rule")
+		if err := ValidateFilterRules(*request.BlocklistRules, "error.feed_invalid_blocklist_rule_at_line"); err != nil {
+			return err
+		}
+	}
+
+	if request.KeeplistRules != nil {
+		if err := ValidateFilterRules(*request.KeeplistRules, "error.feed_invalid_keeplist_rule_at_line"); err != nil {
+			return err
+		}
+	}
+
+	if request.Headers != nil {
+		if !IsValidHTTPHeaders(*request.Headers) {
+			return NewValidationError("error.feed_invalid_headers")
+		}
+	}
+
+	if request.TLSClientCertificate != nil && *request.TLSClientCertificate != "" {
+		if !IsValidPEMCertificates(*request.TLSClientCertificate) {
+			return NewValidationError("error.feed_invalid_tls_client_certificate")
+		}
+	}
+
+	if request.TLSClientKey != nil && *request.TLSClientKey != "" {
+		if !IsValidPEMPrivateKey(*request.TLSClientKey) {
+			return NewValidationError("error.feed_invalid_tls_client_certificate")
+		}
+
+		if !config.Opts.HasEncryptionKey() {
+			return NewValidationError("error.feed_tls_encryption_key_missing")
+		}
+	}
+
+	if request.TLSClientCertificate != nil || (request.TLSClientKey != nil && *request.TLSClientKey != "") {
+		if !isValidTLSClientCertificateModification(store, userID, feedID, request) {
+			return NewValidationError("error.feed_invalid_tls_client_certificate")
+		}
+	}
+
+	if request.TLSCABundle != nil && *request.TLSCABundle != "" {
+		if !IsValidPEMCertificates(*request.TLSCABundle) {
+			return NewValidationError("error.feed_invalid_tls_ca_bundle")
+		}
+	}
+
+	if request.ProxyName != nil {
+		if !IsValidProxyName(*request.ProxyName) {
+			return NewValidationError("error.invalid_proxy_name")
+		}
+	}
+
+	if request.EntryIdentity != nil {
+		if !model.IsValidEntryIdentity(*request.EntryIdentity) {
+			return NewValidationError("error.invalid_entry_identity")
+		}
+	}
+
+	if !IsValidRetentionPolicy(optionalInt(request.RetentionReadDays), optionalInt(request.RetentionUnreadDays), optionalInt(request.RetentionMaxEntries)) {
+		return NewValidationError("error.invalid_retention_policy")
+	}
+
+	return nil
+}
+
+// isValidTLSClientCertificateModification verifies the client certificate and key the feed uses once modified.
+//
+// The stored key is kept when the request does not send a new one, a new certificate must match it.
+func isValidTLSClientCertificateModification(store *storage.Storage, userID, feedID int64, request *model.FeedModificationRequest) bool {
+	feed, err := store.FeedByID(userID, feedID)
+	if err != nil || feed == nil {
+		return false
+	}
+
+	request.Patch(feed)
+	if feed.TLSClientCertificate == "" && feed.TLSClientKey == "" {
+		return true
+	}
+	return IsValidTLSKeyPair(feed.TLSClientCertificate, feed.TLSClientKey)
+}
+
+// optionalInt returns the value of an optional setting, the settings left unchanged are valid.
+func optionalInt(value *int) int {
+	if value == nil {
+		return model.RetentionInherit
+	}
+	return *value
+}
+
+// ValidateFeedPreview validates the rules of a feed preview request.
+func ValidateFeedPreview(request *model.FeedPreviewRequest) *ValidationError {
+	if request.BlocklistRules != nil {
+		if err := ValidateFilterRules(*request.BlocklistRules, "error.feed_invalid_blocklist_rule_at_line"); err != nil {
+			return err
 		}
 	}
 
 	if request.KeeplistRules != nil {
-		if !IsValidRegex(*request.KeeplistRules) {
-			return NewValidationError("error.feed_invalid_keeplist_rule")
+		if err := ValidateFilterRules(*request.KeeplistRules, "error.feed_invalid_keeplist_rule_at_line"); err != nil {
+			return err
 		}
 	}
 
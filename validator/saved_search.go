@@ -0,0 +1,35 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package validator // import "miniflux.app/validator"
+
+import (
+	"strings"
+
+	"miniflux.app/model"
+	"miniflux.app/search"
+	"miniflux.app/storage"
+)
+
+// ValidateSavedSearch validates saved search creation and modification.
+func ValidateSavedSearch(store *storage.Storage, userID, savedSearchID int64, request *model.SavedSearchRequest) *ValidationError {
+	if strings.TrimSpace(request.Title) == "" || strings.TrimSpace(request.Query) == "" {
+		return NewValidationError("error.fields_mandatory")
+	}
+
+	if search.Parse(request.Query).IsEmpty() {
+		return NewValidationError("error.saved_search_invalid_query")
+	}
+
+	if store.SavedSearchExists(userID, savedSearchID, request.Title) {
+		return NewValidationError("error.saved_search_already_exists")
+	}
+
+	// The Google Reader API lists the saved searches and the categories as labels, their titles must be distinct.
+	if store.CategoryTitleExists(userID, request.Title) {
+		return NewValidationError("error.saved_search_category_exists")
+	}
+
+	return nil
+}
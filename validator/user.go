@@ -103,6 +103,24 @@ func ValidateUserModification(store *storage.Storage, userID int64, changes *mod
 		}
 	}
 
+	if changes.DuplicateEntries != nil {
+		if err := validateDuplicateEntries(*changes.DuplicateEntries); err != nil {
+			return err
+		}
+	}
+
+	if changes.BlocklistRules != nil {
+		if err := ValidateFilterRules(*changes.BlocklistRules, "error.feed_invalid_blocklist_rule_at_line"); err != nil {
+			return err
+		}
+	}
+
+	if changes.KeeplistRules != nil {
+		if err := ValidateFilterRules(*changes.KeeplistRules, "error.feed_invalid_keeplist_rule_at_line"); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -183,3 +201,10 @@ func validateDefaultHomePage(defaultHomePage string) *ValidationError {
 	}
 	return nil
 }
+
+func validateDuplicateEntries(duplicateEntries string) *ValidationError {
+	if _, found := model.DuplicateEntriesOptions()[duplicateEntries]; !found {
+		return NewValidationError("error.invalid_duplicate_entries")
+	}
+	return nil
+}
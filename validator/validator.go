@@ -5,30 +5,46 @@
 package validator // import "miniflux.app/validator"
 
 import (
-	"errors"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
 	"fmt"
 	"net/url"
 	"regexp"
+	"strings"
 
+	"miniflux.app/config"
+	"miniflux.app/errors"
+	"miniflux.app/http/client"
 	"miniflux.app/locale"
+	"miniflux.app/model"
+	"miniflux.app/reader/filter"
+
+	"golang.org/x/net/http/httpguts"
 )
 
 // ValidationError represents a validation error.
 type ValidationError struct {
 	TranslationKey string
+	Args           []interface{}
 }
 
 // NewValidationError initializes a validation error.
-func NewValidationError(translationKey string) *ValidationError {
-	return &ValidationError{TranslationKey: translationKey}
+func NewValidationError(translationKey string, args ...interface{}) *ValidationError {
+	return &ValidationError{TranslationKey: translationKey, Args: args}
 }
 
 func (v *ValidationError) String() string {
-	return locale.NewPrinter("en_US").Printf(v.TranslationKey)
+	return locale.NewPrinter("en_US").Printf(v.TranslationKey, v.Args...)
+}
+
+// Localized returns the validation error as a localized error, with its arguments.
+func (v *ValidationError) Localized() *errors.LocalizedError {
+	return errors.NewLocalizedError(v.TranslationKey, v.Args...)
 }
 
 func (v *ValidationError) Error() error {
-	return errors.New(v.String())
+	return fmt.Errorf("%s", v.String())
 }
 
 // ValidateRange makes sure the offset/limit values are valid.
@@ -60,8 +76,91 @@ func IsValidRegex(expr string) bool {
 	return err == nil
 }
 
+// ValidateFilterRules verifies the blocklist or keeplist rules, the error reports the line of the first invalid rule.
+//
+// The translation key receives the line number and the reason.
+func ValidateFilterRules(rules, translationKey string) *ValidationError {
+	_, err := filter.ParseListRules(rules)
+	return newFilterRulesError(err, translationKey)
+}
+
+func newFilterRulesError(err error, translationKey string) *ValidationError {
+	if err == nil {
+		return nil
+	}
+
+	if syntaxErr, ok := err.(*filter.SyntaxError); ok {
+		return NewValidationError(translationKey, syntaxErr.Line, syntaxErr.Message)
+	}
+	return NewValidationError(translationKey, 1, err.Error())
+}
+
 // IsValidURL verifies if the provided value is a valid absolute URL.
 func IsValidURL(absoluteURL string) bool {
 	_, err := url.ParseRequestURI(absoluteURL)
 	return err == nil
 }
+
+// IsValidHTTPHeaders verifies if the names and the values of the headers can be sent in a request.
+func IsValidHTTPHeaders(headers map[string]string) bool {
+	for name, value := range headers {
+		if !httpguts.ValidHeaderFieldName(name) || !httpguts.ValidHeaderFieldValue(value) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsValidProxyName verifies if the proxy name is empty, selects direct access or one of the configured proxies.
+func IsValidProxyName(name string) bool {
+	if name == "" || name == client.DirectProxy {
+		return true
+	}
+	_, found := config.Opts.HTTPClientProxies()[name]
+	return found
+}
+
+// IsValidRetentionPolicy verifies if the retention settings are positive, inherited, or disabled.
+func IsValidRetentionPolicy(readDays, unreadDays, maxEntries int) bool {
+	for _, value := range []int{readDays, unreadDays, maxEntries} {
+		if value < model.RetentionNever {
+			return false
+		}
+	}
+	return true
+}
+
+// IsValidPEMCertificates verifies if the value contains only PEM encoded certificates.
+func IsValidPEMCertificates(data string) bool {
+	rest := []byte(data)
+	found := false
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+
+		if block.Type != "CERTIFICATE" {
+			return false
+		}
+
+		if _, err := x509.ParseCertificate(block.Bytes); err != nil {
+			return false
+		}
+		found = true
+	}
+	return found && len(strings.TrimSpace(string(rest))) == 0
+}
+
+// IsValidPEMPrivateKey verifies if the value is a PEM encoded private key.
+func IsValidPEMPrivateKey(data string) bool {
+	block, rest := pem.Decode([]byte(data))
+	return block != nil && strings.HasSuffix(block.Type, "PRIVATE KEY") && len(strings.TrimSpace(string(rest))) == 0
+}
+
+// IsValidTLSKeyPair verifies if the private key matches the client certificate.
+func IsValidTLSKeyPair(certificate, key string) bool {
+	_, err := tls.X509KeyPair([]byte(certificate), []byte(key))
+	return err == nil
+}
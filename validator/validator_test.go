@@ -4,7 +4,20 @@
 
 package validator // import "miniflux.app/validator"
 
-import "testing"
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"testing"
+	"time"
+
+	"miniflux.app/config"
+)
 
 func TestIsValidURL(t *testing.T) {
 	scenarios := map[string]bool{
@@ -60,3 +73,156 @@ func TestIsValidRegex(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateFilterRules(t *testing.T) {
+	for _, rules := range []string{"", "(?i)example", "#ad", "#!rules\ntitle contains example\nauthor equals bot"} {
+		if err := ValidateFilterRules(rules, "error"); err != nil {
+			t.Errorf(`The rules %q should be valid: %v`, rules, err.String())
+		}
+	}
+
+	err := ValidateFilterRules("#!rules\ntitle contains bot and", "error.line.%d.%s")
+	if err == nil {
+		t.Fatal(`Invalid rules should be rejected`)
+	}
+
+	if len(err.Args) != 2 || err.Args[0] != 2 {
+		t.Fatalf(`The error should report the second line, got %v`, err.Args)
+	}
+}
+
+func TestIsValidHTTPHeaders(t *testing.T) {
+	scenarios := []struct {
+		headers  map[string]string
+		expected bool
+	}{
+		{nil, true},
+		{map[string]string{"Authorization": "Bearer token", "PRIVATE-TOKEN": "secret"}, true},
+		{map[string]string{"Invalid Name": "value"}, false},
+		{map[string]string{"X-Header": "line\r\nInjected: value"}, false},
+	}
+
+	for _, scenario := range scenarios {
+		result := IsValidHTTPHeaders(scenario.headers)
+		if result != scenario.expected {
+			t.Errorf(`Unexpected result for %v, got %v instead of %v`, scenario.headers, result, scenario.expected)
+		}
+	}
+}
+
+func TestIsValidPEMCertificates(t *testing.T) {
+	certificate, key := generateTestCertificate(t)
+
+	scenarios := map[string]bool{
+		certificate:               true,
+		certificate + certificate: true,
+		certificate + "garbage":   false,
+		key:                       false,
+		"not a certificate":       false,
+		"":                        false,
+	}
+
+	for data, expected := range scenarios {
+		result := IsValidPEMCertificates(data)
+		if result != expected {
+			t.Errorf(`Unexpected result for %q, got %v instead of %v`, data, result, expected)
+		}
+	}
+}
+
+func TestIsValidPEMPrivateKey(t *testing.T) {
+	certificate, key := generateTestCertificate(t)
+
+	scenarios := map[string]bool{
+		key:             true,
+		certificate:     false,
+		"not a key":     false,
+		key + "garbage": false,
+	}
+
+	for data, expected := range scenarios {
+		result := IsValidPEMPrivateKey(data)
+		if result != expected {
+			t.Errorf(`Unexpected result for %q, got %v instead of %v`, data, result, expected)
+		}
+	}
+}
+
+func TestIsValidTLSKeyPair(t *testing.T) {
+	certificate, key := generateTestCertificate(t)
+	_, otherKey := generateTestCertificate(t)
+
+	if !IsValidTLSKeyPair(certificate, key) {
+		t.Error(`The key pair should be valid`)
+	}
+
+	if IsValidTLSKeyPair(certificate, otherKey) {
+		t.Error(`A key that doesn't match the certificate should be invalid`)
+	}
+}
+
+func generateTestCertificate(t *testing.T) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "miniflux"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+
+	certificate, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	privateKey, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificate})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: privateKey}))
+}
+
+func TestIsValidProxyName(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("HTTP_CLIENT_PROXIES", "tor=socks5://127.0.0.1:9050")
+
+	var err error
+	config.Opts, err = config.NewParser().ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	scenarios := map[string]bool{
+		"":        true,
+		"direct":  true,
+		"tor":     true,
+		"unknown": false,
+	}
+
+	for name, expected := range scenarios {
+		result := IsValidProxyName(name)
+		if result != expected {
+			t.Errorf(`Unexpected result for %q, got %v instead of %v`, name, result, expected)
+		}
+	}
+}
+
+func TestIsValidRetentionPolicy(t *testing.T) {
+	if !IsValidRetentionPolicy(0, 2, 50) {
+		t.Error(`Inherited and positive values should be accepted`)
+	}
+
+	if !IsValidRetentionPolicy(-1, -1, -1) {
+		t.Error(`Disabled cleanups should be accepted`)
+	}
+
+	if IsValidRetentionPolicy(0, -5, 0) {
+		t.Error(`Values lower than -1 should be rejected`)
+	}
+}
@@ -0,0 +1,10 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+/*
+Package websub implements the subscriber side of the WebSub protocol.
+
+Specs: https://www.w3.org/TR/websub/
+*/
+package websub // import "miniflux.app/websub"
@@ -0,0 +1,148 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package websub // import "miniflux.app/websub"
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"miniflux.app/config"
+	"miniflux.app/http/request"
+	"miniflux.app/logger"
+	"miniflux.app/model"
+	"miniflux.app/storage"
+
+	"github.com/gorilla/mux"
+)
+
+// Serve declares the public callback used by the hubs.
+//
+// The pushed content is sent to the worker pool with the push function, the
+// refreshes of a feed never run concurrently and respect the per-host limits.
+func Serve(router *mux.Router, store *storage.Storage, push func(job model.Job)) {
+	handler := &handler{store, push}
+	router.HandleFunc("/websub/{token}", handler.verifyIntent).Methods(http.MethodGet).Name("websubVerification")
+	router.HandleFunc("/websub/{token}", handler.receiveContent).Methods(http.MethodPost).Name("websubContent")
+}
+
+// subscriptionStore is the part of the storage used by the callback.
+type subscriptionStore interface {
+	WebSubSubscriptionByCallbackToken(token string) (*model.WebSubSubscription, error)
+	UpdateWebSubSubscriptionState(feedID int64, state string, leaseExpiresAt *time.Time) error
+}
+
+type handler struct {
+	store subscriptionStore
+	push  func(job model.Job)
+}
+
+// verifyIntent answers the verification requests sent by the hubs to confirm our subscription requests.
+func (h *handler) verifyIntent(w http.ResponseWriter, r *http.Request) {
+	token := request.RouteStringParam(r, "token")
+	mode := request.QueryStringParam(r, "hub.mode", "")
+	topic := request.QueryStringParam(r, "hub.topic", "")
+	challenge := request.QueryStringParam(r, "hub.challenge", "")
+
+	subscription, err := h.store.WebSubSubscriptionByCallbackToken(token)
+	if err != nil {
+		logger.Error("[WebSub] %v", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	switch mode {
+	case modeSubscribe:
+		if subscription == nil || subscription.TopicURL != topic || challenge == "" {
+			http.NotFound(w, r)
+			return
+		}
+
+		lease := leaseDuration
+		if seconds, err := strconv.Atoi(request.QueryStringParam(r, "hub.lease_seconds", "")); err == nil && seconds > 0 {
+			lease = time.Duration(seconds) * time.Second
+		}
+
+		leaseExpiresAt := time.Now().Add(lease)
+		if err := h.store.UpdateWebSubSubscriptionState(subscription.FeedID, model.WebSubStateActive, &leaseExpiresAt); err != nil {
+			logger.Error("[WebSub] %v", err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+
+		logger.Info("[WebSub] Feed #%d subscribed to %s until %v", subscription.FeedID, subscription.HubURL, leaseExpiresAt)
+		writeChallenge(w, challenge)
+	case modeUnsubscribe:
+		// We only confirm the removal of the subscriptions we don't know anymore.
+		if subscription != nil || challenge == "" {
+			http.NotFound(w, r)
+			return
+		}
+
+		writeChallenge(w, challenge)
+	case modeDenied:
+		if subscription != nil && subscription.TopicURL == topic {
+			logger.Info("[WebSub] The hub %s denied the subscription of feed #%d: %s",
+				subscription.HubURL,
+				subscription.FeedID,
+				request.QueryStringParam(r, "hub.reason", ""),
+			)
+
+			if err := h.store.UpdateWebSubSubscriptionState(subscription.FeedID, model.WebSubStateDenied, nil); err != nil {
+				logger.Error("[WebSub] %v", err)
+			}
+		}
+
+		w.WriteHeader(http.StatusOK)
+	default:
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+	}
+}
+
+// receiveContent handles the content distribution requests sent by the hubs.
+func (h *handler) receiveContent(w http.ResponseWriter, r *http.Request) {
+	token := request.RouteStringParam(r, "token")
+
+	subscription, err := h.store.WebSubSubscriptionByCallbackToken(token)
+	if err != nil {
+		logger.Error("[WebSub] %v", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	// Tell the hub to stop sending the content of a subscription that doesn't exist anymore.
+	if subscription == nil {
+		http.Error(w, "Gone", http.StatusGone)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, config.Opts.HTTPClientMaxBodySize()))
+	if err != nil {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+
+	// Per the specs, the content with an invalid signature is acknowledged but ignored.
+	if !validSignature(subscription.Secret, r.Header.Get("X-Hub-Signature"), body) {
+		logger.Error("[WebSub] [ClientIP=%s] Invalid signature for feed #%d", request.ClientIP(r), subscription.FeedID)
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	h.push(model.Job{
+		UserID:  subscription.UserID,
+		FeedID:  subscription.FeedID,
+		FeedURL: subscription.TopicURL,
+		Content: string(body),
+	})
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func writeChallenge(w http.ResponseWriter, challenge string) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(challenge))
+}
@@ -0,0 +1,194 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package websub // import "miniflux.app/websub"
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"miniflux.app/config"
+	"miniflux.app/model"
+
+	"github.com/gorilla/mux"
+)
+
+type fakeStore struct {
+	subscription   *model.WebSubSubscription
+	state          string
+	leaseExpiresAt *time.Time
+}
+
+func (f *fakeStore) WebSubSubscriptionByCallbackToken(token string) (*model.WebSubSubscription, error) {
+	if f.subscription != nil && f.subscription.CallbackToken == token {
+		return f.subscription, nil
+	}
+	return nil, nil
+}
+
+func (f *fakeStore) UpdateWebSubSubscriptionState(feedID int64, state string, leaseExpiresAt *time.Time) error {
+	f.state = state
+	f.leaseExpiresAt = leaseExpiresAt
+	return nil
+}
+
+func newTestRouter(t *testing.T, store *fakeStore, jobs *[]model.Job) *mux.Router {
+	var err error
+	os.Clearenv()
+	config.Opts, err = config.NewParser().ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	handler := &handler{store, func(job model.Job) { *jobs = append(*jobs, job) }}
+	router := mux.NewRouter()
+	router.HandleFunc("/websub/{token}", handler.verifyIntent).Methods(http.MethodGet)
+	router.HandleFunc("/websub/{token}", handler.receiveContent).Methods(http.MethodPost)
+	return router
+}
+
+func newTestSubscription() *model.WebSubSubscription {
+	return &model.WebSubSubscription{
+		FeedID:        1,
+		UserID:        2,
+		HubURL:        "https://hub.example.org/",
+		TopicURL:      "https://example.org/feed.xml",
+		Secret:        "secret",
+		CallbackToken: "token",
+		State:         model.WebSubStatePending,
+	}
+}
+
+func TestVerifySubscription(t *testing.T) {
+	store := &fakeStore{subscription: newTestSubscription()}
+	router := newTestRouter(t, store, &[]model.Job{})
+
+	r := httptest.NewRequest(http.MethodGet, "/websub/token?hub.mode=subscribe&hub.topic=https://example.org/feed.xml&hub.challenge=abc&hub.lease_seconds=3600", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+
+	if w.Code != http.StatusOK || w.Body.String() != "abc" {
+		t.Fatalf(`The challenge should be returned, got %d %q`, w.Code, w.Body.String())
+	}
+
+	if store.state != model.WebSubStateActive {
+		t.Fatalf(`The subscription should be active, got %q`, store.state)
+	}
+
+	if lease := time.Until(*store.leaseExpiresAt); lease < 59*time.Minute || lease > time.Hour {
+		t.Fatalf(`The lease should be the one chosen by the hub, got %v`, lease)
+	}
+}
+
+func TestVerifySubscriptionWithDefaultLease(t *testing.T) {
+	store := &fakeStore{subscription: newTestSubscription()}
+	router := newTestRouter(t, store, &[]model.Job{})
+
+	r := httptest.NewRequest(http.MethodGet, "/websub/token?hub.mode=subscribe&hub.topic=https://example.org/feed.xml&hub.challenge=abc", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf(`Unexpected status code, got %d`, w.Code)
+	}
+
+	if lease := time.Until(*store.leaseExpiresAt); lease < leaseDuration-time.Minute || lease > leaseDuration {
+		t.Fatalf(`The default lease should be used, got %v`, lease)
+	}
+}
+
+func TestVerifySubscriptionWithUnknownTopic(t *testing.T) {
+	scenarios := []string{
+		"/websub/token?hub.mode=subscribe&hub.topic=https://example.org/other.xml&hub.challenge=abc",
+		"/websub/unknown?hub.mode=subscribe&hub.topic=https://example.org/feed.xml&hub.challenge=abc",
+		"/websub/token?hub.mode=subscribe&hub.topic=https://example.org/feed.xml",
+	}
+
+	for _, target := range scenarios {
+		store := &fakeStore{subscription: newTestSubscription()}
+		router := newTestRouter(t, store, &[]model.Job{})
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, target, nil))
+
+		if w.Code != http.StatusNotFound {
+			t.Errorf(`The verification of %q should be refused, got %d`, target, w.Code)
+		}
+
+		if store.state != "" {
+			t.Errorf(`The subscription should not be updated for %q`, target)
+		}
+	}
+}
+
+func TestVerifyUnsubscription(t *testing.T) {
+	router := newTestRouter(t, &fakeStore{subscription: newTestSubscription()}, &[]model.Job{})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/websub/token?hub.mode=unsubscribe&hub.topic=https://example.org/feed.xml&hub.challenge=abc", nil))
+	if w.Code != http.StatusNotFound {
+		t.Fatalf(`The removal of an existing subscription should be refused, got %d`, w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/websub/unknown?hub.mode=unsubscribe&hub.topic=https://example.org/feed.xml&hub.challenge=abc", nil))
+	if w.Code != http.StatusOK || w.Body.String() != "abc" {
+		t.Fatalf(`The removal of an unknown subscription should be confirmed, got %d %q`, w.Code, w.Body.String())
+	}
+}
+
+func TestReceiveContent(t *testing.T) {
+	var jobs []model.Job
+	router := newTestRouter(t, &fakeStore{subscription: newTestSubscription()}, &jobs)
+
+	r := httptest.NewRequest(http.MethodPost, "/websub/token", strings.NewReader("Some content"))
+	r.Header.Set("X-Hub-Signature", "sha1=eeb0df41b8c8bff77abb9ef42777f87da7bbd16a")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+
+	if w.Code != http.StatusAccepted {
+		t.Fatalf(`Unexpected status code, got %d`, w.Code)
+	}
+
+	expected := model.Job{UserID: 2, FeedID: 1, FeedURL: "https://example.org/feed.xml", Content: "Some content"}
+	if len(jobs) != 1 || jobs[0] != expected {
+		t.Fatalf(`The content should be sent to the worker pool, got %+v`, jobs)
+	}
+}
+
+func TestReceiveContentWithInvalidSignature(t *testing.T) {
+	for _, signature := range []string{"", "sha1=0000000000000000000000000000000000000000", "md5=eeb0df41b8c8bff77abb9ef42777f87da7bbd16a"} {
+		var jobs []model.Job
+		router := newTestRouter(t, &fakeStore{subscription: newTestSubscription()}, &jobs)
+
+		r := httptest.NewRequest(http.MethodPost, "/websub/token", strings.NewReader("Some content"))
+		r.Header.Set("X-Hub-Signature", signature)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+
+		if w.Code != http.StatusAccepted {
+			t.Errorf(`The content should be acknowledged, got %d`, w.Code)
+		}
+
+		if len(jobs) != 0 {
+			t.Errorf(`The content with the signature %q should be ignored`, signature)
+		}
+	}
+}
+
+func TestReceiveContentOfUnknownSubscription(t *testing.T) {
+	var jobs []model.Job
+	router := newTestRouter(t, &fakeStore{subscription: newTestSubscription()}, &jobs)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/websub/unknown", strings.NewReader("Some content")))
+
+	if w.Code != http.StatusGone || len(jobs) != 0 {
+		t.Fatalf(`The hub should be told to stop sending the content, got %d`, w.Code)
+	}
+}
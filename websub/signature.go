@@ -0,0 +1,47 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package websub // import "miniflux.app/websub"
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"hash"
+	"strings"
+)
+
+// validSignature checks the X-Hub-Signature header sent by the hub with the content.
+// The header is formatted as "method=signature", where method is sha1, sha256, sha384 or sha512.
+func validSignature(secret, header string, body []byte) bool {
+	method, signature, found := strings.Cut(strings.TrimSpace(header), "=")
+	if !found {
+		return false
+	}
+
+	var hashFunc func() hash.Hash
+	switch strings.ToLower(method) {
+	case "sha1":
+		hashFunc = sha1.New
+	case "sha256":
+		hashFunc = sha256.New
+	case "sha384":
+		hashFunc = sha512.New384
+	case "sha512":
+		hashFunc = sha512.New
+	default:
+		return false
+	}
+
+	expected, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(hashFunc, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
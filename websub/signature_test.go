@@ -0,0 +1,35 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package websub // import "miniflux.app/websub"
+
+import "testing"
+
+func TestValidSignature(t *testing.T) {
+	body := []byte("Some content")
+
+	scenarios := map[string]bool{
+		"sha1=eeb0df41b8c8bff77abb9ef42777f87da7bbd16a":                                                                                           true,
+		"SHA256=653de6ea2f798a1056a0faba41476a40af4dbe8e0c13c6262ca707bfc14ad2d8":                                                                 true,
+		"sha384=b93124dd5aad2de939042c24b41fca6329b74569bc57dc16c177e3142dd2084c038b28c6523c878379545b3e589d882f":                                 true,
+		"sha512=17529bd1698fd13956639c31f2e970a46ff482a6b9bef83bb2f7a70b0abc7cdb9f3693e1a7bf9788af33f3f84057fab597ee94b2a59485b230d58c0b84d35ca4": true,
+		"sha1=0000000000000000000000000000000000000000":                                                                                           false,
+		"md5=eeb0df41b8c8bff77abb9ef42777f87da7bbd16a":                                                                                            false,
+		"sha1=not hexadecimal":                     false,
+		"eeb0df41b8c8bff77abb9ef42777f87da7bbd16a": false,
+		"": false,
+	}
+
+	for header, expected := range scenarios {
+		if result := validSignature("secret", header, body); result != expected {
+			t.Errorf(`Unexpected result for %q, got %v instead of %v`, header, result, expected)
+		}
+	}
+}
+
+func TestInvalidSignatureWithAnotherSecret(t *testing.T) {
+	if validSignature("another secret", "sha1=eeb0df41b8c8bff77abb9ef42777f87da7bbd16a", []byte("Some content")) {
+		t.Error(`The signature should not be valid with another secret`)
+	}
+}
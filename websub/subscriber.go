@@ -0,0 +1,143 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package websub // import "miniflux.app/websub"
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"miniflux.app/config"
+	"miniflux.app/http/client"
+	"miniflux.app/logger"
+	"miniflux.app/model"
+	"miniflux.app/storage"
+)
+
+const (
+	modeSubscribe   = "subscribe"
+	modeUnsubscribe = "unsubscribe"
+	modeDenied      = "denied"
+
+	// leaseDuration is the lease requested to the hubs, they are free to choose another one.
+	leaseDuration = 10 * 24 * time.Hour
+
+	// renewalMargin is the delay before the end of the lease to renew a subscription.
+	renewalMargin = 24 * time.Hour
+
+	// pendingTimeout is the delay after which a subscription not verified by the hub is requested again.
+	pendingTimeout = 24 * time.Hour
+)
+
+// CallbackURL returns the public URL used by the hub to verify the subscription and to push the content.
+func CallbackURL(token string) string {
+	return config.Opts.BaseURL() + "/websub/" + token
+}
+
+// Subscribe subscribes the feed to the hub advertised in the feed document.
+//
+// Nothing is sent when the subscription to the same hub and topic is active or pending, the leases and the pending
+// requests are renewed by the scheduler. A denied or expired subscription is requested again.
+// The previous subscription is removed when the feed does not advertise a hub anymore.
+func Subscribe(store *storage.Storage, userID, feedID int64, hubURL, topicURL string) {
+	if !config.Opts.HasWebSub() {
+		return
+	}
+
+	current, err := store.WebSubSubscription(feedID)
+	if err != nil {
+		logger.Error("[WebSub] Feed #%d: %v", feedID, err)
+		return
+	}
+
+	if hubURL == "" || topicURL == "" {
+		if current != nil {
+			unsubscribe(store, current)
+		}
+		return
+	}
+
+	sameTopic := current != nil && current.HubURL == hubURL && current.TopicURL == topicURL
+	if sameTopic && (current.IsActive() || current.State == model.WebSubStatePending) {
+		return
+	}
+
+	if current != nil && !sameTopic {
+		unsubscribe(store, current)
+	}
+
+	subscription := model.NewWebSubSubscription(userID, feedID, hubURL, topicURL)
+
+	// Some hubs verify the intent before answering the subscription request.
+	if err := store.SaveWebSubSubscription(subscription); err != nil {
+		logger.Error("[WebSub] Feed #%d: %v", feedID, err)
+		return
+	}
+
+	if err := sendRequest(subscription, modeSubscribe); err != nil {
+		logger.Error("[WebSub] Feed #%d: %v", feedID, err)
+	}
+}
+
+// RenewSubscriptions requests the subscriptions with an expiring lease again,
+// as well as the subscriptions never verified by the hub.
+func RenewSubscriptions(store *storage.Storage) {
+	subscriptions, err := store.WebSubSubscriptionsToRenew(time.Now().Add(renewalMargin), pendingTimeout)
+	if err != nil {
+		logger.Error("[WebSub] %v", err)
+		return
+	}
+
+	for _, subscription := range subscriptions {
+		logger.Debug("[WebSub] Renewing subscription of feed #%d to %s", subscription.FeedID, subscription.HubURL)
+
+		// Saving the subscription updates the request date, failing hubs are retried later.
+		if err := store.SaveWebSubSubscription(subscription); err != nil {
+			logger.Error("[WebSub] Feed #%d: %v", subscription.FeedID, err)
+			continue
+		}
+
+		if err := sendRequest(subscription, modeSubscribe); err != nil {
+			logger.Error("[WebSub] Feed #%d: %v", subscription.FeedID, err)
+		}
+	}
+}
+
+func unsubscribe(store *storage.Storage, subscription *model.WebSubSubscription) {
+	if err := store.RemoveWebSubSubscription(subscription.FeedID); err != nil {
+		logger.Error("[WebSub] Feed #%d: %v", subscription.FeedID, err)
+		return
+	}
+
+	// The hub confirms the request with the callback of the removed subscription.
+	if err := sendRequest(subscription, modeUnsubscribe); err != nil {
+		logger.Debug("[WebSub] Feed #%d: %v", subscription.FeedID, err)
+	}
+}
+
+func sendRequest(subscription *model.WebSubSubscription, mode string) error {
+	values := url.Values{}
+	values.Set("hub.callback", CallbackURL(subscription.CallbackToken))
+	values.Set("hub.mode", mode)
+	values.Set("hub.topic", subscription.TopicURL)
+
+	if mode == modeSubscribe {
+		values.Set("hub.secret", subscription.Secret)
+		values.Set("hub.lease_seconds", strconv.Itoa(int(leaseDuration.Seconds())))
+	}
+
+	request := client.NewClientWithConfig(subscription.HubURL, config.Opts)
+	response, err := request.PostForm(values)
+	if err != nil {
+		return fmt.Errorf("unable to send %s request to %s: %v", mode, subscription.HubURL, err)
+	}
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return fmt.Errorf("the hub %s refused the %s request with the status code %d", subscription.HubURL, mode, response.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,285 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package worker // import "miniflux.app/worker"
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"miniflux.app/config"
+	"miniflux.app/metric"
+	"miniflux.app/model"
+	"miniflux.app/url"
+)
+
+type queuedJob struct {
+	job      model.Job
+	sequence uint64
+}
+
+// hostQueue holds the pending jobs, one lane per priority, and the in-flight requests of a single host.
+type hostQueue struct {
+	lanes         [numPriorities][]queuedJob
+	active        int
+	lastRequestAt time.Time
+}
+
+func (q *hostQueue) len() int {
+	length := 0
+	for _, lane := range q.lanes {
+		length += len(lane)
+	}
+	return length
+}
+
+// head returns the job to run first for this host: the oldest job of the highest priority lane.
+// The jobs of the running feeds are skipped.
+func (q *hostQueue) head(running map[int64]bool) (queuedJob, Priority, int, bool) {
+	for priority, lane := range q.lanes {
+		for index, queued := range lane {
+			if !running[queued.job.FeedID] {
+				return queued, Priority(priority), index, true
+			}
+		}
+	}
+	return queuedJob{}, 0, 0, false
+}
+
+func (q *hostQueue) index(priority Priority, feedID int64) int {
+	for i, queued := range q.lanes[priority] {
+		if queued.job.FeedID == feedID {
+			return i
+		}
+	}
+	return -1
+}
+
+func (q *hostQueue) remove(priority Priority, feedID int64) (queuedJob, bool) {
+	lane := q.lanes[priority]
+	for i, queued := range lane {
+		if queued.job.FeedID == feedID {
+			q.lanes[priority] = append(lane[:i:i], lane[i+1:]...)
+			return queued, true
+		}
+	}
+	return queuedJob{}, false
+}
+
+// queuedFeed locates the pending job of a feed.
+type queuedFeed struct {
+	host     string
+	priority Priority
+}
+
+// hostScheduler dispatches jobs without sending more than maxConnections
+// concurrent requests to the same host, and waits at least minDelay between
+// two requests to the same host.
+//
+// Interactive jobs are dispatched before background jobs, a feed is queued only once
+// and its job waits while the feed is being refreshed.
+// A host doesn't hold more than maxQueueSize pending background jobs, a slow host
+// doesn't build up an unbounded backlog.
+type hostScheduler struct {
+	mu             sync.Mutex
+	maxConnections int
+	minDelay       time.Duration
+	maxQueueSize   int
+	sequence       uint64
+	hosts          map[string]*hostQueue
+	feeds          map[int64]queuedFeed
+	running        map[int64]bool
+	lengths        [numPriorities]int
+	wakeup         chan struct{}
+}
+
+func newHostScheduler(maxConnections int, minDelay time.Duration, maxQueueSize int) *hostScheduler {
+	return &hostScheduler{
+		maxConnections: maxConnections,
+		minDelay:       minDelay,
+		maxQueueSize:   maxQueueSize,
+		hosts:          make(map[string]*hostQueue),
+		feeds:          make(map[int64]queuedFeed),
+		running:        make(map[int64]bool),
+		wakeup:         make(chan struct{}, 1),
+	}
+}
+
+// enqueue adds a job to the queue of its host, it returns false when the job is dropped.
+//
+// When the feed is already waiting, the job is merged with the pending one,
+// which is moved to the interactive lane if needed. A background job is dropped
+// when the queue of its host is full.
+func (s *hostScheduler) enqueue(job model.Job, priority Priority) bool {
+	s.mu.Lock()
+	defer s.notify()
+	defer s.mu.Unlock()
+
+	if pending, found := s.feeds[job.FeedID]; found {
+		queue := s.hosts[pending.host]
+		index := queue.index(pending.priority, job.FeedID)
+		if index < 0 {
+			return true
+		}
+
+		// A pushed document merged with another document or with a regular refresh
+		// becomes a regular refresh, none of the pushed entries is lost.
+		if queue.lanes[pending.priority][index].job.Content != job.Content {
+			queue.lanes[pending.priority][index].job.Content = ""
+		}
+
+		if priority >= pending.priority {
+			return true
+		}
+
+		if queued, found := queue.remove(pending.priority, job.FeedID); found {
+			queue.lanes[priority] = append(queue.lanes[priority], queued)
+			s.feeds[job.FeedID] = queuedFeed{host: pending.host, priority: priority}
+			s.updateQueueLength(pending.priority, -1)
+			s.updateQueueLength(priority, 1)
+		}
+		return true
+	}
+
+	host := jobHost(job)
+	queue, found := s.hosts[host]
+	if !found {
+		queue = &hostQueue{}
+		s.hosts[host] = queue
+	}
+
+	if priority == PriorityBackground && s.maxQueueSize > 0 && len(queue.lanes[priority]) >= s.maxQueueSize {
+		return false
+	}
+
+	s.sequence++
+	queue.lanes[priority] = append(queue.lanes[priority], queuedJob{job: job, sequence: s.sequence})
+	s.feeds[job.FeedID] = queuedFeed{host: host, priority: priority}
+	s.updateQueueLength(priority, 1)
+	updateHostQueueDepth(host, queue.len())
+	return true
+}
+
+// next returns the job that is allowed to run now, with the highest priority
+// and the oldest first, and reserves a connection slot for its host. When no
+// job is ready, it returns the delay after which a job becomes ready, or zero
+// if we must wait for a new job or a free connection slot.
+func (s *hostScheduler) next(now time.Time) (job model.Job, wait time.Duration, ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var selectedHost string
+	var selectedQueue *hostQueue
+	var selectedJob queuedJob
+	var selectedPriority Priority
+	var selectedIndex int
+
+	for host, queue := range s.hosts {
+		head, priority, index, found := queue.head(s.running)
+		if !found {
+			if queue.len() == 0 && queue.active <= 0 && now.Sub(queue.lastRequestAt) >= s.minDelay {
+				delete(s.hosts, host)
+				deleteHostQueueDepth(host)
+			}
+			continue
+		}
+
+		if s.maxConnections > 0 && queue.active >= s.maxConnections {
+			continue
+		}
+
+		if s.minDelay > 0 && !queue.lastRequestAt.IsZero() {
+			if remaining := queue.lastRequestAt.Add(s.minDelay).Sub(now); remaining > 0 {
+				if wait == 0 || remaining < wait {
+					wait = remaining
+				}
+				continue
+			}
+		}
+
+		if selectedQueue == nil ||
+			priority < selectedPriority ||
+			(priority == selectedPriority && head.sequence < selectedJob.sequence) {
+			selectedHost = host
+			selectedQueue = queue
+			selectedJob = head
+			selectedPriority = priority
+			selectedIndex = index
+		}
+	}
+
+	if selectedQueue == nil {
+		return job, wait, false
+	}
+
+	lane := selectedQueue.lanes[selectedPriority]
+	selectedQueue.lanes[selectedPriority] = append(lane[:selectedIndex:selectedIndex], lane[selectedIndex+1:]...)
+	selectedQueue.active++
+	selectedQueue.lastRequestAt = now
+	delete(s.feeds, selectedJob.job.FeedID)
+	s.running[selectedJob.job.FeedID] = true
+	s.updateQueueLength(selectedPriority, -1)
+	updateHostQueueDepth(selectedHost, selectedQueue.len())
+
+	return selectedJob.job, 0, true
+}
+
+// release frees the connection slot reserved for the given job.
+func (s *hostScheduler) release(job model.Job) {
+	host := jobHost(job)
+
+	s.mu.Lock()
+	delete(s.running, job.FeedID)
+	if queue, found := s.hosts[host]; found {
+		queue.active--
+
+		// Keep the host around while the delay is running to remember the last request time.
+		if queue.active <= 0 && queue.len() == 0 && time.Since(queue.lastRequestAt) >= s.minDelay {
+			delete(s.hosts, host)
+			deleteHostQueueDepth(host)
+		}
+	}
+	s.mu.Unlock()
+
+	s.notify()
+}
+
+// length returns the number of pending jobs with the given priority.
+func (s *hostScheduler) length(priority Priority) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lengths[priority]
+}
+
+func (s *hostScheduler) notify() {
+	select {
+	case s.wakeup <- struct{}{}:
+	default:
+	}
+}
+
+func (s *hostScheduler) updateQueueLength(priority Priority, delta int) {
+	s.lengths[priority] += delta
+
+	if config.Opts.HasMetricsCollector() {
+		metric.WorkerQueueLength.WithLabelValues(priority.String()).Set(float64(s.lengths[priority]))
+	}
+}
+
+func jobHost(job model.Job) string {
+	return strings.ToLower(url.Domain(job.FeedURL))
+}
+
+func updateHostQueueDepth(host string, depth int) {
+	if config.Opts.HasMetricsCollector() {
+		metric.WorkerHostQueueDepth.WithLabelValues(host).Set(float64(depth))
+	}
+}
+
+func deleteHostQueueDepth(host string) {
+	if config.Opts.HasMetricsCollector() {
+		metric.WorkerHostQueueDepth.DeleteLabelValues(host)
+	}
+}
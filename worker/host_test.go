@@ -0,0 +1,201 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package worker // import "miniflux.app/worker"
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"miniflux.app/config"
+	"miniflux.app/model"
+)
+
+func setupConfig(t *testing.T) {
+	var err error
+	os.Clearenv()
+	parser := config.NewParser()
+	config.Opts, err = parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+}
+
+func TestHostSchedulerMaxConnections(t *testing.T) {
+	setupConfig(t)
+
+	scheduler := newHostScheduler(1, 0, 0)
+	scheduler.enqueue(model.Job{FeedID: 1, FeedURL: "https://example.org/feed1.xml"}, PriorityBackground)
+	scheduler.enqueue(model.Job{FeedID: 2, FeedURL: "https://EXAMPLE.org/feed2.xml"}, PriorityBackground)
+	scheduler.enqueue(model.Job{FeedID: 3, FeedURL: "https://example.com/feed.xml"}, PriorityBackground)
+
+	now := time.Now()
+	job, _, ok := scheduler.next(now)
+	if !ok || job.FeedID != 1 {
+		t.Fatalf(`Unexpected job, got %+v`, job)
+	}
+
+	job, _, ok = scheduler.next(now)
+	if !ok || job.FeedID != 3 {
+		t.Fatalf(`The second job for the same host must wait, got %+v`, job)
+	}
+
+	if _, _, ok = scheduler.next(now); ok {
+		t.Fatal(`No job should be ready while the host is busy`)
+	}
+
+	scheduler.release(model.Job{FeedID: 1, FeedURL: "https://example.org/feed1.xml"})
+
+	job, _, ok = scheduler.next(now)
+	if !ok || job.FeedID != 2 {
+		t.Fatalf(`The job should be ready once the connection is released, got %+v`, job)
+	}
+}
+
+func TestHostSchedulerMinDelay(t *testing.T) {
+	setupConfig(t)
+
+	scheduler := newHostScheduler(0, time.Minute, 0)
+	scheduler.enqueue(model.Job{FeedID: 1, FeedURL: "https://example.org/feed1.xml"}, PriorityBackground)
+	scheduler.enqueue(model.Job{FeedID: 2, FeedURL: "https://example.org/feed2.xml"}, PriorityBackground)
+
+	now := time.Now()
+	if _, _, ok := scheduler.next(now); !ok {
+		t.Fatal(`The first job should be ready`)
+	}
+
+	_, wait, ok := scheduler.next(now.Add(10 * time.Second))
+	if ok {
+		t.Fatal(`The second job should wait for the delay`)
+	}
+
+	if wait != 50*time.Second {
+		t.Fatalf(`Unexpected wait duration, got %v`, wait)
+	}
+
+	job, _, ok := scheduler.next(now.Add(time.Minute))
+	if !ok || job.FeedID != 2 {
+		t.Fatalf(`The second job should be ready after the delay, got %+v`, job)
+	}
+}
+
+func TestHostSchedulerPriorities(t *testing.T) {
+	setupConfig(t)
+
+	scheduler := newHostScheduler(0, 0, 0)
+	scheduler.enqueue(model.Job{FeedID: 1, FeedURL: "https://example.org/feed.xml"}, PriorityBackground)
+	scheduler.enqueue(model.Job{FeedID: 2, FeedURL: "https://example.org/feed.xml"}, PriorityBackground)
+	scheduler.enqueue(model.Job{FeedID: 3, FeedURL: "https://example.com/feed.xml"}, PriorityInteractive)
+	scheduler.enqueue(model.Job{FeedID: 4, FeedURL: "https://example.org/feed.xml"}, PriorityInteractive)
+
+	if length := scheduler.length(PriorityInteractive); length != 2 {
+		t.Fatalf(`Unexpected interactive queue length, got %d`, length)
+	}
+
+	now := time.Now()
+	for _, expected := range []int64{3, 4, 1, 2} {
+		job, _, ok := scheduler.next(now)
+		if !ok || job.FeedID != expected {
+			t.Fatalf(`Unexpected job, got %+v instead of feed #%d`, job, expected)
+		}
+	}
+
+	if length := scheduler.length(PriorityBackground); length != 0 {
+		t.Fatalf(`Unexpected background queue length, got %d`, length)
+	}
+}
+
+func TestHostSchedulerCoalesceDuplicateJobs(t *testing.T) {
+	setupConfig(t)
+
+	scheduler := newHostScheduler(0, 0, 0)
+	scheduler.enqueue(model.Job{FeedID: 1, FeedURL: "https://example.org/feed1.xml"}, PriorityBackground)
+	scheduler.enqueue(model.Job{FeedID: 2, FeedURL: "https://example.org/feed2.xml"}, PriorityBackground)
+	scheduler.enqueue(model.Job{FeedID: 1, FeedURL: "https://example.org/feed1.xml"}, PriorityBackground)
+	scheduler.enqueue(model.Job{FeedID: 2, FeedURL: "https://example.org/feed2.xml"}, PriorityInteractive)
+	scheduler.enqueue(model.Job{FeedID: 2, FeedURL: "https://example.org/feed2.xml"}, PriorityBackground)
+
+	if length := scheduler.length(PriorityBackground); length != 1 {
+		t.Fatalf(`Unexpected background queue length, got %d`, length)
+	}
+
+	if length := scheduler.length(PriorityInteractive); length != 1 {
+		t.Fatalf(`Unexpected interactive queue length, got %d`, length)
+	}
+
+	now := time.Now()
+	for _, expected := range []int64{2, 1} {
+		job, _, ok := scheduler.next(now)
+		if !ok || job.FeedID != expected {
+			t.Fatalf(`Unexpected job, got %+v instead of feed #%d`, job, expected)
+		}
+	}
+
+	if _, _, ok := scheduler.next(now); ok {
+		t.Fatal(`The duplicate jobs should be merged`)
+	}
+
+	// A feed can be queued again once its job is dispatched, but waits until the refresh is done.
+	scheduler.enqueue(model.Job{FeedID: 1, FeedURL: "https://example.org/feed1.xml"}, PriorityBackground)
+	if job, _, ok := scheduler.next(now); ok {
+		t.Fatalf(`The feed is still being refreshed, got %+v`, job)
+	}
+
+	scheduler.release(model.Job{FeedID: 1, FeedURL: "https://example.org/feed1.xml"})
+	if job, _, ok := scheduler.next(now); !ok || job.FeedID != 1 {
+		t.Fatalf(`Unexpected job, got %+v`, job)
+	}
+}
+
+func TestHostSchedulerMergePushedContent(t *testing.T) {
+	setupConfig(t)
+
+	scheduler := newHostScheduler(0, 0, 0)
+	scheduler.enqueue(model.Job{FeedID: 1, FeedURL: "https://example.org/feed.xml", Content: "first"}, PriorityInteractive)
+	scheduler.enqueue(model.Job{FeedID: 1, FeedURL: "https://example.org/feed.xml", Content: "first"}, PriorityInteractive)
+	scheduler.enqueue(model.Job{FeedID: 2, FeedURL: "https://example.org/feed.xml", Content: "first"}, PriorityInteractive)
+	scheduler.enqueue(model.Job{FeedID: 2, FeedURL: "https://example.org/feed.xml", Content: "second"}, PriorityInteractive)
+	scheduler.enqueue(model.Job{FeedID: 3, FeedURL: "https://example.org/feed.xml", Content: "first"}, PriorityInteractive)
+	scheduler.enqueue(model.Job{FeedID: 3, FeedURL: "https://example.org/feed.xml"}, PriorityBackground)
+
+	now := time.Now()
+	for _, expected := range []model.Job{
+		{FeedID: 1, FeedURL: "https://example.org/feed.xml", Content: "first"},
+		{FeedID: 2, FeedURL: "https://example.org/feed.xml"},
+		{FeedID: 3, FeedURL: "https://example.org/feed.xml"},
+	} {
+		job, _, ok := scheduler.next(now)
+		if !ok || job != expected {
+			t.Fatalf(`Unexpected job, got %+v instead of %+v`, job, expected)
+		}
+	}
+}
+
+func TestHostSchedulerMaxQueueSize(t *testing.T) {
+	setupConfig(t)
+
+	scheduler := newHostScheduler(0, 0, 2)
+	for feedID := int64(1); feedID <= 2; feedID++ {
+		if !scheduler.enqueue(model.Job{FeedID: feedID, FeedURL: "https://example.org/feed.xml"}, PriorityBackground) {
+			t.Fatalf(`The job of feed #%d should be queued`, feedID)
+		}
+	}
+
+	if scheduler.enqueue(model.Job{FeedID: 3, FeedURL: "https://example.org/feed.xml"}, PriorityBackground) {
+		t.Fatal(`A background job should be dropped when the host queue is full`)
+	}
+
+	if !scheduler.enqueue(model.Job{FeedID: 4, FeedURL: "https://example.com/feed.xml"}, PriorityBackground) {
+		t.Fatal(`The queue of another host should not be affected`)
+	}
+
+	if !scheduler.enqueue(model.Job{FeedID: 5, FeedURL: "https://example.org/feed.xml"}, PriorityInteractive) {
+		t.Fatal(`An interactive job should never be dropped`)
+	}
+
+	if length := scheduler.length(PriorityBackground); length != 3 {
+		t.Fatalf(`Unexpected background queue length, got %d`, length)
+	}
+}
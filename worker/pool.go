@@ -5,32 +5,100 @@
 package worker // import "miniflux.app/worker"
 
 import (
+	"time"
+
+	"miniflux.app/config"
+	"miniflux.app/logger"
 	"miniflux.app/model"
 	"miniflux.app/storage"
 )
 
+// Priority defines the order in which the jobs are dispatched to the workers.
+type Priority int
+
+// Job priorities, from the most urgent to the least urgent.
+const (
+	// PriorityInteractive is used for the refreshes requested by users.
+	PriorityInteractive Priority = iota
+	// PriorityBackground is used for the refreshes planned by the scheduler.
+	PriorityBackground
+
+	numPriorities = 2
+)
+
+func (p Priority) String() string {
+	switch p {
+	case PriorityInteractive:
+		return "interactive"
+	default:
+		return "background"
+	}
+}
+
 // Pool handles a pool of workers.
 type Pool struct {
+	store *storage.Storage
 	queue chan model.Job
+	hosts *hostScheduler
 }
 
-// Push send a list of jobs to the queue.
-func (p *Pool) Push(jobs model.JobList) {
+// Push adds a list of jobs to the queue without blocking.
+// The jobs of feeds already waiting in the queue are merged with the pending ones.
+//
+// The background jobs of a host with a full queue are dropped and the claim of their
+// feeds is released, the scheduler picks them again during the next polling interval.
+func (p *Pool) Push(jobs model.JobList, priority Priority) {
+	dropped := 0
 	for _, job := range jobs {
-		p.queue <- job
+		if p.hosts.enqueue(job, priority) {
+			continue
+		}
+
+		dropped++
+		if err := p.store.ReleaseFeedClaim(job.FeedID, config.Opts.NodeName()); err != nil {
+			logger.Error("[Worker] %v", err)
+		}
+	}
+
+	if dropped > 0 {
+		logger.Info("[Worker] %d %s jobs dropped because the queue of their host is full", dropped, priority)
+	}
+}
+
+// dispatch sends the jobs to the workers while respecting the per-host limits.
+func (p *Pool) dispatch() {
+	for {
+		job, wait, ok := p.hosts.next(time.Now())
+		if ok {
+			p.queue <- job
+			continue
+		}
+
+		if wait > 0 {
+			select {
+			case <-p.hosts.wakeup:
+			case <-time.After(wait):
+			}
+		} else {
+			<-p.hosts.wakeup
+		}
 	}
 }
 
 // NewPool creates a pool of background workers.
 func NewPool(store *storage.Storage, nbWorkers int) *Pool {
 	workerPool := &Pool{
+		store: store,
 		queue: make(chan model.Job),
+		hosts: newHostScheduler(config.Opts.WorkerHostMaxConnections(), config.Opts.WorkerHostRequestDelay(), config.Opts.WorkerHostMaxQueueSize()),
 	}
 
 	for i := 0; i < nbWorkers; i++ {
-		worker := &Worker{id: i, store: store}
+		worker := &Worker{id: i, store: store, hosts: workerPool.hosts}
 		go worker.Run(workerPool.queue)
 	}
 
+	go workerPool.dispatch()
+
 	return workerPool
 }
@@ -19,6 +19,7 @@ import (
 type Worker struct {
 	id    int
 	store *storage.Storage
+	hosts *hostScheduler
 }
 
 // Run wait for a job and refresh the given feed.
@@ -30,7 +31,13 @@ func (w *Worker) Run(c chan model.Job) {
 		logger.Debug("[Worker #%d] Received feed #%d for user #%d", w.id, job.FeedID, job.UserID)
 
 		startTime := time.Now()
-		refreshErr := feedHandler.RefreshFeed(w.store, job.UserID, job.FeedID)
+		var refreshErr error
+		if job.Content != "" {
+			refreshErr = feedHandler.RefreshFeedWithContent(w.store, job.UserID, job.FeedID, job.Content)
+		} else {
+			refreshErr = feedHandler.RefreshFeed(w.store, job.UserID, job.FeedID)
+		}
+		w.hosts.release(job)
 
 		if config.Opts.HasMetricsCollector() {
 			status := "success"